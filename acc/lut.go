@@ -0,0 +1,260 @@
+package acc
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Channel 表示 LUT 的色彩通道。
+type Channel int
+
+const (
+	ChannelRed Channel = iota
+	ChannelGreen
+	ChannelBlue
+)
+
+// String 回傳通道的英文名稱，用於 CSV/JSON 欄位與介面顯示。
+func (c Channel) String() string {
+	switch c {
+	case ChannelRed:
+		return "red"
+	case ChannelGreen:
+		return "green"
+	case ChannelBlue:
+		return "blue"
+	default:
+		return fmt.Sprintf("channel%d", int(c))
+	}
+}
+
+// LUT 儲存 R/G/B 三個通道的 ACC/Gamma 對照表。
+type LUT struct {
+	Bits  int      `json:"bits"`  // 每筆資料的有效位元數
+	Red   []uint16 `json:"red"`   // 紅色通道
+	Green []uint16 `json:"green"` // 綠色通道
+	Blue  []uint16 `json:"blue"`  // 藍色通道
+}
+
+// NewLinearLUT 建立指定筆數與位元數的線性 LUT，作為編輯的起點。
+func NewLinearLUT(entries, bits int) *LUT {
+	lut := &LUT{
+		Bits:  bits,
+		Red:   make([]uint16, entries),
+		Green: make([]uint16, entries),
+		Blue:  make([]uint16, entries),
+	}
+	if entries <= 1 {
+		return lut
+	}
+	max := float64(lut.MaxValue())
+	for i := 0; i < entries; i++ {
+		v := uint16(math.Round(float64(i) * max / float64(entries-1)))
+		lut.Red[i], lut.Green[i], lut.Blue[i] = v, v, v
+	}
+	return lut
+}
+
+// Scale 回傳換算成指定位元數的 LUT（依滿刻度比例四捨五入）；位元數相同時回傳原 LUT。
+func (l *LUT) Scale(bits int) *LUT {
+	if l.Bits == bits {
+		return l
+	}
+	scaled := &LUT{Bits: bits}
+	from, to := float64(l.MaxValue()), float64(scaled.MaxValue())
+	convert := func(values []uint16) []uint16 {
+		out := make([]uint16, len(values))
+		for i, v := range values {
+			out[i] = uint16(math.Round(float64(v) * to / from))
+		}
+		return out
+	}
+	scaled.Red, scaled.Green, scaled.Blue = convert(l.Red), convert(l.Green), convert(l.Blue)
+	return scaled
+}
+
+// Entries 回傳每個通道的資料筆數。
+func (l *LUT) Entries() int {
+	return len(l.Red)
+}
+
+// MaxValue 回傳依位元數可表示的最大值。
+func (l *LUT) MaxValue() uint16 {
+	if l.Bits <= 0 || l.Bits >= 16 {
+		return math.MaxUint16
+	}
+	return uint16(1<<l.Bits - 1)
+}
+
+// Channel 依通道回傳對應的資料切片。
+func (l *LUT) Channel(c Channel) []uint16 {
+	switch c {
+	case ChannelRed:
+		return l.Red
+	case ChannelGreen:
+		return l.Green
+	case ChannelBlue:
+		return l.Blue
+	default:
+		return nil
+	}
+}
+
+// Validate 檢查三個通道長度一致且數值未超出位元數範圍。
+func (l *LUT) Validate() error {
+	if l == nil {
+		return errors.New("acc: lut is nil")
+	}
+	if l.Bits <= 0 || l.Bits > 16 {
+		return fmt.Errorf("acc: invalid bit depth %d", l.Bits)
+	}
+	if len(l.Red) == 0 {
+		return errors.New("acc: lut is empty")
+	}
+	if len(l.Green) != len(l.Red) || len(l.Blue) != len(l.Red) {
+		return fmt.Errorf("acc: channel length mismatch (r=%d g=%d b=%d)", len(l.Red), len(l.Green), len(l.Blue))
+	}
+	max := l.MaxValue()
+	for _, c := range []Channel{ChannelRed, ChannelGreen, ChannelBlue} {
+		for i, v := range l.Channel(c) {
+			if v > max {
+				return fmt.Errorf("acc: %s[%d]=%d exceeds %d-bit range", c, i, v, l.Bits)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteCSV 以 index,red,green,blue 欄位輸出 CSV。
+func (l *LUT) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"index", "red", "green", "blue"}); err != nil {
+		return err
+	}
+	for i := 0; i < l.Entries(); i++ {
+		record := []string{
+			strconv.Itoa(i),
+			strconv.Itoa(int(l.Red[i])),
+			strconv.Itoa(int(l.Green[i])),
+			strconv.Itoa(int(l.Blue[i])),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV 解析 WriteCSV 產生的格式；bits 指定資料位元數。
+func ReadCSV(r io.Reader, bits int) (*LUT, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	lut := &LUT{Bits: bits}
+	for line, record := range records {
+		if len(record) < 4 {
+			return nil, fmt.Errorf("acc: csv line %d: expected 4 columns", line+1)
+		}
+		if line == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "index") {
+			// 第一列為標題時略過。
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("acc: csv line %d: %w", line+1, err)
+		}
+		if index != lut.Entries() {
+			return nil, fmt.Errorf("acc: csv line %d: index %d out of order", line+1, index)
+		}
+		values := make([]uint16, 3)
+		for i := range values {
+			v, err := strconv.ParseUint(strings.TrimSpace(record[i+1]), 0, 16)
+			if err != nil {
+				return nil, fmt.Errorf("acc: csv line %d column %d: %w", line+1, i+2, err)
+			}
+			values[i] = uint16(v)
+		}
+		lut.Red = append(lut.Red, values[0])
+		lut.Green = append(lut.Green, values[1])
+		lut.Blue = append(lut.Blue, values[2])
+	}
+
+	if err := lut.Validate(); err != nil {
+		return nil, err
+	}
+	return lut, nil
+}
+
+// WriteJSON 以縮排 JSON 輸出 LUT。
+func (l *LUT) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
+
+// ReadJSON 解析 JSON 格式的 LUT 並檢查內容。
+func ReadJSON(r io.Reader) (*LUT, error) {
+	var lut LUT
+	if err := json.NewDecoder(r).Decode(&lut); err != nil {
+		return nil, err
+	}
+	if err := lut.Validate(); err != nil {
+		return nil, err
+	}
+	return &lut, nil
+}
+
+// LoadFile 依副檔名（.csv 或 .json）讀取 LUT 檔案。
+func LoadFile(path string, bits int) (*LUT, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadCSV(f, bits)
+	case ".json":
+		return ReadJSON(f)
+	default:
+		return nil, fmt.Errorf("acc: unsupported file type %q", filepath.Ext(path))
+	}
+}
+
+// SaveFile 依副檔名（.csv 或 .json）輸出 LUT 檔案。
+func (l *LUT) SaveFile(path string) error {
+	var write func(io.Writer) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		write = l.WriteCSV
+	case ".json":
+		write = l.WriteJSON
+	default:
+		return fmt.Errorf("acc: unsupported file type %q", filepath.Ext(path))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
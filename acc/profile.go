@@ -0,0 +1,255 @@
+package acc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// ErrVerifyFailed 表示燒錄後狀態碼與預期不符。
+var ErrVerifyFailed = errors.New("acc: verification failed")
+
+// Profile 描述特定 TCON 透過 AUX 傳送 ACC 資料的協定參數。
+type Profile struct {
+	Name        string
+	Slave       byte          // ACC 指令使用的 I2C-over-AUX 從屬位址
+	PollSlave   byte          // 燒錄狀態輪詢使用的從屬位址
+	Pages       int           // ACC 資料頁數
+	PageSize    int           // 每頁位元組數
+	Entries     int           // 每個通道的資料筆數
+	Bits        int           // 每筆資料的有效位元數
+	BigEndian   bool          // 每筆資料以 2 位元組 big-endian 排列
+	PageCommand byte          // 傳送第 N 頁時使用的指令基底（0x20+N）
+	Enter       []byte        // 進入 ACC 調校模式的指令序列
+	Flash       []byte        // 將 ACC 寫入 Flash 的指令序列
+	Reset       []byte        // 重置 TCON 的指令序列
+	Status      uint16        // 更新結果狀態碼位址
+	PollStatus  uint16        // 燒錄進度狀態碼位址
+	StatusOK    byte          // 成功時的狀態碼
+	FlashWait   time.Duration // 等待 MCU 寫入 Flash 的時間
+	PollTimeout time.Duration // 輪詢燒錄狀態的最長時間
+}
+
+// dpcdModeRegister 為 Novatek TCON 切換 16/32 模式所使用的 DPCD 位址。
+const dpcdModeRegister = 0x00102
+
+// builtinProfiles 收錄 TCONAUX 目錄內應用說明所描述的 ACC 協定。
+var builtinProfiles = []Profile{
+	{
+		// NT71851C ACC AUX Application Note V0.3：6 頁 × 256 位元組，
+		// 對應 R/G/B 各 256 筆、每筆 2 位元組的資料。
+		Name:        "NT71851C",
+		Slave:       0x62,
+		PollSlave:   0x60,
+		Pages:       6,
+		PageSize:    256,
+		Entries:     256,
+		Bits:        12,
+		BigEndian:   true,
+		PageCommand: 0x20,
+		Enter:       []byte{0xFF, 0x3C, 0xC3, 0x55, 0xAA, 0x51, 0x01},
+		Flash:       []byte{0xFF, 0x5F, 0x05},
+		Reset:       []byte{0xFF, 0x5F, 0x99},
+		Status:      0xFF5E,
+		PollStatus:  0x00F4,
+		StatusOK:    0x97,
+		FlashWait:   500 * time.Millisecond,
+		PollTimeout: 5 * time.Second,
+	},
+}
+
+// Profiles 回傳所有內建的 TCON 設定檔。
+func Profiles() []Profile {
+	return append([]Profile(nil), builtinProfiles...)
+}
+
+// LookupProfile 依名稱（不分大小寫）尋找設定檔。
+func LookupProfile(name string) (Profile, bool) {
+	for _, p := range builtinProfiles {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// Encode 將 LUT 依設定檔排列成逐頁傳送的原始位元組。
+func (p Profile) Encode(lut *LUT) ([]byte, error) {
+	if err := lut.Validate(); err != nil {
+		return nil, err
+	}
+	if lut.Entries() != p.Entries {
+		return nil, fmt.Errorf("acc: %s expects %d entries per channel, got %d", p.Name, p.Entries, lut.Entries())
+	}
+	if lut.Bits > p.Bits {
+		return nil, fmt.Errorf("acc: %s supports up to %d bits, got %d", p.Name, p.Bits, lut.Bits)
+	}
+	// 位元數較低的 LUT（例如 8-bit JSON）先放大到 TCON 的位元數，避免被當成高位元資料送出。
+	lut = lut.Scale(p.Bits)
+
+	data := make([]byte, p.Pages*p.PageSize)
+	offset := 0
+	for _, c := range []Channel{ChannelRed, ChannelGreen, ChannelBlue} {
+		for _, v := range lut.Channel(c) {
+			if offset+2 > len(data) {
+				return nil, fmt.Errorf("acc: %s page space exhausted", p.Name)
+			}
+			p.putValue(data[offset:], v)
+			offset += 2
+		}
+	}
+	return data, nil
+}
+
+func (p Profile) putValue(dst []byte, v uint16) {
+	if p.BigEndian {
+		binary.BigEndian.PutUint16(dst, v)
+		return
+	}
+	binary.LittleEndian.PutUint16(dst, v)
+}
+
+// Programmer 以指定的 GPU 驅動與 TCON 設定檔讀寫 ACC 資料。
+type Programmer struct {
+	driver  gpu.Driver
+	profile Profile
+	sleep   func(time.Duration)
+	// Progress 若不為 nil，會在每個階段完成時回報進度訊息。
+	Progress func(message string)
+}
+
+// NewProgrammer 建立 ACC 燒錄器。
+func NewProgrammer(driver gpu.Driver, profile Profile) *Programmer {
+	return &Programmer{driver: driver, profile: profile, sleep: time.Sleep}
+}
+
+// Profile 回傳燒錄器使用的設定檔。
+func (p *Programmer) Profile() Profile {
+	return p.profile
+}
+
+// Program 依應用說明流程：進入調校模式、逐頁傳送、寫入 Flash，最後驗證。
+func (p *Programmer) Program(lut *LUT) error {
+	data, err := p.profile.Encode(lut)
+	if err != nil {
+		return err
+	}
+
+	if err := p.command(p.profile.Enter); err != nil {
+		return fmt.Errorf("acc: enter tune mode: %w", err)
+	}
+	p.report("已進入 ACC 調校模式")
+
+	for page := 0; page < p.profile.Pages; page++ {
+		// 每頁以 0x20+N、0x00、0x00 為前導，再接上 PageSize 位元組資料。
+		payload := make([]byte, 0, p.profile.PageSize+2)
+		payload = append(payload, 0x00, 0x00)
+		payload = append(payload, data[page*p.profile.PageSize:(page+1)*p.profile.PageSize]...)
+		addr := uint32(p.profile.Slave) | uint32(p.profile.PageCommand+byte(page))<<8
+		err := p.withTuneWindow(func() error {
+			return p.driver.WriteI2C(addr, payload)
+		})
+		if err != nil {
+			return fmt.Errorf("acc: send page %d: %w", page, err)
+		}
+		p.report(fmt.Sprintf("傳送第 %d/%d 頁", page+1, p.profile.Pages))
+	}
+
+	if err := p.command(p.profile.Flash); err != nil {
+		return fmt.Errorf("acc: write flash: %w", err)
+	}
+	p.report("等待 TCON 寫入 Flash")
+	p.sleep(p.profile.FlashWait)
+
+	return p.Verify()
+}
+
+// Verify 輪詢燒錄狀態並檢查更新結果。應用說明未提供讀回指令，因此只能以狀態碼確認。
+func (p *Programmer) Verify() error {
+	deadline := time.Now().Add(p.profile.PollTimeout)
+	for {
+		status, err := p.readStatus(p.profile.PollSlave, p.profile.PollStatus)
+		if err != nil {
+			return fmt.Errorf("acc: poll flash status: %w", err)
+		}
+		if status == p.profile.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: flash status 0x%02X", ErrVerifyFailed, status)
+		}
+		p.sleep(100 * time.Millisecond)
+	}
+
+	status, err := p.readStatus(p.profile.Slave, p.profile.Status)
+	if err != nil {
+		return fmt.Errorf("acc: read update status: %w", err)
+	}
+	if status != p.profile.StatusOK {
+		return fmt.Errorf("%w: update status 0x%02X", ErrVerifyFailed, status)
+	}
+	p.report(fmt.Sprintf("狀態碼 0x%02X，更新成功", status))
+	return nil
+}
+
+// ResetTCON 送出重置指令並等待 TCON 重新啟動。
+func (p *Programmer) ResetTCON() error {
+	if err := p.command(p.profile.Reset); err != nil {
+		return fmt.Errorf("acc: reset tcon: %w", err)
+	}
+	p.sleep(time.Second)
+	return nil
+}
+
+// command 在調校視窗內送出一段以第一個位元組為索引的指令序列。
+func (p *Programmer) command(seq []byte) error {
+	if len(seq) == 0 {
+		return nil
+	}
+	addr := uint32(p.profile.Slave) | uint32(seq[0])<<8
+	return p.withTuneWindow(func() error {
+		return p.driver.WriteI2C(addr, seq[1:])
+	})
+}
+
+// readStatus 寫入 16-bit 狀態位址後讀回一個位元組。
+// 依 read_i2c 的位址慣例，暫存器位址放在 addr 第 8 位元以上；
+// 驅動需支援雙位元組索引才能送出完整的 16-bit 位址。
+func (p *Programmer) readStatus(slave byte, reg uint16) (byte, error) {
+	var status byte
+	err := p.withTuneWindow(func() error {
+		addr := uint32(slave) | uint32(reg)<<8
+		data, err := p.driver.ReadI2C(addr, 1)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return errors.New("empty status response")
+		}
+		status = data[0]
+		return nil
+	})
+	return status, err
+}
+
+// withTuneWindow 在操作前後切換 DPCD 0x00102，關閉與恢復 16/32 模式。
+func (p *Programmer) withTuneWindow(fn func() error) error {
+	if err := p.driver.WriteDPCD(dpcdModeRegister, []byte{0xC0}); err != nil {
+		return fmt.Errorf("disable 16/32 mode: %w", err)
+	}
+	err := fn()
+	if restoreErr := p.driver.WriteDPCD(dpcdModeRegister, []byte{0x00}); restoreErr != nil && err == nil {
+		err = fmt.Errorf("enable 16/32 mode: %w", restoreErr)
+	}
+	return err
+}
+
+func (p *Programmer) report(message string) {
+	if p.Progress != nil {
+		p.Progress(message)
+	}
+}
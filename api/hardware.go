@@ -0,0 +1,265 @@
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/dpcd"
+	"GMTAUXOneKeyBuild/edidhelper"
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+// bus 表示 DPCD 或 I2C 位址空間。
+type bus string
+
+const (
+	busDPCD bus = "dpcd"
+	busI2C  bus = "i2c"
+)
+
+// Bytes 以整數陣列序列化位元組，方便 LabVIEW 等不易處理 base64 的用戶端。
+type Bytes []byte
+
+// MarshalJSON 輸出 [18, 20, ...]。
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	values := make([]string, len(b))
+	for i, v := range b {
+		values[i] = strconv.Itoa(int(v))
+	}
+	return []byte("[" + strings.Join(values, ",") + "]"), nil
+}
+
+// DisplaysResponse 為 GET /api/v1/displays 的回應。
+type DisplaysResponse struct {
+	Displays []*display.Display `json:"displays"`
+	Error    string             `json:"error,omitempty"` // 部分顯示器載入失敗時的最後錯誤
+}
+
+// EDIDResponse 為 GET /api/v1/edid 的回應。
+type EDIDResponse struct {
+	Driver   string           `json:"driver"`
+	DeviceID string           `json:"device_id,omitempty"` // 操作的顯示器
+	Blocks   int              `json:"blocks"`
+	Hex      string           `json:"hex"`
+	Data     Bytes            `json:"data"`
+	Display  *display.Display `json:"display,omitempty"`
+	Warning  string           `json:"warning,omitempty"` // 檢查碼錯誤等仍回傳資料的問題
+}
+
+// RegisterField 為解碼後的欄位。
+type RegisterField struct {
+	Name    string `json:"name"`
+	Value   uint32 `json:"value"`
+	Meaning string `json:"meaning,omitempty"`
+}
+
+// Register 為解碼後的 DPCD 暫存器。
+type Register struct {
+	Address uint32          `json:"address"`
+	Name    string          `json:"name"`
+	Group   string          `json:"group"`
+	Raw     Bytes           `json:"raw"`
+	Summary string          `json:"summary,omitempty"`
+	Fields  []RegisterField `json:"fields,omitempty"`
+}
+
+// ReadResponse 為 GET /api/v1/dpcd 與 /api/v1/i2c 的回應。
+type ReadResponse struct {
+	Driver   string     `json:"driver"`
+	DeviceID string     `json:"device_id,omitempty"` // 操作的顯示器
+	Address  uint32     `json:"address"`
+	Length   int        `json:"length"`
+	Data     Bytes      `json:"data"`
+	Hex      string     `json:"hex"`
+	Decoded  []Register `json:"decoded,omitempty"`
+}
+
+// WriteRequest 為 POST /api/v1/dpcd 與 /api/v1/i2c 的內容；data 與 hex 擇一。
+// Display 為目標顯示器的 device_id，也可改放在查詢字串。
+type WriteRequest struct {
+	Display string `json:"display,omitempty"`
+	Address uint32 `json:"address"`
+	Data    []int  `json:"data,omitempty"`
+	Hex     string `json:"hex,omitempty"`
+}
+
+// WriteResponse 為寫入成功的回應。
+type WriteResponse struct {
+	Driver   string `json:"driver"`
+	DeviceID string `json:"device_id,omitempty"` // 操作的顯示器
+	Address  uint32 `json:"address"`
+	Written  int    `json:"written"`
+}
+
+func (s *Server) handleDisplays(w http.ResponseWriter, r *http.Request) {
+	displays, err := edidhelper.GetScreens()
+	if err != nil && len(displays) == 0 {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	resp := DisplaysResponse{Displays: displays}
+	if resp.Displays == nil {
+		resp.Displays = []*display.Display{}
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleSinkEDID(w http.ResponseWriter, r *http.Request) {
+	driver, target, ok := s.driver(w, r.URL.Query().Get("display"))
+	if !ok {
+		return
+	}
+	s.hwMu.Lock()
+	edid, err := edidhelper.ReadEDIDFromSink(driver)
+	s.hwMu.Unlock()
+	if len(edid) == 0 {
+		writeError(w, statusForError(err), err)
+		return
+	}
+
+	resp := EDIDResponse{
+		Driver:   driver.Name(),
+		DeviceID: deviceID(target),
+		Blocks:   len(edid) / 128,
+		Hex:      strings.ToUpper(hex.EncodeToString(edid)),
+		Data:     edid,
+	}
+	if err != nil {
+		resp.Warning = err.Error()
+	}
+	if info, perr := display.ParseEDID(edid, driver.Name(), "", ""); perr == nil {
+		info.Source = display.SourceSink
+		resp.Display = info
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleRead 處理 ?address=0x100&length=16[&decode=true] 的讀取。
+func (s *Server) handleRead(b bus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		address, err := parseNumber(query.Get("address"), 32)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("api: address: %w", err))
+			return
+		}
+		length, err := parseNumber(query.Get("length"), 16)
+		if err != nil || length == 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("api: length must be between 1 and 65535"))
+			return
+		}
+		driver, target, ok := s.driver(w, query.Get("display"))
+		if !ok {
+			return
+		}
+
+		s.hwMu.Lock()
+		var data []byte
+		if b == busDPCD {
+			data, err = driver.ReadDPCD(uint32(address), uint32(length))
+		} else {
+			data, err = driver.ReadI2C(uint32(address), uint32(length))
+		}
+		s.hwMu.Unlock()
+		if err != nil {
+			writeError(w, statusForError(err), err)
+			return
+		}
+
+		resp := ReadResponse{
+			Driver:   driver.Name(),
+			DeviceID: deviceID(target),
+			Address:  uint32(address),
+			Length:   len(data),
+			Data:     data,
+			Hex:      fmt.Sprintf("% X", data),
+		}
+		if b == busDPCD && query.Get("decode") == "true" {
+			for _, d := range dpcd.Decode(uint32(address), data) {
+				resp.Decoded = append(resp.Decoded, toRegister(d))
+			}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// handleWrite 處理 {"address": ..., "data": [...]} 或 {"address": ..., "hex": "..."} 的寫入。
+func (s *Server) handleWrite(b bus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req WriteRequest
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		data, err := req.bytes()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		driver, target, ok := s.driver(w, displayParam(r, req.Display))
+		if !ok {
+			return
+		}
+
+		s.hwMu.Lock()
+		if b == busDPCD {
+			err = driver.WriteDPCD(req.Address, data)
+		} else {
+			err = driver.WriteI2C(req.Address, data)
+		}
+		s.hwMu.Unlock()
+		if err != nil {
+			writeError(w, statusForError(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, WriteResponse{Driver: driver.Name(), DeviceID: deviceID(target), Address: req.Address, Written: len(data)})
+	}
+}
+
+// bytes 取得寫入資料並檢查範圍。
+func (req *WriteRequest) bytes() ([]byte, error) {
+	if req.Hex != "" {
+		if len(req.Data) > 0 {
+			return nil, fmt.Errorf("api: specify either data or hex, not both")
+		}
+		data, err := hex.DecodeString(strings.NewReplacer(" ", "", "0x", "", ",", "").Replace(req.Hex))
+		if err != nil {
+			return nil, fmt.Errorf("api: invalid hex: %w", err)
+		}
+		return data, nil
+	}
+	if len(req.Data) == 0 {
+		return nil, fmt.Errorf("api: data is empty")
+	}
+	data := make([]byte, len(req.Data))
+	for i, v := range req.Data {
+		if v < 0 || v > 0xFF {
+			return nil, fmt.Errorf("api: data[%d]=%d out of byte range", i, v)
+		}
+		data[i] = byte(v)
+	}
+	return data, nil
+}
+
+// parseNumber 解析十進位或 0x 開頭的十六進位數字。
+func parseNumber(text string, bits int) (uint64, error) {
+	if text == "" {
+		return 0, fmt.Errorf("missing value")
+	}
+	return strconv.ParseUint(text, 0, bits)
+}
+
+// toRegister 將 dpcd.Decoded 轉成 API 結構。
+func toRegister(d dpcd.Decoded) Register {
+	reg := Register{Address: d.Address, Name: d.Name, Group: d.Group, Raw: d.Raw, Summary: d.Summary}
+	for _, f := range d.Fields {
+		reg.Fields = append(reg.Fields, RegisterField{Name: f.Name, Value: f.Value, Meaning: f.Meaning})
+	}
+	return reg
+}
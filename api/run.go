@@ -0,0 +1,223 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/luascripts"
+	"GMTAUXOneKeyBuild/recipe"
+)
+
+// ScriptInfo 為腳本清單的項目。
+type ScriptInfo struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ScriptRequest 為執行腳本時的選用參數；Display 也可改放在查詢字串。
+type ScriptRequest struct {
+	Display string `json:"display,omitempty"`
+}
+
+// ScriptResult 為 POST /api/v1/scripts/{name}/run 的回應。
+type ScriptResult struct {
+	Script   string        `json:"script"`
+	Driver   string        `json:"driver,omitempty"`
+	DeviceID string        `json:"device_id,omitempty"` // 操作的顯示器
+	Success  bool          `json:"success"`
+	Results  []interface{} `json:"results"`
+	Error    string        `json:"error,omitempty"`
+	Duration string        `json:"duration"`
+	DryRun   bool          `json:"dry_run,omitempty"`
+	Writes   []string      `json:"writes,omitempty"` // dry-run 時攔截到的寫入
+}
+
+// RecipeInfo 為配方清單的項目。
+type RecipeInfo struct {
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Variables   []string `json:"variables,omitempty"`
+	Error       string   `json:"error,omitempty"` // 配方載入或驗證失敗的原因
+}
+
+// RecipeRequest 為執行配方時的選用參數；Display 也可改放在查詢字串。
+type RecipeRequest struct {
+	Display string            `json:"display,omitempty"`
+	Vars    map[string]string `json:"vars,omitempty"`
+	StartAt int               `json:"start_at,omitempty"`
+}
+
+// RecipeResult 為 POST /api/v1/recipes/{name}/run 的回應。
+type RecipeResult struct {
+	*recipe.Report
+	DeviceID   string `json:"device_id,omitempty"` // 操作的顯示器
+	Error      string `json:"error,omitempty"`
+	ReportPath string `json:"report_path,omitempty"`
+}
+
+func (s *Server) handleScripts(w http.ResponseWriter, r *http.Request) {
+	scripts, err := luascripts.ListScripts(s.ScriptsDir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	list := make([]ScriptInfo, 0, len(scripts))
+	for _, script := range scripts {
+		list = append(list, ScriptInfo{Name: script.Name, Path: script.Path})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// handleRunScript 執行腳本並回傳所有回傳值；腳本錯誤仍回應 200，以 success 區分。
+func (s *Server) handleRunScript(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	var req ScriptRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	scripts, err := luascripts.ListScripts(s.ScriptsDir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	var script *luascripts.Script
+	for i := range scripts {
+		if scripts[i].Name == name {
+			script = &scripts[i]
+			break
+		}
+	}
+	if script == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("api: script %q not found", name))
+		return
+	}
+
+	target, err := s.target(displayParam(r, req.Display))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// 沒有驅動時仍執行腳本，由腳本的 read_* 函式回傳錯誤，與介面行為一致。
+	driver, detectErr := s.backend.Driver(target)
+	opts := s.backend.ScriptRuntime(target, driver, detectErr)
+
+	s.hwMu.Lock()
+	start := time.Now()
+	values, err := luascripts.ExecuteScript(script.Path, opts)
+	elapsed := time.Since(start)
+	s.hwMu.Unlock()
+
+	result := ScriptResult{
+		Script:   script.Name,
+		DeviceID: deviceID(target),
+		Success:  err == nil,
+		Results:  make([]interface{}, 0, len(values)),
+		Duration: elapsed.Round(time.Millisecond).String(),
+	}
+	if driver != nil {
+		result.Driver = driver.Name()
+	}
+	if dryRun, ok := driver.(*gpu.DryRunDriver); ok {
+		result.DryRun = true
+		for _, write := range dryRun.Writes() {
+			result.Writes = append(result.Writes, write.String())
+		}
+	}
+	for _, v := range values {
+		result.Results = append(result.Results, luascripts.ToGo(v))
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleRecipes(w http.ResponseWriter, r *http.Request) {
+	paths, err := recipe.List(s.RecipesDir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	list := make([]RecipeInfo, 0, len(paths))
+	for _, path := range paths {
+		info := RecipeInfo{Name: recipeName(path)}
+		if rc, err := recipe.Load(path); err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Title = rc.Name
+			info.Description = rc.Description
+			info.Variables = rc.Variables()
+		}
+		list = append(list, info)
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// handleRunRecipe 執行配方並回傳統一報告；配方失敗時回應 200 並以 success 與 error 區分。
+func (s *Server) handleRunRecipe(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	var req RecipeRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	path := filepath.Join(s.RecipesDir, name+".json")
+	if recipeName(path) != name || strings.ContainsAny(name, `/\`) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("api: invalid recipe name %q", name))
+		return
+	}
+	rc, err := recipe.Load(path)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, os.ErrNotExist) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+	driver, target, ok := s.driver(w, displayParam(r, req.Display))
+	if !ok {
+		return
+	}
+
+	executor := recipe.NewExecutor(driver)
+	if req.Vars != nil {
+		executor.Vars = req.Vars
+	}
+	executor.StartAt = req.StartAt
+
+	s.hwMu.Lock()
+	report, runErr := executor.Run(rc)
+	s.hwMu.Unlock()
+	if report == nil {
+		writeError(w, http.StatusBadRequest, runErr)
+		return
+	}
+
+	result := RecipeResult{Report: report, DeviceID: deviceID(target)}
+	if runErr != nil {
+		result.Error = runErr.Error()
+	}
+	if s.ReportsDir != "" {
+		reportPath := filepath.Join(s.ReportsDir, report.FileName(rc.Path))
+		if err := os.MkdirAll(s.ReportsDir, 0o755); err == nil && report.Save(reportPath) == nil {
+			result.ReportPath = reportPath
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// recipeName 回傳不含副檔名的配方檔名。
+func recipeName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,239 @@
+// Package api 提供本機 HTTP JSON API，讓產線 MES 與 LabVIEW 等自動化系統以程式呼叫
+// 顯示器列舉、EDID、DPCD/I2C 存取、Lua 腳本與 One Key Build 配方，並取得結構化結果。
+//
+// 所有回應皆為 JSON；失敗時回傳對應的 HTTP 狀態碼與 {"error": "..."}。
+// 設定 Token 後，請求需帶有 "Authorization: Bearer <token>" 標頭。
+//
+// 硬體操作以 display 參數（GET /api/v1/displays 的 device_id，可放在查詢字串或
+// 請求內容）指定目標顯示器；只連接一台顯示器時可省略。
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/luascripts"
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+// Backend 提供 API 使用的驅動與 Lua 執行環境，由 TUI 實作，
+// 使 API 與介面共用驅動快取、dry-run 與遠端測試機設定。
+type Backend interface {
+	// Displays 回傳介面顯示器清單的快照，用來解析請求指定的 display；可在任何 goroutine 呼叫。
+	Displays() []*display.Display
+	// Driver 回傳操作 target 使用的驅動；target 為 nil 時使用預設驅動。
+	Driver(target *display.Display) (gpu.Driver, error)
+	// ScriptRuntime 回傳在 target 上執行腳本時注入的函式、模組與變數。
+	ScriptRuntime(target *display.Display, driver gpu.Driver, detectErr error) luascripts.RuntimeOptions
+}
+
+var (
+	// ErrDisplayRequired 表示連接多台顯示器時請求未指定 display。
+	ErrDisplayRequired = errors.New("api: display is required when more than one display is connected")
+	// ErrUnknownDisplay 表示 display 不在顯示器清單中。
+	ErrUnknownDisplay = errors.New("api: unknown display")
+)
+
+// Server 為本機 HTTP API 伺服器。
+type Server struct {
+	backend Backend
+
+	// ScriptsDir 為 Lua 腳本目錄。
+	ScriptsDir string
+	// RecipesDir 為配方目錄。
+	RecipesDir string
+	// ReportsDir 不為空時，配方報告會另存至此目錄。
+	ReportsDir string
+	// Token 不為空時要求 Bearer 權杖驗證。
+	Token string
+
+	hwMu sync.Mutex // 序列化硬體存取，避免腳本、配方與單次讀寫交錯
+	mu   sync.Mutex
+	http *http.Server
+}
+
+// NewServer 建立 API 伺服器。
+func NewServer(backend Backend, scriptsDir, recipesDir string) *Server {
+	return &Server{backend: backend, ScriptsDir: scriptsDir, RecipesDir: recipesDir}
+}
+
+// Handler 回傳 API 的路由，方便嵌入其他 HTTP 伺服器或以 httptest 測試。
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/displays", s.handleDisplays)
+	mux.HandleFunc("GET /api/v1/edid", s.handleSinkEDID)
+	mux.HandleFunc("GET /api/v1/dpcd", s.handleRead(busDPCD))
+	mux.HandleFunc("POST /api/v1/dpcd", s.handleWrite(busDPCD))
+	mux.HandleFunc("GET /api/v1/i2c", s.handleRead(busI2C))
+	mux.HandleFunc("POST /api/v1/i2c", s.handleWrite(busI2C))
+	mux.HandleFunc("GET /api/v1/scripts", s.handleScripts)
+	mux.HandleFunc("POST /api/v1/scripts/{name}/run", s.handleRunScript)
+	mux.HandleFunc("GET /api/v1/recipes", s.handleRecipes)
+	mux.HandleFunc("POST /api/v1/recipes/{name}/run", s.handleRunRecipe)
+	return s.authenticate(mux)
+}
+
+// ListenAndServe 監聽 addr 並處理請求，直到 Close 被呼叫。
+func (s *Server) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ln)
+}
+
+// Serve 在既有的 listener 上處理請求。
+func (s *Server) Serve(ln net.Listener) error {
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	s.mu.Lock()
+	s.http = srv
+	s.mu.Unlock()
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Close 停止伺服器。
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.http == nil {
+		return nil
+	}
+	return s.http.Close()
+}
+
+// authenticate 在設定 Token 時驗證 Bearer 權杖。
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.Token != "" {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.Token)) != 1 {
+				writeError(w, http.StatusUnauthorized, errors.New("api: missing or invalid bearer token"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// target 依 device_id 解析目標顯示器；未指定時僅在只有一台顯示器時自動選用，
+// 沒有任何顯示器時回傳 nil 並由預設驅動處理。由檔案載入的 EDID 不列入。
+func (s *Server) target(id string) (*display.Display, error) {
+	var candidates []*display.Display
+	seen := make(map[string]bool)
+	for _, d := range s.backend.Displays() {
+		if d.Source == display.SourceFile || d.DeviceID == "" || seen[d.DeviceID] {
+			continue
+		}
+		seen[d.DeviceID] = true
+		candidates = append(candidates, d)
+	}
+	if id == "" {
+		switch len(candidates) {
+		case 0:
+			return nil, nil
+		case 1:
+			return candidates[0], nil
+		default:
+			return nil, ErrDisplayRequired
+		}
+	}
+	for _, d := range candidates {
+		if d.DeviceID == id {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownDisplay, id)
+}
+
+// driver 解析目標顯示器並取得驅動；display 無法解析時回傳 400，沒有驅動時回傳 503。
+func (s *Server) driver(w http.ResponseWriter, id string) (gpu.Driver, *display.Display, bool) {
+	target, err := s.target(id)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, nil, false
+	}
+	driver, err := s.backend.Driver(target)
+	if err != nil || driver == nil {
+		if err == nil {
+			err = gpu.ErrNoDriver
+		}
+		writeError(w, http.StatusServiceUnavailable, err)
+		return nil, nil, false
+	}
+	return driver, target, true
+}
+
+// displayParam 回傳查詢字串的 display，未指定時使用請求內容中的值。
+func displayParam(r *http.Request, body string) string {
+	if id := r.URL.Query().Get("display"); id != "" {
+		return id
+	}
+	return body
+}
+
+// deviceID 回傳 target 的 device_id，target 為 nil 時回傳空字串。
+func deviceID(target *display.Display) string {
+	if target == nil {
+		return ""
+	}
+	return target.DeviceID
+}
+
+// writeJSON 以指定狀態碼輸出 JSON。
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
+
+// writeError 輸出 {"error": "..."}；可分類的驅動錯誤另附 "kind"（例如 "defer"、"timeout"）。
+func writeError(w http.ResponseWriter, status int, err error) {
+	body := map[string]string{"error": err.Error()}
+	if kind := gpu.ErrorKind(err); kind != "" {
+		body["kind"] = kind
+	}
+	writeJSON(w, status, body)
+}
+
+// statusForError 將驅動錯誤對應為 HTTP 狀態碼。
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, gpu.ErrNoDriver), errors.Is(err, gpu.ErrBusy):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, gpu.ErrNotImplemented):
+		return http.StatusNotImplemented
+	case errors.Is(err, gpu.ErrTimeout):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, gpu.ErrInvalidParameter):
+		return http.StatusBadRequest
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// decodeBody 解析 JSON 請求內容。
+func decodeBody(r *http.Request, v interface{}) error {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("api: invalid request body: %w", err)
+	}
+	return nil
+}
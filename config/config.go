@@ -0,0 +1,242 @@
+// Package config 讀寫應用程式設定檔（JSON），保存各測試機不同的目錄、驅動偏好、
+// AUX 延遲、逾時、記錄位置、選單語言與快捷鍵。
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// DefaultPath 為未指定時使用的設定檔路徑（相對於工作目錄）。
+const DefaultPath = "gmtaux.json"
+
+// 主選單語言；其餘頁面與訊息固定為中文。
+const (
+	LanguageChinese = "zh-TW"
+	LanguageEnglish = "en"
+)
+
+// DriverAuto 表示依顯示卡描述自動選擇驅動。
+const DriverAuto = "auto"
+
+// Config 為設定檔內容；欄位為零值時使用 Default 的對應值。
+type Config struct {
+	ScriptsDir   string            `json:"scripts_dir"`
+	RecipesDir   string            `json:"recipes_dir"`
+	PanelsFile   string            `json:"panels_file"`
+	SnapshotFile string            `json:"snapshot_file"`
+	Drivers      map[string]string `json:"drivers,omitempty"` // 廠牌（intel、nvidia、default）對應的驅動名稱
+	AUX          AUX               `json:"aux"`
+	Timeouts     Timeouts          `json:"timeouts"`
+	Retry        Retry             `json:"retry"`
+	Logs         Logs              `json:"logs"`
+	Language     string            `json:"language"`               // 僅影響主選單文字
+	KeyBindings  map[string]string `json:"key_bindings,omitempty"` // 主選單項目 ID 對應的單一字元快捷鍵
+	Hotplug      Hotplug           `json:"hotplug"`
+
+	// DisplayDrivers 為個別顯示器（以裝置識別碼為鍵）手動指定的驅動，優先於 Drivers；
+	// 值可為已註冊的驅動名稱、sim 或 remote:<host[:port]>。
+	DisplayDrivers map[string]string `json:"display_drivers,omitempty"`
+}
+
+// AUX 為 AUX/I2C 交易相關的等待時間（毫秒）。
+type AUX struct {
+	IntelCUIDelayMS   int `json:"intel_cui_delay_ms"`   // Intel igfx CUI 每次交易後的等待
+	EDIDWriteDelayMS  int `json:"edid_write_delay_ms"`  // EDID EEPROM 每頁寫入後的等待
+	DDCReplyDelayMS   int `json:"ddc_reply_delay_ms"`   // DDC/CI 請求後讀取回覆前的等待
+	DDCCommandDelayMS int `json:"ddc_command_delay_ms"` // DDC/CI 訊息之間的最小間隔
+}
+
+// Timeouts 為逾時設定（毫秒）。
+type Timeouts struct {
+	RemoteMS  int `json:"remote_ms"`   // 遠端測試機連線與單一請求
+	ACCPollMS int `json:"acc_poll_ms"` // ACC 燒錄狀態輪詢
+}
+
+// Retry 為 AUX/I2C 暫時性錯誤（DEFER、忙碌、逾時）的重試設定。
+type Retry struct {
+	MaxAttempts  int `json:"max_attempts"`   // 含第一次的總嘗試次數，1 表示不重試
+	BackoffMS    int `json:"backoff_ms"`     // 第一次重試前的等待，之後每次加倍
+	MaxBackoffMS int `json:"max_backoff_ms"` // 等待時間上限
+
+	// WriteTimeout 為 true 時寫入逾時也重試；逾時的寫入可能已送達面板，預設不重試。
+	WriteTimeout bool `json:"write_timeout,omitempty"`
+}
+
+// Policy 轉換為 gpu.RetryDriver 使用的重試設定。
+func (r Retry) Policy() gpu.RetryPolicy {
+	return gpu.RetryPolicy{
+		MaxAttempts: r.MaxAttempts,
+		Backoff:     Millis(r.BackoffMS),
+		MaxBackoff:  Millis(r.MaxBackoffMS),
+
+		RetryWriteTimeout: r.WriteTimeout,
+	}
+}
+
+// Logs 為記錄檔與報告的位置。
+type Logs struct {
+	ReportsDir string `json:"reports_dir"`        // 配方執行報告
+	LogFile    string `json:"log_file,omitempty"` // 狀態列訊息記錄檔，空字串表示不記錄
+}
+
+// Hotplug 為顯示器熱插拔設定。
+type Hotplug struct {
+	Enabled bool   `json:"enabled"`
+	Script  string `json:"script,omitempty"` // 偵測到新面板時執行的 Lua 腳本名稱
+}
+
+// Default 回傳與程式內建行為相同的設定。
+func Default() Config {
+	return Config{
+		ScriptsDir:   "scripts",
+		RecipesDir:   "recipes",
+		PanelsFile:   "panels.json",
+		SnapshotFile: "snapshot.json",
+		AUX: AUX{
+			IntelCUIDelayMS:   20,
+			EDIDWriteDelayMS:  10,
+			DDCReplyDelayMS:   40,
+			DDCCommandDelayMS: 50,
+		},
+		Timeouts: Timeouts{
+			RemoteMS:  10000,
+			ACCPollMS: 5000,
+		},
+		Retry: Retry{
+			MaxAttempts:  4,
+			BackoffMS:    5,
+			MaxBackoffMS: 100,
+		},
+		Logs:     Logs{ReportsDir: "reports"},
+		Language: LanguageChinese,
+		Hotplug:  Hotplug{Enabled: true},
+	}
+}
+
+// Load 讀取設定檔並以預設值補齊未填的欄位；檔案不存在時回傳 Default 且不視為錯誤。
+func Load(path string) (Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("%s: %w", path, err)
+	}
+	cfg.FillDefaults()
+	if err := cfg.Validate(); err != nil {
+		return Default(), fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save 以縮排 JSON 寫入設定檔。
+func Save(path string, cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// FillDefaults 將空白的路徑、語言與零值的逾時補回預設值。
+func (c *Config) FillDefaults() {
+	def := Default()
+	for _, f := range []struct{ value, fallback *string }{
+		{&c.ScriptsDir, &def.ScriptsDir},
+		{&c.RecipesDir, &def.RecipesDir},
+		{&c.PanelsFile, &def.PanelsFile},
+		{&c.SnapshotFile, &def.SnapshotFile},
+		{&c.Logs.ReportsDir, &def.Logs.ReportsDir},
+		{&c.Language, &def.Language},
+	} {
+		if strings.TrimSpace(*f.value) == "" {
+			*f.value = *f.fallback
+		}
+	}
+	if c.Timeouts.RemoteMS == 0 {
+		c.Timeouts.RemoteMS = def.Timeouts.RemoteMS
+	}
+	if c.Timeouts.ACCPollMS == 0 {
+		c.Timeouts.ACCPollMS = def.Timeouts.ACCPollMS
+	}
+	if c.Retry.MaxAttempts == 0 {
+		c.Retry = def.Retry
+	}
+}
+
+// Validate 檢查語言、延遲與快捷鍵格式；快捷鍵是否重複由介面依選單項目檢查。
+func (c Config) Validate() error {
+	if c.Language != LanguageChinese && c.Language != LanguageEnglish {
+		return fmt.Errorf("language %q must be %q or %q", c.Language, LanguageChinese, LanguageEnglish)
+	}
+	for _, d := range []struct {
+		name  string
+		value int
+	}{
+		{"aux.intel_cui_delay_ms", c.AUX.IntelCUIDelayMS},
+		{"aux.edid_write_delay_ms", c.AUX.EDIDWriteDelayMS},
+		{"aux.ddc_reply_delay_ms", c.AUX.DDCReplyDelayMS},
+		{"aux.ddc_command_delay_ms", c.AUX.DDCCommandDelayMS},
+		{"timeouts.remote_ms", c.Timeouts.RemoteMS},
+		{"timeouts.acc_poll_ms", c.Timeouts.ACCPollMS},
+		{"retry.backoff_ms", c.Retry.BackoffMS},
+		{"retry.max_backoff_ms", c.Retry.MaxBackoffMS},
+	} {
+		if d.value < 0 || d.value > 60000 {
+			return fmt.Errorf("%s %d out of range (0-60000)", d.name, d.value)
+		}
+	}
+	if c.Retry.MaxAttempts < 1 || c.Retry.MaxAttempts > 20 {
+		return fmt.Errorf("retry.max_attempts %d out of range (1-20)", c.Retry.MaxAttempts)
+	}
+	if c.AUX.IntelCUIDelayMS > 0xFFFF {
+		return fmt.Errorf("aux.intel_cui_delay_ms %d out of range", c.AUX.IntelCUIDelayMS)
+	}
+	for id, key := range c.KeyBindings {
+		if len([]rune(key)) > 1 {
+			return fmt.Errorf("key binding for %s must be a single character, got %q", id, key)
+		}
+	}
+	return nil
+}
+
+// Driver 回傳廠牌偏好的驅動名稱；未設定或為 auto 時回傳空字串。
+func (c Config) Driver(vendor string) string {
+	if vendor == "" {
+		vendor = "default"
+	}
+	name := strings.ToLower(strings.TrimSpace(c.Drivers[vendor]))
+	if name == DriverAuto {
+		return ""
+	}
+	return name
+}
+
+// DisplayDriver 回傳顯示器手動指定的驅動；未指定或為 auto 時回傳空字串。
+func (c Config) DisplayDriver(deviceID string) string {
+	name := strings.TrimSpace(c.DisplayDrivers[deviceID])
+	if strings.EqualFold(name, DriverAuto) {
+		return ""
+	}
+	return name
+}
+
+// Millis 將毫秒數轉為 time.Duration。
+func Millis(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
@@ -0,0 +1,242 @@
+package ddcci
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 常用的 VCP 代碼。
+const (
+	VCPRestoreFactory    = 0x04
+	VCPBrightness        = 0x10
+	VCPContrast          = 0x12
+	VCPColorPreset       = 0x14
+	VCPVideoGainRed      = 0x16
+	VCPVideoGainGreen    = 0x18
+	VCPVideoGainBlue     = 0x1A
+	VCPInputSource       = 0x60
+	VCPAudioVolume       = 0x62
+	VCPDisplayController = 0xC8
+	VCPFirmwareLevel     = 0xC9
+	VCPPowerMode         = 0xD6
+	VCPVersion           = 0xDF
+)
+
+// VCPNames 為 MCCS 2.2 常見 VCP 代碼的名稱。
+var VCPNames = map[byte]string{
+	0x02: "New Control Value",
+	0x04: "Restore Factory Defaults",
+	0x05: "Restore Brightness and Contrast",
+	0x08: "Restore Color Defaults",
+	0x0B: "Color Temperature Increment",
+	0x0C: "Color Temperature Request",
+	0x10: "Brightness",
+	0x12: "Contrast",
+	0x14: "Select Color Preset",
+	0x16: "Video Gain Red",
+	0x18: "Video Gain Green",
+	0x1A: "Video Gain Blue",
+	0x52: "Active Control",
+	0x60: "Input Source",
+	0x62: "Audio Speaker Volume",
+	0x6C: "Video Black Level Red",
+	0x6E: "Video Black Level Green",
+	0x70: "Video Black Level Blue",
+	0x87: "Sharpness",
+	0xAC: "Horizontal Frequency",
+	0xAE: "Vertical Frequency",
+	0xB2: "Flat Panel Sub-Pixel Layout",
+	0xB6: "Display Technology Type",
+	0xC6: "Application Enable Key",
+	0xC8: "Display Controller Type",
+	0xC9: "Display Firmware Level",
+	0xCA: "OSD",
+	0xCC: "OSD Language",
+	0xD6: "Power Mode",
+	0xDF: "VCP Version",
+}
+
+// ColorPresets 為 VCP 0x14 的數值意義。
+var ColorPresets = map[uint16]string{
+	0x01: "sRGB",
+	0x02: "Display Native",
+	0x03: "4000 K",
+	0x04: "5000 K",
+	0x05: "6500 K",
+	0x06: "7500 K",
+	0x07: "8200 K",
+	0x08: "9300 K",
+	0x09: "10000 K",
+	0x0A: "11500 K",
+	0x0B: "User 1",
+	0x0C: "User 2",
+	0x0D: "User 3",
+}
+
+// VCPName 回傳 VCP 代碼名稱，未知時以十六進位表示。
+func VCPName(code byte) string {
+	if name, ok := VCPNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("VCP 0x%02X", code)
+}
+
+// Capabilities 為解析後的能力字串。
+type Capabilities struct {
+	Raw      string
+	Fields   map[string]string // 頂層欄位的原始內容，例如 model、type、mccs_ver
+	Commands []byte
+	// VCP 為支援的 VCP 代碼與其允許值；連續值型的代碼沒有列舉值。
+	VCP map[byte][]uint16
+}
+
+// Model 回傳能力字串中的型號。
+func (c *Capabilities) Model() string {
+	return c.Fields["model"]
+}
+
+// MCCSVersion 回傳能力字串中的 MCCS 版本。
+func (c *Capabilities) MCCSVersion() string {
+	return c.Fields["mccs_ver"]
+}
+
+// VCPCodes 回傳排序後的支援 VCP 代碼。
+func (c *Capabilities) VCPCodes() []byte {
+	codes := make([]byte, 0, len(c.VCP))
+	for code := range c.VCP {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
+
+// ParseCapabilities 解析形如 "(prot(monitor)type(lcd)vcp(10 12 14(05 08))mccs_ver(2.2))" 的能力字串。
+func ParseCapabilities(raw string) (*Capabilities, error) {
+	caps := &Capabilities{Raw: raw, Fields: map[string]string{}, VCP: map[byte][]uint16{}}
+	text := strings.TrimSpace(raw)
+	if strings.HasPrefix(text, "(") {
+		if !strings.HasSuffix(text, ")") {
+			return nil, fmt.Errorf("ddcci: unbalanced capabilities string")
+		}
+		text = text[1 : len(text)-1]
+	}
+
+	entries, err := splitGroups(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		key := strings.ToLower(strings.TrimSpace(e.key))
+		caps.Fields[key] = e.value
+		switch key {
+		case "cmds":
+			codes, err := parseCodeList(e.value)
+			if err != nil {
+				return nil, fmt.Errorf("ddcci: cmds: %w", err)
+			}
+			for _, c := range codes {
+				caps.Commands = append(caps.Commands, c.code)
+			}
+		case "vcp":
+			codes, err := parseCodeList(e.value)
+			if err != nil {
+				return nil, fmt.Errorf("ddcci: vcp: %w", err)
+			}
+			for _, c := range codes {
+				caps.VCP[c.code] = c.values
+			}
+		}
+	}
+	return caps, nil
+}
+
+type group struct {
+	key   string
+	value string
+}
+
+// splitGroups 將 "key(value)key2(value2)" 拆成鍵值，value 內可再有括號。
+func splitGroups(text string) ([]group, error) {
+	var groups []group
+	for i := 0; i < len(text); {
+		open := strings.IndexByte(text[i:], '(')
+		if open < 0 {
+			if strings.TrimSpace(text[i:]) != "" {
+				return nil, fmt.Errorf("ddcci: trailing text %q", text[i:])
+			}
+			break
+		}
+		open += i
+		depth := 0
+		end := -1
+		for j := open; j < len(text); j++ {
+			switch text[j] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("ddcci: unbalanced parentheses after %q", text[i:open])
+		}
+		groups = append(groups, group{key: text[i:open], value: text[open+1 : end]})
+		i = end + 1
+	}
+	return groups, nil
+}
+
+type codeEntry struct {
+	code   byte
+	values []uint16
+}
+
+// parseCodeList 解析 "10 12 14(05 08 0B) 60(0F 11)" 形式的十六進位代碼清單。
+func parseCodeList(text string) ([]codeEntry, error) {
+	var entries []codeEntry
+	text = strings.TrimSpace(text)
+	for len(text) > 0 {
+		end := strings.IndexAny(text, " (")
+		token := text
+		if end >= 0 {
+			token = text[:end]
+		}
+		// 代碼固定為兩位十六進位；部分螢幕的代碼之間沒有空白，例如 "1012"。
+		if len(token) > 2 {
+			token = token[:2]
+			end = 2
+		}
+		code, err := strconv.ParseUint(token, 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid code %q", token)
+		}
+		entry := codeEntry{code: byte(code)}
+		rest := ""
+		if end >= 0 {
+			rest = text[end:]
+		}
+		if strings.HasPrefix(rest, "(") {
+			close := strings.IndexByte(rest, ')')
+			if close < 0 {
+				return nil, fmt.Errorf("unbalanced values for code %s", token)
+			}
+			for _, field := range strings.Fields(rest[1:close]) {
+				v, err := strconv.ParseUint(field, 16, 16)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q for code %s", field, token)
+				}
+				entry.values = append(entry.values, uint16(v))
+			}
+			rest = rest[close+1:]
+		}
+		entries = append(entries, entry)
+		text = strings.TrimSpace(rest)
+	}
+	return entries, nil
+}
@@ -0,0 +1,252 @@
+// Package ddcci 在 GPU 驅動的 I2C-over-AUX 通道上實作 DDC/CI（MCCS）協定，
+// 提供 VCP 讀寫與能力字串查詢，用於外接 DP 螢幕的亮度、對比與色彩預設調整。
+//
+// 寫入時將來源位址 0x51 作為 I2C 暫存器位元組送出，讀取時暫存器為 0，
+// 因此驅動需實作 gpu.DirectI2C：暫存器為 0 的讀取直接發出讀取交易、不可先寫入位移，
+// 且須支援多位元組寫入。不支援的驅動會回傳 ErrDirectI2CUnsupported，不會送出錯誤的框架。
+package ddcci
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+const (
+	// SlaveAddress 為 DDC/CI 的 7-bit 從屬位址（8-bit 0x6E/0x6F）。
+	SlaveAddress = 0x37
+
+	hostAddress    = 0x51 // 主機端來源位址
+	displayAddress = 0x6E // 螢幕端 8-bit 寫入位址，計算主機訊息的檢查碼
+	replyAddress   = 0x50 // 計算螢幕回覆檢查碼時使用的虛擬目的位址
+
+	opGetVCP         = 0x01
+	opGetVCPReply    = 0x02
+	opSetVCP         = 0x03
+	opCapabilities   = 0xF3
+	opCapsReply      = 0xE3
+	maxCapsFragment  = 32
+	maxCapsLength    = 8192
+	getVCPReplyBytes = 11
+)
+
+var (
+	// ErrChecksum 表示回覆的檢查碼錯誤。
+	ErrChecksum = errors.New("ddcci: checksum mismatch")
+	// ErrUnsupportedVCP 表示螢幕回報不支援該 VCP 代碼。
+	ErrUnsupportedVCP = errors.New("ddcci: unsupported vcp code")
+	// ErrNullMessage 表示螢幕回覆空訊息，通常代表忙碌或尚未準備好。
+	ErrNullMessage = errors.New("ddcci: null message")
+	// ErrInvalidReply 表示回覆格式不符合 MCCS 規範。
+	ErrInvalidReply = errors.New("ddcci: invalid reply")
+	// ErrDirectI2CUnsupported 表示驅動無法發出不帶索引的讀取或多位元組寫入。
+	ErrDirectI2CUnsupported = errors.New("ddcci: driver cannot issue raw i2c transactions at 0x37")
+)
+
+// VCPValue 為 Get VCP Feature 的回覆內容。
+type VCPValue struct {
+	Code    byte
+	Type    byte // 0 = set parameter，1 = momentary
+	Current uint16
+	Max     uint16
+}
+
+// Client 以指定驅動與螢幕進行 DDC/CI 通訊，並維持規範要求的訊息間隔。
+type Client struct {
+	driver gpu.Driver
+
+	// ReplyDelay 為送出請求後到讀取回覆前的等待時間（Get VCP 規範為 40 ms）。
+	ReplyDelay time.Duration
+	// CommandDelay 為兩個訊息之間的最小間隔（規範為 50 ms）。
+	CommandDelay time.Duration
+	// Retries 為遇到空訊息或檢查碼錯誤時的重試次數。
+	Retries int
+
+	mu    sync.Mutex
+	last  time.Time
+	sleep func(time.Duration)
+	now   func() time.Time
+}
+
+// New 建立使用 MCCS 預設延遲的 DDC/CI 用戶端。
+func New(driver gpu.Driver) *Client {
+	return &Client{
+		driver:       driver,
+		ReplyDelay:   40 * time.Millisecond,
+		CommandDelay: 50 * time.Millisecond,
+		Retries:      2,
+		sleep:        time.Sleep,
+		now:          time.Now,
+	}
+}
+
+// GetVCP 讀取 VCP 代碼的目前值與最大值。
+func (c *Client) GetVCP(code byte) (VCPValue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var value VCPValue
+	err := c.retry(func() error {
+		reply, err := c.transact([]byte{opGetVCP, code}, getVCPReplyBytes, c.ReplyDelay)
+		if err != nil {
+			return err
+		}
+		// 回覆：opcode 0x02、結果碼、VCP 代碼、類型、最大值 2 位元組、目前值 2 位元組。
+		if len(reply) != 8 || reply[0] != opGetVCPReply {
+			return fmt.Errorf("%w: unexpected get vcp reply % X", ErrInvalidReply, reply)
+		}
+		if reply[1] == 0x01 {
+			return fmt.Errorf("%w 0x%02X", ErrUnsupportedVCP, code)
+		}
+		if reply[1] != 0x00 || reply[2] != code {
+			return fmt.Errorf("%w: get vcp 0x%02X returned % X", ErrInvalidReply, code, reply)
+		}
+		value = VCPValue{
+			Code:    code,
+			Type:    reply[3],
+			Max:     uint16(reply[4])<<8 | uint16(reply[5]),
+			Current: uint16(reply[6])<<8 | uint16(reply[7]),
+		}
+		return nil
+	})
+	return value, err
+}
+
+// SetVCP 寫入 VCP 代碼的數值；MCCS 的 Set VCP 沒有回覆，需要時請再以 GetVCP 確認。
+func (c *Client) SetVCP(code byte, value uint16) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.send([]byte{opSetVCP, code, byte(value >> 8), byte(value)})
+}
+
+// Capabilities 分段讀取完整的能力字串。
+func (c *Client) Capabilities() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var caps []byte
+	for len(caps) < maxCapsLength {
+		offset := len(caps)
+		var fragment []byte
+		err := c.retry(func() error {
+			reply, err := c.transact([]byte{opCapabilities, byte(offset >> 8), byte(offset)}, 3+maxCapsFragment+3, c.CommandDelay)
+			if err != nil {
+				return err
+			}
+			if len(reply) < 3 || reply[0] != opCapsReply {
+				return fmt.Errorf("%w: unexpected capabilities reply % X", ErrInvalidReply, reply)
+			}
+			if got := int(reply[1])<<8 | int(reply[2]); got != offset {
+				return fmt.Errorf("%w: capabilities offset %d, want %d", ErrInvalidReply, got, offset)
+			}
+			fragment = reply[3:]
+			return nil
+		})
+		if err != nil {
+			return string(caps), err
+		}
+		if len(fragment) == 0 {
+			break
+		}
+		caps = append(caps, fragment...)
+	}
+	// 部分螢幕會以 NUL 結尾。
+	for len(caps) > 0 && caps[len(caps)-1] == 0 {
+		caps = caps[:len(caps)-1]
+	}
+	return string(caps), nil
+}
+
+// retry 在空訊息或檢查碼錯誤時重試。
+func (c *Client) retry(fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= c.Retries; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrNullMessage) && !errors.Is(err, ErrChecksum) {
+			return err
+		}
+	}
+	return err
+}
+
+// transact 送出請求、等待 delay 後讀取最多 readLen 位元組的回覆，並回傳去除框架後的內容。
+func (c *Client) transact(payload []byte, readLen int, delay time.Duration) ([]byte, error) {
+	if err := c.send(payload); err != nil {
+		return nil, err
+	}
+	c.sleep(delay)
+	raw, err := c.driver.ReadI2C(SlaveAddress, uint32(readLen))
+	c.last = c.now()
+	if err != nil {
+		return nil, fmt.Errorf("ddcci: read reply: %w", err)
+	}
+	return decodeReply(raw)
+}
+
+// send 組成「來源位址、長度、內容、檢查碼」的訊息並寫出。
+func (c *Client) send(payload []byte) error {
+	if c.driver == nil {
+		return gpu.ErrNoDriver
+	}
+	if !gpu.SupportsDirectI2C(c.driver) {
+		return fmt.Errorf("%w (%s)", ErrDirectI2CUnsupported, c.driver.Name())
+	}
+	if wait := c.CommandDelay - c.now().Sub(c.last); !c.last.IsZero() && wait > 0 {
+		c.sleep(wait)
+	}
+	frame := encodeRequest(payload)
+	// 來源位址 0x51 作為暫存器位元組送出，其餘為資料。
+	err := c.driver.WriteI2C(SlaveAddress|hostAddress<<8, frame[1:])
+	c.last = c.now()
+	if err != nil {
+		return fmt.Errorf("ddcci: write request: %w", err)
+	}
+	return nil
+}
+
+// encodeRequest 產生包含來源位址與檢查碼的完整請求。
+func encodeRequest(payload []byte) []byte {
+	frame := make([]byte, 0, len(payload)+3)
+	frame = append(frame, hostAddress, 0x80|byte(len(payload)))
+	frame = append(frame, payload...)
+	return append(frame, checksum(displayAddress, frame))
+}
+
+// decodeReply 驗證回覆框架並回傳內容；長度為 0 的回覆視為空訊息。
+func decodeReply(raw []byte) ([]byte, error) {
+	if len(raw) < 3 {
+		return nil, fmt.Errorf("%w: short reply % X", ErrInvalidReply, raw)
+	}
+	if raw[0] != displayAddress {
+		return nil, fmt.Errorf("%w: source 0x%02X", ErrInvalidReply, raw[0])
+	}
+	if raw[1]&0x80 == 0 {
+		return nil, fmt.Errorf("%w: length byte 0x%02X", ErrInvalidReply, raw[1])
+	}
+	length := int(raw[1] & 0x7F)
+	if length == 0 {
+		return nil, ErrNullMessage
+	}
+	if 2+length+1 > len(raw) {
+		return nil, fmt.Errorf("%w: length %d exceeds %d read bytes", ErrInvalidReply, length, len(raw))
+	}
+	body := raw[:2+length]
+	if checksum(replyAddress, body) != raw[2+length] {
+		return nil, ErrChecksum
+	}
+	return append([]byte(nil), raw[2:2+length]...), nil
+}
+
+// checksum 以 seed 與所有位元組做 XOR。
+func checksum(seed byte, data []byte) byte {
+	sum := seed
+	for _, b := range data {
+		sum ^= b
+	}
+	return sum
+}
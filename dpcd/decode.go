@@ -0,0 +1,206 @@
+// Package dpcd 提供 DisplayPort / eDP DPCD 暫存器資料庫與解碼器，
+// 將 ReadDPCD 取得的原始位元組轉成具名欄位與意義。
+package dpcd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Field 描述單一位元組暫存器中的位元欄位，High 與 Low 為包含的位元範圍。
+type Field struct {
+	Name   string
+	High   uint
+	Low    uint
+	Values map[uint32]string     // 已知數值的意義
+	Format func(v uint32) string // 需要換算時使用，優先於 Values
+}
+
+// extract 取出欄位數值。
+func (f Field) extract(b byte) uint32 {
+	width := f.High - f.Low + 1
+	return uint32(b>>f.Low) & (1<<width - 1)
+}
+
+// meaning 回傳欄位數值的說明；單一位元且未定義意義時以 yes/no 表示。
+func (f Field) meaning(v uint32) string {
+	if f.Format != nil {
+		return f.Format(v)
+	}
+	if f.Values != nil {
+		if text, ok := f.Values[v]; ok {
+			return text
+		}
+		return "reserved"
+	}
+	if f.High == f.Low {
+		if v != 0 {
+			return "yes"
+		}
+		return "no"
+	}
+	return ""
+}
+
+// Register 描述 DPCD 暫存器；多位元組暫存器以 Format 整體解碼。
+type Register struct {
+	Address uint32
+	Name    string
+	Group   string
+	Length  int // 0 視為 1
+	Fields  []Field
+	Format  func(data []byte) string
+}
+
+// Size 回傳暫存器佔用的位元組數。
+func (r Register) Size() int {
+	if r.Length <= 0 {
+		return 1
+	}
+	return r.Length
+}
+
+// FieldValue 為解碼後的欄位。
+type FieldValue struct {
+	Name    string
+	Value   uint32
+	Meaning string
+}
+
+// Decoded 為解碼後的暫存器。
+type Decoded struct {
+	Address uint32
+	Name    string
+	Group   string
+	Raw     []byte
+	Fields  []FieldValue
+	Summary string // 多位元組暫存器的整體說明
+}
+
+// String 以多行文字呈現解碼結果。
+func (d Decoded) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "0x%05X %-36s % X", d.Address, d.Name, d.Raw)
+	if d.Summary != "" {
+		fmt.Fprintf(&b, "  %s", d.Summary)
+	}
+	for _, f := range d.Fields {
+		fmt.Fprintf(&b, "\n        %-40s %-4d %s", f.Name, f.Value, f.Meaning)
+	}
+	return b.String()
+}
+
+// Lookup 依起始位址尋找暫存器定義。
+func Lookup(addr uint32) (Register, bool) {
+	i := sort.Search(len(registers), func(i int) bool { return registers[i].Address >= addr })
+	if i < len(registers) && registers[i].Address == addr {
+		return registers[i], true
+	}
+	return Register{}, false
+}
+
+// Find 尋找涵蓋 addr 的暫存器定義，多位元組暫存器的中間位址也會命中。
+func Find(addr uint32) (Register, bool) {
+	i := sort.Search(len(registers), func(i int) bool { return registers[i].Address > addr })
+	if i == 0 {
+		return Register{}, false
+	}
+	reg := registers[i-1]
+	if addr < reg.Address+uint32(reg.Size()) {
+		return reg, true
+	}
+	return Register{}, false
+}
+
+// Registers 回傳依位址排序的所有暫存器定義。
+func Registers() []Register {
+	return append([]Register(nil), registers...)
+}
+
+// Decode 將自 addr 起讀取的原始資料逐一對應暫存器並解碼；
+// 未定義的位址會略過，超出資料範圍的多位元組暫存器也不解碼。
+func Decode(addr uint32, data []byte) []Decoded {
+	var result []Decoded
+	for offset := 0; offset < len(data); {
+		reg, ok := Lookup(addr + uint32(offset))
+		if !ok {
+			offset++
+			continue
+		}
+		size := reg.Size()
+		if offset+size > len(data) {
+			break
+		}
+		raw := data[offset : offset+size]
+		decoded := Decoded{
+			Address: reg.Address,
+			Name:    reg.Name,
+			Group:   reg.Group,
+			Raw:     append([]byte(nil), raw...),
+		}
+		if reg.Format != nil {
+			decoded.Summary = reg.Format(raw)
+		}
+		for _, f := range reg.Fields {
+			v := f.extract(raw[0])
+			decoded.Fields = append(decoded.Fields, FieldValue{Name: f.Name, Value: v, Meaning: f.meaning(v)})
+		}
+		result = append(result, decoded)
+		offset += size
+	}
+	return result
+}
+
+// Format 解碼並輸出為多行文字。
+func Format(addr uint32, data []byte) string {
+	decoded := Decode(addr, data)
+	if len(decoded) == 0 {
+		return fmt.Sprintf("0x%05X: no known registers in % X", addr, data)
+	}
+	lines := make([]string, len(decoded))
+	for i, d := range decoded {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Range 為一段連續的 DPCD 位址。
+type Range struct {
+	Start  uint32
+	Length uint32
+}
+
+// Group 為同一類別暫存器，以及讀取它們所需的位址區段。
+type Group struct {
+	Name   string
+	Ranges []Range
+}
+
+// Groups 依資料庫順序回傳暫存器類別；間隔不超過 16 位元組的暫存器合併為同一區段，
+// 以減少 AUX 讀取次數。
+func Groups() []Group {
+	var groups []Group
+	index := map[string]int{}
+	for _, reg := range registers {
+		i, ok := index[reg.Group]
+		if !ok {
+			i = len(groups)
+			index[reg.Group] = i
+			groups = append(groups, Group{Name: reg.Group})
+		}
+		g := &groups[i]
+		end := reg.Address + uint32(reg.Size())
+		if n := len(g.Ranges); n > 0 {
+			last := &g.Ranges[n-1]
+			if reg.Address <= last.Start+last.Length+16 {
+				if end > last.Start+last.Length {
+					last.Length = end - last.Start
+				}
+				continue
+			}
+		}
+		g.Ranges = append(g.Ranges, Range{Start: reg.Address, Length: end - reg.Address})
+	}
+	return groups
+}
@@ -0,0 +1,552 @@
+package dpcd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 暫存器類別名稱。
+const (
+	GroupReceiverCap = "Receiver Capability"
+	GroupLinkConfig  = "Link Configuration"
+	GroupSinkStatus  = "Sink Status"
+	GroupPSR         = "PSR / Panel Replay"
+	GroupDeviceID    = "Source / Sink / Branch Device"
+	GroupPower       = "Power"
+	GroupBacklight   = "eDP Backlight"
+	GroupExtended    = "Extended Capability / ESI"
+)
+
+// linkRates 為 8b/10b 連結速率代碼，單位為每通道 Gbps。
+var linkRates = map[uint32]string{
+	0x06: "1.62 Gbps (RBR)",
+	0x0A: "2.7 Gbps (HBR)",
+	0x14: "5.4 Gbps (HBR2)",
+	0x1E: "8.1 Gbps (HBR3)",
+}
+
+var trainingPatterns = map[uint32]string{
+	0x0: "not in progress",
+	0x1: "TPS1",
+	0x2: "TPS2",
+	0x3: "TPS3",
+	0x7: "TPS4",
+}
+
+var selfRefreshStates = map[uint32]string{
+	0: "inactive",
+	1: "active, display from source (sync)",
+	2: "active, display from RFB",
+	3: "active, sink synced",
+	4: "resync",
+	7: "internal error",
+}
+
+func flag(name string, bit uint) Field {
+	return Field{Name: name, High: bit, Low: bit}
+}
+
+func bits(name string, high, low uint) Field {
+	return Field{Name: name, High: high, Low: low}
+}
+
+func enum(name string, high, low uint, values map[uint32]string) Field {
+	return Field{Name: name, High: high, Low: low, Values: values}
+}
+
+func formatted(name string, high, low uint, format func(uint32) string) Field {
+	return Field{Name: name, High: high, Low: low, Format: format}
+}
+
+func revision(data []byte) string {
+	return fmt.Sprintf("%d.%d", data[0]>>4, data[0]&0x0F)
+}
+
+func oui(data []byte) string {
+	return fmt.Sprintf("OUI %02X-%02X-%02X", data[0], data[1], data[2])
+}
+
+func deviceString(data []byte) string {
+	text := strings.TrimRight(string(data), "\x00 ")
+	printable := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7E {
+			return '.'
+		}
+		return r
+	}, text)
+	return fmt.Sprintf("%q", printable)
+}
+
+func firmwareRevision(data []byte) string {
+	return fmt.Sprintf("%d.%d", data[0], data[1])
+}
+
+func uint16BE(data []byte) string {
+	return fmt.Sprintf("%d (0x%04X)", binary.BigEndian.Uint16(data), binary.BigEndian.Uint16(data))
+}
+
+func symbolErrorCount(data []byte) string {
+	v := binary.LittleEndian.Uint16(data)
+	if v&0x8000 == 0 {
+		return fmt.Sprintf("count %d (invalid)", v&0x7FFF)
+	}
+	return fmt.Sprintf("count %d", v&0x7FFF)
+}
+
+// supportedLinkRates 解碼 eDP 1.4 的 SUPPORTED_LINK_RATES，每筆 2 位元組、單位 200 kHz。
+func supportedLinkRates(data []byte) string {
+	var rates []string
+	for i := 0; i+1 < len(data); i += 2 {
+		v := binary.LittleEndian.Uint16(data[i:])
+		if v == 0 {
+			break
+		}
+		rates = append(rates, fmt.Sprintf("[%d] %.2f Gbps", i/2, float64(v)*0.2/1000))
+	}
+	if len(rates) == 0 {
+		return "not used (use MAX_LINK_RATE)"
+	}
+	return strings.Join(rates, ", ")
+}
+
+func laneStatus(prefix string) []Field {
+	return []Field{
+		flag(prefix+"_CR_DONE", 0),
+		flag(prefix+"_CHANNEL_EQ_DONE", 1),
+		flag(prefix+"_SYMBOL_LOCKED", 2),
+	}
+}
+
+func laneStatusPair(lo, hi string) []Field {
+	fields := laneStatus(lo)
+	for _, f := range laneStatus(hi) {
+		f.High += 4
+		f.Low += 4
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func trainingLaneSet() []Field {
+	return []Field{
+		bits("VOLTAGE_SWING_SET", 1, 0),
+		flag("MAX_SWING_REACHED", 2),
+		bits("PRE_EMPHASIS_SET", 4, 3),
+		flag("MAX_PRE_EMPHASIS_REACHED", 5),
+	}
+}
+
+func adjustRequest(lo, hi string) []Field {
+	return []Field{
+		bits("VOLTAGE_SWING_"+lo, 1, 0),
+		bits("PRE_EMPHASIS_"+lo, 3, 2),
+		bits("VOLTAGE_SWING_"+hi, 5, 4),
+		bits("PRE_EMPHASIS_"+hi, 7, 6),
+	}
+}
+
+func receivePortCap() []Field {
+	return []Field{
+		flag("LOCAL_EDID_PRESENT", 1),
+		flag("ASSOCIATED_TO_PRECEDING_PORT", 2),
+		flag("HBLANK_EXPANSION_CAPABLE", 3),
+		enum("BUFFER_SIZE_UNIT", 4, 4, map[uint32]string{0: "pixels", 1: "bytes"}),
+		enum("BUFFER_SIZE_PER_PORT", 5, 5, map[uint32]string{0: "per lane", 1: "per port"}),
+	}
+}
+
+func bufferSize(v uint32) string {
+	return fmt.Sprintf("%d", (v+1)*32)
+}
+
+func i2cSpeeds() []Field {
+	return []Field{
+		flag("1 kbps", 0),
+		flag("5 kbps", 1),
+		flag("10 kbps", 2),
+		flag("100 kbps", 3),
+		flag("400 kbps", 4),
+		flag("1 Mbps", 5),
+	}
+}
+
+func channelCoding() []Field {
+	return []Field{
+		flag("8B10B", 0),
+		flag("128B132B", 1),
+	}
+}
+
+// receiverCapability 為 0x00000 起的接收端能力欄位，另複製於 0x02200 的延伸能力區。
+func receiverCapability(base uint32, group, prefix string) []Register {
+	return []Register{
+		{Address: base + 0x000, Name: prefix + "DPCD_REV", Group: group, Fields: []Field{
+			bits("MAJOR", 7, 4),
+			bits("MINOR", 3, 0),
+		}},
+		{Address: base + 0x001, Name: prefix + "MAX_LINK_RATE", Group: group, Fields: []Field{
+			enum("MAX_LINK_RATE", 7, 0, linkRates),
+		}},
+		{Address: base + 0x002, Name: prefix + "MAX_LANE_COUNT", Group: group, Fields: []Field{
+			bits("MAX_LANE_COUNT", 4, 0),
+			flag("POST_LT_ADJ_REQ_SUPPORTED", 5),
+			flag("TPS3_SUPPORTED", 6),
+			flag("ENHANCED_FRAME_CAP", 7),
+		}},
+		{Address: base + 0x003, Name: prefix + "MAX_DOWNSPREAD", Group: group, Fields: []Field{
+			enum("MAX_DOWNSPREAD", 0, 0, map[uint32]string{0: "none", 1: "up to 0.5%"}),
+			flag("STREAM_REGENERATION_STATUS_CAP", 1),
+			flag("NO_AUX_TRANSACTION_LINK_TRAINING", 6),
+			flag("TPS4_SUPPORTED", 7),
+		}},
+		{Address: base + 0x004, Name: prefix + "NORP_DP_PWR_VOLTAGE_CAP", Group: group, Fields: []Field{
+			formatted("NORP", 0, 0, func(v uint32) string { return fmt.Sprintf("%d receiver port(s)", v+1) }),
+			flag("5V_DP_PWR_CAP", 5),
+			flag("12V_DP_PWR_CAP", 6),
+			flag("18V_DP_PWR_CAP", 7),
+		}},
+		{Address: base + 0x005, Name: prefix + "DOWN_STREAM_PORT_PRESENT", Group: group, Fields: []Field{
+			flag("DFP_PRESENT", 0),
+			enum("DFP_TYPE", 2, 1, map[uint32]string{0: "DisplayPort", 1: "analog VGA", 2: "DVI / HDMI / DP++", 3: "other"}),
+			flag("FORMAT_CONVERSION", 3),
+			flag("DETAILED_CAP_INFO_AVAILABLE", 4),
+		}},
+		{Address: base + 0x006, Name: prefix + "MAIN_LINK_CHANNEL_CODING", Group: group, Fields: channelCoding()},
+		{Address: base + 0x007, Name: prefix + "DOWN_STREAM_PORT_COUNT", Group: group, Fields: []Field{
+			bits("DFP_COUNT", 3, 0),
+			flag("MSA_TIMING_PAR_IGNORED", 6),
+			flag("OUI_SUPPORT", 7),
+		}},
+		{Address: base + 0x008, Name: prefix + "RECEIVE_PORT0_CAP_0", Group: group, Fields: receivePortCap()},
+		{Address: base + 0x009, Name: prefix + "RECEIVE_PORT0_CAP_1", Group: group, Fields: []Field{
+			formatted("BUFFER_SIZE", 7, 0, bufferSize),
+		}},
+		{Address: base + 0x00A, Name: prefix + "RECEIVE_PORT1_CAP_0", Group: group, Fields: receivePortCap()},
+		{Address: base + 0x00B, Name: prefix + "RECEIVE_PORT1_CAP_1", Group: group, Fields: []Field{
+			formatted("BUFFER_SIZE", 7, 0, bufferSize),
+		}},
+		{Address: base + 0x00C, Name: prefix + "I2C_SPEED_CONTROL_CAP", Group: group, Fields: i2cSpeeds()},
+		{Address: base + 0x00D, Name: prefix + "EDP_CONFIGURATION_CAP", Group: group, Fields: []Field{
+			flag("ALTERNATE_SCRAMBLER_RESET_CAPABLE", 0),
+			flag("FRAMING_CHANGE_CAPABLE", 1),
+			flag("DPCD_DISPLAY_CONTROL_CAPABLE", 3),
+		}},
+		{Address: base + 0x00E, Name: prefix + "TRAINING_AUX_RD_INTERVAL", Group: group, Fields: []Field{
+			enum("TRAINING_AUX_RD_INTERVAL", 6, 0, map[uint32]string{
+				0: "100 us CR / 400 us EQ",
+				1: "4 ms",
+				2: "8 ms",
+				3: "12 ms",
+				4: "16 ms",
+			}),
+			flag("EXTENDED_RECEIVER_CAP_FIELD_PRESENT", 7),
+		}},
+		{Address: base + 0x00F, Name: prefix + "ADAPTER_CAP", Group: group, Fields: []Field{
+			flag("FORCE_LOAD_SENSE_CAP", 0),
+			flag("ALTERNATE_I2C_PATTERN_CAP", 1),
+		}},
+	}
+}
+
+var registers = buildRegisters()
+
+func buildRegisters() []Register {
+	var regs []Register
+	regs = append(regs, receiverCapability(0x00000, GroupReceiverCap, "")...)
+	regs = append(regs,
+		Register{Address: 0x00010, Name: "SUPPORTED_LINK_RATES", Group: GroupReceiverCap, Length: 16, Format: supportedLinkRates},
+		Register{Address: 0x00021, Name: "MSTM_CAP", Group: GroupReceiverCap, Fields: []Field{
+			flag("MST_CAP", 0),
+			flag("SINGLE_STREAM_SIDEBAND_MSG", 1),
+		}},
+		Register{Address: 0x00022, Name: "NUMBER_OF_AUDIO_ENDPOINTS", Group: GroupReceiverCap, Fields: []Field{
+			bits("AUDIO_ENDPOINTS", 7, 0),
+		}},
+		Register{Address: 0x00060, Name: "DSC_SUPPORT", Group: GroupReceiverCap, Fields: []Field{
+			flag("DSC_DECOMPRESSION_SUPPORTED", 0),
+			flag("DSC_PASSTHROUGH_SUPPORTED", 1),
+		}},
+		Register{Address: 0x00061, Name: "DSC_REV", Group: GroupReceiverCap, Fields: []Field{
+			bits("MAJOR", 3, 0),
+			bits("MINOR", 7, 4),
+		}},
+		Register{Address: 0x00070, Name: "PSR_SUPPORT", Group: GroupPSR, Fields: []Field{
+			enum("PSR_VERSION", 7, 0, map[uint32]string{
+				0: "not supported",
+				1: "PSR",
+				2: "PSR2",
+				3: "PSR2 with Y-coordinate",
+			}),
+		}},
+		Register{Address: 0x00071, Name: "PSR_CAPABILITIES", Group: GroupPSR, Fields: []Field{
+			flag("LINK_TRAINING_ON_EXIT_NOT_REQUIRED", 0),
+			enum("PSR_SETUP_TIME", 3, 1, map[uint32]string{
+				0: "330 us", 1: "275 us", 2: "220 us", 3: "165 us", 4: "110 us", 5: "55 us", 6: "0 us",
+			}),
+			flag("Y_COORDINATE_REQUIRED", 4),
+			flag("SU_GRANULARITY_REQUIRED", 5),
+		}},
+		Register{Address: 0x00090, Name: "FEC_CAPABILITY", Group: GroupReceiverCap, Fields: []Field{
+			flag("FEC_CAPABLE", 0),
+			flag("UNCORRECTED_BLOCK_ERROR_COUNT_CAP", 1),
+			flag("CORRECTED_BLOCK_ERROR_COUNT_CAP", 2),
+			flag("BIT_ERROR_COUNT_CAP", 3),
+		}},
+		Register{Address: 0x000B0, Name: "PANEL_REPLAY_CAP", Group: GroupPSR, Fields: []Field{
+			flag("PANEL_REPLAY_SUPPORT", 0),
+			flag("SELECTIVE_UPDATE_SUPPORT", 1),
+		}},
+
+		Register{Address: 0x00100, Name: "LINK_BW_SET", Group: GroupLinkConfig, Fields: []Field{
+			enum("LINK_BW_SET", 7, 0, mergeValues(linkRates, map[uint32]string{0: "use LINK_RATE_SET"})),
+		}},
+		Register{Address: 0x00101, Name: "LANE_COUNT_SET", Group: GroupLinkConfig, Fields: []Field{
+			bits("LANE_COUNT_SET", 4, 0),
+			flag("POST_LT_ADJ_REQ_GRANTED", 5),
+			flag("ENHANCED_FRAME_EN", 7),
+		}},
+		Register{Address: 0x00102, Name: "TRAINING_PATTERN_SET", Group: GroupLinkConfig, Fields: []Field{
+			enum("TRAINING_PATTERN_SELECT", 3, 0, trainingPatterns),
+			flag("RECOVERED_CLOCK_OUT_EN", 4),
+			flag("SCRAMBLING_DISABLE", 5),
+			enum("SYMBOL_ERROR_COUNT_SEL", 7, 6, map[uint32]string{
+				0: "disparity and illegal symbol",
+				1: "disparity",
+				2: "illegal symbol",
+				3: "reserved (TCON vendor mode on some panels)",
+			}),
+		}},
+		Register{Address: 0x00103, Name: "TRAINING_LANE0_SET", Group: GroupLinkConfig, Fields: trainingLaneSet()},
+		Register{Address: 0x00104, Name: "TRAINING_LANE1_SET", Group: GroupLinkConfig, Fields: trainingLaneSet()},
+		Register{Address: 0x00105, Name: "TRAINING_LANE2_SET", Group: GroupLinkConfig, Fields: trainingLaneSet()},
+		Register{Address: 0x00106, Name: "TRAINING_LANE3_SET", Group: GroupLinkConfig, Fields: trainingLaneSet()},
+		Register{Address: 0x00107, Name: "DOWNSPREAD_CTRL", Group: GroupLinkConfig, Fields: []Field{
+			enum("SPREAD_AMP", 4, 4, map[uint32]string{0: "off", 1: "0.5%"}),
+			flag("MSA_TIMING_PAR_IGNORE_EN", 7),
+		}},
+		Register{Address: 0x00108, Name: "MAIN_LINK_CHANNEL_CODING_SET", Group: GroupLinkConfig, Fields: channelCoding()},
+		Register{Address: 0x00109, Name: "I2C_SPEED_CONTROL", Group: GroupLinkConfig, Fields: i2cSpeeds()},
+		Register{Address: 0x0010A, Name: "EDP_CONFIGURATION_SET", Group: GroupLinkConfig, Fields: []Field{
+			flag("ALTERNATE_SCRAMBLER_RESET_ENABLE", 0),
+			flag("FRAMING_CHANGE_ENABLE", 1),
+			flag("PANEL_SELF_TEST_ENABLE", 7),
+		}},
+		Register{Address: 0x00111, Name: "MSTM_CTRL", Group: GroupLinkConfig, Fields: []Field{
+			flag("MST_EN", 0),
+			flag("UP_REQ_EN", 1),
+			flag("UPSTREAM_IS_SRC", 2),
+		}},
+		Register{Address: 0x00115, Name: "LINK_RATE_SET", Group: GroupLinkConfig, Fields: []Field{
+			bits("LINK_RATE_SET_INDEX", 2, 0),
+		}},
+		Register{Address: 0x00120, Name: "FEC_CONFIGURATION", Group: GroupLinkConfig, Fields: []Field{
+			flag("FEC_READY", 0),
+		}},
+		Register{Address: 0x00160, Name: "DSC_ENABLE", Group: GroupLinkConfig, Fields: []Field{
+			flag("DECOMPRESSION_ENABLE", 0),
+		}},
+		Register{Address: 0x00170, Name: "PSR_EN_CFG", Group: GroupPSR, Fields: []Field{
+			flag("PSR_ENABLE", 0),
+			flag("MAIN_LINK_ACTIVE", 1),
+			flag("CRC_VERIFICATION", 2),
+			flag("FRAME_CAPTURE_INDICATION", 3),
+			flag("SU_REGION_SCANLINE_CAPTURE", 4),
+			flag("IRQ_HPD_WITH_CRC_ERRORS", 5),
+			flag("ENABLE_PSR2", 6),
+		}},
+		Register{Address: 0x001B0, Name: "PANEL_REPLAY_ENABLE", Group: GroupPSR, Fields: []Field{
+			flag("PANEL_REPLAY_ENABLE", 0),
+			flag("SU_ENABLE", 5),
+		}},
+
+		Register{Address: 0x00200, Name: "SINK_COUNT", Group: GroupSinkStatus, Fields: []Field{
+			bits("SINK_COUNT", 5, 0),
+			flag("CP_READY", 6),
+		}},
+		Register{Address: 0x00201, Name: "DEVICE_SERVICE_IRQ_VECTOR", Group: GroupSinkStatus, Fields: []Field{
+			flag("AUTOMATED_TEST_REQUEST", 1),
+			flag("CP_IRQ", 2),
+			flag("MCCS_IRQ", 3),
+			flag("DOWN_REP_MSG_RDY", 4),
+			flag("UP_REQ_MSG_RDY", 5),
+			flag("SINK_SPECIFIC_IRQ", 6),
+		}},
+		Register{Address: 0x00202, Name: "LANE0_1_STATUS", Group: GroupSinkStatus, Fields: laneStatusPair("LANE0", "LANE1")},
+		Register{Address: 0x00203, Name: "LANE2_3_STATUS", Group: GroupSinkStatus, Fields: laneStatusPair("LANE2", "LANE3")},
+		Register{Address: 0x00204, Name: "LANE_ALIGN_STATUS_UPDATED", Group: GroupSinkStatus, Fields: []Field{
+			flag("INTERLANE_ALIGN_DONE", 0),
+			flag("DOWNSTREAM_PORT_STATUS_CHANGED", 6),
+			flag("LINK_STATUS_UPDATED", 7),
+		}},
+		Register{Address: 0x00205, Name: "SINK_STATUS", Group: GroupSinkStatus, Fields: []Field{
+			flag("RECEIVE_PORT_0_IN_SYNC", 0),
+			flag("RECEIVE_PORT_1_IN_SYNC", 1),
+		}},
+		Register{Address: 0x00206, Name: "ADJUST_REQUEST_LANE0_1", Group: GroupSinkStatus, Fields: adjustRequest("LANE0", "LANE1")},
+		Register{Address: 0x00207, Name: "ADJUST_REQUEST_LANE2_3", Group: GroupSinkStatus, Fields: adjustRequest("LANE2", "LANE3")},
+		Register{Address: 0x00210, Name: "SYMBOL_ERROR_COUNT_LANE0", Group: GroupSinkStatus, Length: 2, Format: symbolErrorCount},
+		Register{Address: 0x00212, Name: "SYMBOL_ERROR_COUNT_LANE1", Group: GroupSinkStatus, Length: 2, Format: symbolErrorCount},
+		Register{Address: 0x00214, Name: "SYMBOL_ERROR_COUNT_LANE2", Group: GroupSinkStatus, Length: 2, Format: symbolErrorCount},
+		Register{Address: 0x00216, Name: "SYMBOL_ERROR_COUNT_LANE3", Group: GroupSinkStatus, Length: 2, Format: symbolErrorCount},
+		Register{Address: 0x00218, Name: "TEST_REQUEST", Group: GroupSinkStatus, Fields: []Field{
+			flag("TEST_LINK_TRAINING", 0),
+			flag("TEST_PATTERN", 1),
+			flag("TEST_EDID_READ", 2),
+			flag("PHY_TEST_PATTERN", 3),
+		}},
+
+		Register{Address: 0x00300, Name: "SOURCE_IEEE_OUI", Group: GroupDeviceID, Length: 3, Format: oui},
+		Register{Address: 0x00400, Name: "SINK_IEEE_OUI", Group: GroupDeviceID, Length: 3, Format: oui},
+		Register{Address: 0x00403, Name: "SINK_DEVICE_ID_STRING", Group: GroupDeviceID, Length: 6, Format: deviceString},
+		Register{Address: 0x00409, Name: "SINK_HARDWARE_REVISION", Group: GroupDeviceID, Format: revision},
+		Register{Address: 0x0040A, Name: "SINK_FIRMWARE_REVISION", Group: GroupDeviceID, Length: 2, Format: firmwareRevision},
+		Register{Address: 0x00500, Name: "BRANCH_IEEE_OUI", Group: GroupDeviceID, Length: 3, Format: oui},
+		Register{Address: 0x00503, Name: "BRANCH_DEVICE_ID_STRING", Group: GroupDeviceID, Length: 6, Format: deviceString},
+		Register{Address: 0x00509, Name: "BRANCH_HARDWARE_REVISION", Group: GroupDeviceID, Format: revision},
+		Register{Address: 0x0050A, Name: "BRANCH_FIRMWARE_REVISION", Group: GroupDeviceID, Length: 2, Format: firmwareRevision},
+
+		Register{Address: 0x00600, Name: "SET_POWER", Group: GroupPower, Fields: []Field{
+			enum("SET_POWER_STATE", 2, 0, map[uint32]string{
+				1: "D0 normal operation",
+				2: "D3 power down",
+				5: "D3 power down, AUX powered",
+			}),
+		}},
+
+		Register{Address: 0x00700, Name: "EDP_DPCD_REV", Group: GroupBacklight, Fields: []Field{
+			enum("EDP_VERSION", 7, 0, map[uint32]string{
+				0: "eDP 1.1 or lower",
+				1: "eDP 1.2",
+				2: "eDP 1.3",
+				3: "eDP 1.4",
+				4: "eDP 1.4a",
+				5: "eDP 1.4b",
+				6: "eDP 1.5",
+			}),
+		}},
+		Register{Address: 0x00701, Name: "EDP_GENERAL_CAPABILITY_1", Group: GroupBacklight, Fields: []Field{
+			flag("TCON_BACKLIGHT_ADJUSTMENT_CAP", 0),
+			flag("BACKLIGHT_PIN_ENABLE_CAP", 1),
+			flag("BACKLIGHT_AUX_ENABLE_CAP", 2),
+			flag("PANEL_SELF_TEST_PIN_ENABLE_CAP", 3),
+			flag("PANEL_SELF_TEST_AUX_ENABLE_CAP", 4),
+			flag("FRC_ENABLE_CAP", 5),
+			flag("COLOR_ENGINE_CAP", 6),
+			flag("SET_POWER_CAP", 7),
+		}},
+		Register{Address: 0x00702, Name: "EDP_BACKLIGHT_ADJUSTMENT_CAP", Group: GroupBacklight, Fields: []Field{
+			flag("BRIGHTNESS_PWM_PIN_CAP", 0),
+			flag("BRIGHTNESS_AUX_SET_CAP", 1),
+			enum("BRIGHTNESS_BYTE_COUNT", 2, 2, map[uint32]string{0: "8-bit", 1: "16-bit"}),
+			flag("AUX_PWM_PRODUCT_CAP", 3),
+			flag("FREQ_PWM_PIN_PASSTHRU_CAP", 4),
+			flag("FREQ_AUX_SET_CAP", 5),
+			flag("DYNAMIC_BACKLIGHT_CAP", 6),
+			flag("VBLANK_BACKLIGHT_UPDATE_CAP", 7),
+		}},
+		Register{Address: 0x00703, Name: "EDP_GENERAL_CAPABILITY_2", Group: GroupBacklight, Fields: []Field{
+			flag("OVERDRIVE_ENGINE_ENABLED", 0),
+			flag("PANEL_LUMINANCE_CONTROL_CAPABLE", 4),
+		}},
+		Register{Address: 0x00720, Name: "EDP_DISPLAY_CONTROL", Group: GroupBacklight, Fields: []Field{
+			flag("BACKLIGHT_ENABLE", 0),
+			flag("BLACK_VIDEO_ENABLE", 1),
+			flag("FRC_ENABLE", 2),
+			flag("COLOR_ENGINE_ENABLE", 3),
+			flag("VBLANK_BACKLIGHT_UPDATE_ENABLE", 7),
+		}},
+		Register{Address: 0x00721, Name: "EDP_BACKLIGHT_MODE_SET", Group: GroupBacklight, Fields: []Field{
+			enum("BACKLIGHT_CONTROL_MODE", 1, 0, map[uint32]string{0: "PWM pin", 1: "preset level", 2: "DPCD brightness", 3: "DPCD x PWM product"}),
+			flag("FREQ_PWM_PIN_PASSTHRU_ENABLE", 2),
+			flag("FREQ_AUX_SET_ENABLE", 3),
+			flag("DYNAMIC_BACKLIGHT_ENABLE", 4),
+			flag("REGIONAL_BACKLIGHT_ENABLE", 5),
+			flag("UPDATE_REGION_BRIGHTNESS", 6),
+			flag("PANEL_LUMINANCE_CONTROL_ENABLE", 7),
+		}},
+		Register{Address: 0x00722, Name: "EDP_BACKLIGHT_BRIGHTNESS", Group: GroupBacklight, Length: 2, Format: uint16BE},
+		Register{Address: 0x00724, Name: "EDP_PWMGEN_BIT_COUNT", Group: GroupBacklight, Fields: []Field{bits("PWMGEN_BIT_COUNT", 4, 0)}},
+		Register{Address: 0x00725, Name: "EDP_PWMGEN_BIT_COUNT_CAP_MIN", Group: GroupBacklight, Fields: []Field{bits("PWMGEN_BIT_COUNT_MIN", 4, 0)}},
+		Register{Address: 0x00726, Name: "EDP_PWMGEN_BIT_COUNT_CAP_MAX", Group: GroupBacklight, Fields: []Field{bits("PWMGEN_BIT_COUNT_MAX", 4, 0)}},
+		Register{Address: 0x00728, Name: "EDP_BACKLIGHT_FREQ_SET", Group: GroupBacklight, Fields: []Field{bits("BACKLIGHT_FREQ_SET", 7, 0)}},
+
+		Register{Address: 0x02002, Name: "SINK_COUNT_ESI", Group: GroupExtended, Fields: []Field{
+			bits("SINK_COUNT", 5, 0),
+			flag("CP_READY", 6),
+		}},
+		Register{Address: 0x02003, Name: "DEVICE_SERVICE_IRQ_VECTOR_ESI0", Group: GroupExtended, Fields: []Field{
+			flag("REMOTE_CONTROL_COMMAND_PENDING", 0),
+			flag("AUTOMATED_TEST_REQUEST", 1),
+			flag("CP_IRQ", 2),
+			flag("MCCS_IRQ", 3),
+			flag("DOWN_REP_MSG_RDY", 4),
+			flag("UP_REQ_MSG_RDY", 5),
+			flag("SINK_SPECIFIC_IRQ", 6),
+		}},
+		Register{Address: 0x02006, Name: "PSR_ERROR_STATUS", Group: GroupPSR, Fields: []Field{
+			flag("LINK_CRC_ERROR", 0),
+			flag("RFB_STORAGE_ERROR", 1),
+			flag("VSC_SDP_UNCORRECTABLE_ERROR", 2),
+		}},
+		Register{Address: 0x02007, Name: "PSR_EVENT_STATUS_INDICATOR", Group: GroupPSR, Fields: []Field{
+			flag("PSR_CAPS_CHANGE", 0),
+		}},
+		Register{Address: 0x02008, Name: "PSR_STATUS", Group: GroupPSR, Fields: []Field{
+			enum("SINK_SELF_REFRESH_STATUS", 2, 0, selfRefreshStates),
+		}},
+		Register{Address: 0x0200C, Name: "LANE0_1_STATUS_ESI", Group: GroupExtended, Fields: laneStatusPair("LANE0", "LANE1")},
+		Register{Address: 0x0200D, Name: "LANE2_3_STATUS_ESI", Group: GroupExtended, Fields: laneStatusPair("LANE2", "LANE3")},
+		Register{Address: 0x0200E, Name: "LANE_ALIGN_STATUS_UPDATED_ESI", Group: GroupExtended, Fields: []Field{
+			flag("INTERLANE_ALIGN_DONE", 0),
+			flag("DOWNSTREAM_PORT_STATUS_CHANGED", 6),
+			flag("LINK_STATUS_UPDATED", 7),
+		}},
+		Register{Address: 0x0200F, Name: "SINK_STATUS_ESI", Group: GroupExtended, Fields: []Field{
+			flag("RECEIVE_PORT_0_IN_SYNC", 0),
+			flag("RECEIVE_PORT_1_IN_SYNC", 1),
+		}},
+		Register{Address: 0x02020, Name: "PANEL_REPLAY_ERROR_STATUS", Group: GroupPSR, Fields: []Field{
+			flag("LINK_CRC_ERROR", 0),
+			flag("RFB_STORAGE_ERROR", 1),
+			flag("VSC_SDP_UNCORRECTABLE_ERROR", 2),
+		}},
+		Register{Address: 0x02022, Name: "PANEL_REPLAY_STATUS", Group: GroupPSR, Fields: []Field{
+			enum("SINK_DEVICE_PANEL_REPLAY_STATUS", 2, 0, selfRefreshStates),
+		}},
+	)
+
+	// DP 1.3 起，0x02200 為延伸接收端能力，MAX_LINK_RATE 可回報 HBR3 以上速率。
+	regs = append(regs, receiverCapability(0x02200, GroupExtended, "EXT_")...)
+	regs = append(regs,
+		Register{Address: 0x02210, Name: "DPRX_FEATURE_ENUMERATION_LIST", Group: GroupExtended, Fields: []Field{
+			flag("GTC_CAP", 0),
+			flag("SST_SPLIT_SDP_CAP", 1),
+			flag("AV_SYNC_CAP", 2),
+			flag("VSC_SDP_EXT_FOR_COLORIMETRY_SUPPORTED", 3),
+			flag("VSC_EXT_VESA_SDP_SUPPORTED", 4),
+			flag("VSC_EXT_VESA_SDP_CHAINING_SUPPORTED", 5),
+			flag("VSC_EXT_CEA_SDP_SUPPORTED", 6),
+			flag("VSC_EXT_CEA_SDP_CHAINING_SUPPORTED", 7),
+		}},
+		Register{Address: 0x02215, Name: "128B132B_SUPPORTED_LINK_RATES", Group: GroupExtended, Fields: []Field{
+			flag("UHBR10", 0),
+			flag("UHBR20", 1),
+			flag("UHBR13.5", 2),
+		}},
+	)
+
+	sort.Slice(regs, func(i, j int) bool { return regs[i].Address < regs[j].Address })
+	return regs
+}
+
+func mergeValues(maps ...map[uint32]string) map[uint32]string {
+	merged := map[uint32]string{}
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,188 @@
+package edidhelper
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrNoEDIDData 表示檔案或貼上的文字中找不到 EDID 位元組。
+var ErrNoEDIDData = errors.New("edid: no EDID bytes found")
+
+// infOverrideKey 為 Windows 顯示器 INF 中覆寫 EDID 的登錄值名稱。
+const infOverrideKey = "EDID_OVERRIDE"
+
+// LoadEDIDFile 依副檔名讀取 EDID 檔案：.bin 為原始位元組，.inf 取出 EDID_OVERRIDE
+// 登錄項目，其餘（.hex、.dat、.txt 等）若以 EDID 標頭開始視為二進位，否則解析十六進位文字。
+func LoadEDIDFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var edid []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bin":
+		edid = data
+	case ".inf":
+		edid, err = ParseINF(string(data))
+	default:
+		if bytes.HasPrefix(data, edidHeader) {
+			edid = data
+		} else {
+			edid, err = ParseEDIDHex(string(data))
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("edid: %s: %w", filepath.Base(path), err)
+	}
+	if len(edid) == 0 {
+		return nil, fmt.Errorf("edid: %s: %w", filepath.Base(path), ErrNoEDIDData)
+	}
+	return edid, nil
+}
+
+// SaveEDIDFile 依副檔名儲存 EDID：.bin 為原始位元組，.hex、.dat、.txt 為每行 16 位元組的
+// 十六進位文字，.inf 為可貼入顯示器 INF 的 EDID_OVERRIDE AddReg 區段。
+func SaveEDIDFile(path string, edid []byte) error {
+	if len(edid) == 0 {
+		return ErrNoEDIDData
+	}
+
+	var data []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bin":
+		data = edid
+	case ".hex", ".dat", ".txt":
+		data = []byte(FormatEDIDHex(edid))
+	case ".inf":
+		data = []byte(FormatINF(edid))
+	default:
+		return fmt.Errorf("edid: unsupported file extension %q (use .bin, .hex, .dat, .txt or .inf)", filepath.Ext(path))
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ParseEDIDHex 解析貼上或文字檔中的十六進位 EDID。可接受以空白或逗號分隔、帶 0x 前綴
+// 或連續的十六進位字串；以 "|" 分隔位移欄的表格只取 "|" 之後的內容，以 ":" 結尾的
+// 位移欄會略過，含有非十六進位字詞的行視為標題或註解而忽略。
+func ParseEDIDHex(text string) ([]byte, error) {
+	tabular := strings.Contains(text, "|")
+	var edid []byte
+	for _, line := range strings.Split(text, "\n") {
+		if tabular {
+			index := strings.LastIndex(line, "|")
+			if index < 0 {
+				continue
+			}
+			line = line[index+1:]
+		}
+		if row, ok := parseHexLine(line); ok {
+			edid = append(edid, row...)
+		}
+	}
+	if len(edid) == 0 {
+		return nil, ErrNoEDIDData
+	}
+	return edid, nil
+}
+
+// parseHexLine 解析一行十六進位位元組；遇到無法解析的字詞時回傳 false。
+func parseHexLine(line string) ([]byte, bool) {
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == ';'
+	})
+	var row []byte
+	for _, field := range fields {
+		if strings.HasSuffix(field, ":") {
+			continue
+		}
+		field = strings.TrimPrefix(strings.TrimPrefix(field, "0x"), "0X")
+		data, err := hex.DecodeString(field)
+		if err != nil || len(data) == 0 {
+			return nil, false
+		}
+		row = append(row, data...)
+	}
+	return row, len(row) > 0
+}
+
+// ParseINF 取出 Windows 顯示器 INF 中的 EDID_OVERRIDE 項目，例如
+//
+//	HKR,EDID_OVERRIDE,"0",0x01,0x00,0xFF,0xFF,...
+//
+// 並依區塊編號串接；以 "\" 結尾的續行會先合併。
+func ParseINF(text string) ([]byte, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\\\n", "")
+
+	blocks := map[int][]byte{}
+	for _, line := range strings.Split(text, "\n") {
+		if index := strings.Index(line, ";"); index >= 0 {
+			line = line[:index]
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) < 5 || !strings.EqualFold(strings.TrimSpace(fields[1]), infOverrideKey) {
+			continue
+		}
+		block, err := strconv.Atoi(strings.Trim(strings.TrimSpace(fields[2]), `"`))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s block %s", infOverrideKey, strings.TrimSpace(fields[2]))
+		}
+		data := make([]byte, 0, len(fields)-4)
+		for _, field := range fields[4:] {
+			value, err := strconv.ParseUint(strings.TrimSpace(field), 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("%s block %d: invalid byte %q", infOverrideKey, block, strings.TrimSpace(field))
+			}
+			data = append(data, byte(value))
+		}
+		blocks[block] = append(blocks[block], data...)
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no %s entries: %w", infOverrideKey, ErrNoEDIDData)
+	}
+
+	indexes := make([]int, 0, len(blocks))
+	for index := range blocks {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	var edid []byte
+	for _, index := range indexes {
+		edid = append(edid, blocks[index]...)
+	}
+	return edid, nil
+}
+
+// FormatEDIDHex 將 EDID 格式化為每行 16 位元組的大寫十六進位文字。
+func FormatEDIDHex(edid []byte) string {
+	var b strings.Builder
+	for offset := 0; offset < len(edid); offset += 16 {
+		end := min(offset+16, len(edid))
+		fmt.Fprintf(&b, "% X\n", edid[offset:end])
+	}
+	return b.String()
+}
+
+// FormatINF 將 EDID 格式化為 INF 的 AddReg 區段，每個 128 位元組區塊一行。
+func FormatINF(edid []byte) string {
+	var b strings.Builder
+	b.WriteString("[EDID_OVERRIDE.AddReg]\n")
+	for block := 0; block*edidBlockSize < len(edid); block++ {
+		end := min((block+1)*edidBlockSize, len(edid))
+		fmt.Fprintf(&b, "HKR,%s,\"%d\",0x01", infOverrideKey, block)
+		for _, value := range edid[block*edidBlockSize : end] {
+			fmt.Fprintf(&b, ",0x%02X", value)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
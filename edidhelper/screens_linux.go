@@ -0,0 +1,102 @@
+//go:build linux
+
+package edidhelper
+
+import (
+	display "GMTAUXOneKeyBuild/struct"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SysfsRoot 為 DRM 連接埠所在的 sysfs 目錄，可改指向測試用的目錄樹。
+var SysfsRoot = "/sys/class/drm"
+
+// pciVendors 將 PCI 供應商 ID 對應為顯示卡廠牌，供驅動偵測判斷廠牌使用。
+var pciVendors = map[string]string{
+	"0x8086": "Intel",
+	"0x10de": "NVIDIA",
+	"0x1002": "AMD",
+}
+
+// GetScreens 走訪 SysfsRoot 下的 card*-* 連接埠，解析已連接顯示器的 EDID。
+// AdapterName 為連接埠名稱（例如 eDP-1），DeviceID 為 sysfs 項目名稱（例如 card0-eDP-1）。
+func GetScreens() ([]*display.Display, error) {
+	entries, err := filepath.Glob(filepath.Join(SysfsRoot, "card*-*"))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no DRM connectors found under %s", SysfsRoot)
+	}
+	sort.Strings(entries)
+
+	var (
+		displays []*display.Display
+		lastErr  error
+	)
+	for _, entry := range entries {
+		deviceID := filepath.Base(entry)
+		card, connector, ok := strings.Cut(deviceID, "-")
+		if !ok {
+			continue
+		}
+		if readSysfsString(filepath.Join(entry, "status")) != "connected" {
+			// 未連接的連接埠沒有 EDID，不需處理。
+			continue
+		}
+
+		edid, err := os.ReadFile(filepath.Join(entry, "edid"))
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", deviceID, err)
+			continue
+		}
+		if len(edid) == 0 {
+			lastErr = fmt.Errorf("%s: empty EDID", deviceID)
+			continue
+		}
+
+		info, err := display.ParseEDID(edid, connector, adapterDescription(card, entry), deviceID)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", deviceID, err)
+			continue
+		}
+		info.Source = display.SourceSysfs
+		displays = append(displays, info)
+	}
+
+	if len(displays) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return displays, lastErr
+}
+
+// adapterDescription 組合顯示卡廠牌、PCI ID 與連接埠啟用狀態的描述。
+func adapterDescription(card, entry string) string {
+	device := filepath.Join(SysfsRoot, card, "device")
+	vendorID := readSysfsString(filepath.Join(device, "vendor"))
+	deviceID := readSysfsString(filepath.Join(device, "device"))
+
+	parts := []string{card}
+	if name, ok := pciVendors[strings.ToLower(vendorID)]; ok {
+		parts = append(parts, name)
+	}
+	if vendorID != "" && deviceID != "" {
+		parts = append(parts, fmt.Sprintf("[%s:%s]", strings.TrimPrefix(vendorID, "0x"), strings.TrimPrefix(deviceID, "0x")))
+	}
+	if enabled := readSysfsString(filepath.Join(entry, "enabled")); enabled != "" && enabled != "enabled" {
+		parts = append(parts, "("+enabled+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// readSysfsString 讀取 sysfs 屬性並去除換行，失敗時回傳空字串。
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
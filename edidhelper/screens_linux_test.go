@@ -0,0 +1,135 @@
+//go:build linux
+
+package edidhelper
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+// testEDID 產生只含標頭、製造商與產品代碼的最小 EDID 區塊。
+func testEDID(t *testing.T, manufacturer string, product uint16) []byte {
+	t.Helper()
+	edid := make([]byte, 128)
+	copy(edid, edidHeader)
+	edid[0x12], edid[0x13] = 1, 4
+	if err := display.SetManufacturerID(edid, manufacturer); err != nil {
+		t.Fatal(err)
+	}
+	if err := display.SetProductCode(edid, product); err != nil {
+		t.Fatal(err)
+	}
+	display.FixChecksums(edid)
+	return edid
+}
+
+// writeSysfsTree 依 files（相對路徑→內容）建立假的 /sys/class/drm。
+func writeSysfsTree(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestGetScreensSysfs(t *testing.T) {
+	edp := testEDID(t, "AUO", 0x123D)
+	hdmi := testEDID(t, "MNE", 0x0001)
+
+	tests := []struct {
+		name    string
+		files   map[string][]byte
+		want    []string // 依序預期的 DeviceID
+		adapter map[string]string
+		wantErr bool
+	}{
+		{
+			name: "connected connectors only",
+			files: map[string][]byte{
+				"card0/device/vendor":    []byte("0x8086\n"),
+				"card0/device/device":    []byte("0x9a49\n"),
+				"card0-eDP-1/status":     []byte("connected\n"),
+				"card0-eDP-1/enabled":    []byte("enabled\n"),
+				"card0-eDP-1/edid":       edp,
+				"card0-HDMI-A-1/status":  []byte("connected\n"),
+				"card0-HDMI-A-1/enabled": []byte("disabled\n"),
+				"card0-HDMI-A-1/edid":    hdmi,
+				"card0-DP-1/status":      []byte("disconnected\n"),
+				"card0-DP-1/edid":        nil,
+				"card1-Virtual-1/status": []byte("disconnected\n"),
+			},
+			want: []string{"card0-HDMI-A-1", "card0-eDP-1"},
+			adapter: map[string]string{
+				"card0-eDP-1":    "card0 Intel [8086:9a49]",
+				"card0-HDMI-A-1": "card0 Intel [8086:9a49] (disabled)",
+			},
+		},
+		{
+			name: "empty edid is skipped",
+			files: map[string][]byte{
+				"card0-eDP-1/status":  []byte("connected\n"),
+				"card0-eDP-1/edid":    nil,
+				"card0-DP-2/status":   []byte("connected\n"),
+				"card0-DP-2/edid":     edp,
+				"card0-DP-2/enabled":  []byte("enabled\n"),
+				"card0/device/vendor": []byte("0x1002\n"),
+			},
+			want:    []string{"card0-DP-2"},
+			adapter: map[string]string{"card0-DP-2": "card0 AMD"},
+			wantErr: true,
+		},
+		{
+			name: "nothing connected",
+			files: map[string][]byte{
+				"card0-DP-1/status": []byte("disconnected\n"),
+			},
+		},
+		{
+			name:    "no connectors",
+			files:   map[string][]byte{"version": []byte("drm 1.1.0\n")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := SysfsRoot
+			SysfsRoot = writeSysfsTree(t, tt.files)
+			defer func() { SysfsRoot = old }()
+
+			displays, err := GetScreens()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetScreens error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(displays) != len(tt.want) {
+				t.Fatalf("got %d displays, want %d", len(displays), len(tt.want))
+			}
+			for i, d := range displays {
+				if d.DeviceID != tt.want[i] {
+					t.Errorf("display %d DeviceID = %q, want %q", i, d.DeviceID, tt.want[i])
+				}
+				_, connector, _ := strings.Cut(d.DeviceID, "-")
+				if d.AdapterName != connector {
+					t.Errorf("%s AdapterName = %q, want %q", d.DeviceID, d.AdapterName, connector)
+				}
+				if want := tt.adapter[d.DeviceID]; d.AdapterString != want {
+					t.Errorf("%s AdapterString = %q, want %q", d.DeviceID, d.AdapterString, want)
+				}
+				if d.Source != display.SourceSysfs {
+					t.Errorf("%s Source = %q, want %q", d.DeviceID, d.Source, display.SourceSysfs)
+				}
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-//go:build !windows
+//go:build !windows && !linux
 
 package edidhelper
 
@@ -7,10 +7,10 @@ import (
 	"errors"
 )
 
-// errUnsupported 說明此功能僅支援在 Windows 平台上列舉顯示器。
-var errUnsupported = errors.New("display enumeration is only supported on Windows")
+// errUnsupported 說明此功能僅支援在 Windows 與 Linux 平台上列舉顯示器。
+var errUnsupported = errors.New("display enumeration is only supported on Windows and Linux")
 
-// GetScreens 在非 Windows 平台上僅回傳錯誤，提示使用者不受支援。
+// GetScreens 在不支援的平台上僅回傳錯誤，提示使用者不受支援。
 func GetScreens() ([]*display.Display, error) {
 	return nil, errUnsupported
 }
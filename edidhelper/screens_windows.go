@@ -74,6 +74,7 @@ func GetScreens() ([]*display.Display, error) {
 				lastErr = err
 				continue
 			}
+			info.Source = display.SourceRegistry
 			displays = append(displays, info)
 		}
 	}
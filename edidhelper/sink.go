@@ -0,0 +1,155 @@
+package edidhelper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+const (
+	// edidSlave 為 EDID EEPROM 的 7-bit 從屬位址。
+	edidSlave = 0x50
+	// segmentSlave 為 E-DDC 區段指標的 7-bit 從屬位址。
+	segmentSlave = 0x30
+	// edidBlockSize 為 EDID 區塊大小，每個區段含兩個區塊。
+	edidBlockSize = 128
+	// edidChunkSize 為單次 I2C-over-AUX 讀取的位元組數。
+	edidChunkSize = 16
+)
+
+var (
+	// ErrSegmentNotRetained 表示寫入區段指標後讀到的仍是區段 0 的內容，
+	// 通常是驅動在兩次交易之間送出 STOP 使指標被重設。
+	ErrSegmentNotRetained = errors.New("edid: sink did not retain E-DDC segment pointer")
+	// ErrChecksum 表示 EDID 區塊的檢查碼錯誤。
+	ErrChecksum = errors.New("edid: block checksum mismatch")
+)
+
+var edidHeader = []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00}
+
+// ReadEDIDFromSink 直接透過 I2C 自面板 EEPROM 讀取完整 EDID，作為登錄檔副本以外的來源。
+// 第 2 個區塊以後以 E-DDC 區段指標定址；驅動實作 gpu.EDIDSegmentReader 時
+// 會以單一交易讀取，否則先寫入 0x30 再讀取 0x50。
+// 區塊檢查碼錯誤時仍回傳已讀取的資料，並回傳包裝 ErrChecksum 的錯誤。
+func ReadEDIDFromSink(driver gpu.Driver) ([]byte, error) {
+	if driver == nil {
+		return nil, gpu.ErrNoDriver
+	}
+
+	base, err := readEDIDBlock(driver, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(base[:8], edidHeader) {
+		return nil, fmt.Errorf("edid: invalid header % X from sink", base[:8])
+	}
+
+	blocks := 1 + int(base[0x7E])
+	edid := append([]byte(nil), base...)
+	for block := 1; block < blocks; block++ {
+		data, err := readEDIDBlock(driver, block)
+		if err != nil {
+			return edid, fmt.Errorf("edid: block %d: %w", block, err)
+		}
+		if block >= 2 && (bytes.Equal(data, edid[:edidBlockSize]) || bytes.Equal(data, edid[edidBlockSize:2*edidBlockSize])) {
+			return edid, fmt.Errorf("edid: block %d: %w", block, ErrSegmentNotRetained)
+		}
+		edid = append(edid, data...)
+	}
+
+	var bad []int
+	for block := 0; block < blocks; block++ {
+		if !validBlockChecksum(edid[block*edidBlockSize : (block+1)*edidBlockSize]) {
+			bad = append(bad, block)
+		}
+	}
+	if len(bad) > 0 {
+		return edid, fmt.Errorf("%w in block(s) %v", ErrChecksum, bad)
+	}
+	return edid, nil
+}
+
+// readEDIDBlock 讀取指定區塊；區塊 n 位於區段 n/2、位移 (n%2)*128。
+func readEDIDBlock(driver gpu.Driver, block int) ([]byte, error) {
+	segment := byte(block / 2)
+	offset := byte(block%2) * edidBlockSize
+
+	if reader, ok := driver.(gpu.EDIDSegmentReader); ok {
+		// 包裝驅動（例如 gpu.RetryDriver）在底層不支援時回傳 ErrNotImplemented，改用分開的交易。
+		data, err := reader.ReadEDIDSegment(segment, offset, edidBlockSize)
+		if !errors.Is(err, gpu.ErrNotImplemented) {
+			return data, err
+		}
+	}
+
+	if segment > 0 {
+		if err := driver.WriteI2C(segmentSlave, []byte{segment}); err != nil {
+			return nil, fmt.Errorf("write segment pointer %d: %w", segment, err)
+		}
+	}
+	data := make([]byte, 0, edidBlockSize)
+	for i := 0; i < edidBlockSize; i += edidChunkSize {
+		chunk, err := driver.ReadI2C(edidSlave|uint32(int(offset)+i)<<8, edidChunkSize)
+		if err != nil {
+			return nil, fmt.Errorf("read 0x%02X offset 0x%02X: %w", edidSlave, int(offset)+i, err)
+		}
+		data = append(data, chunk...)
+	}
+	if segment > 0 {
+		// 讀取完畢後將區段指標歸零，避免影響其他存取 0x50 的程式。
+		_ = driver.WriteI2C(segmentSlave, []byte{0})
+	}
+	return data, nil
+}
+
+// validBlockChecksum 檢查 128 位元組總和是否為 0。
+func validBlockChecksum(block []byte) bool {
+	var sum byte
+	for _, b := range block {
+		sum += b
+	}
+	return sum == 0
+}
+
+// edidWritePage 為單次寫入 EEPROM 的位元組數，對齊常見 24C02 的 8 位元組頁面。
+const edidWritePage = 8
+
+// EDIDWriteDelay 為每頁寫入後等待 EEPROM 完成寫入週期的時間，可由設定檔調整。
+var EDIDWriteDelay = 10 * time.Millisecond
+
+// WriteEDIDToSink 將 EDID 以 8 位元組為一頁寫入面板 EEPROM（從屬位址 0x50），
+// 完成後讀回比對。0x50 僅能以 8 位元位移定址，因此最多寫入 256 位元組。
+func WriteEDIDToSink(driver gpu.Driver, edid []byte) error {
+	if driver == nil {
+		return gpu.ErrNoDriver
+	}
+	if len(edid) == 0 || len(edid)%edidBlockSize != 0 {
+		return fmt.Errorf("edid: invalid length %d", len(edid))
+	}
+	if len(edid) > 2*edidBlockSize {
+		return fmt.Errorf("edid: %d bytes exceed the 256 bytes addressable at 0x%02X", len(edid), edidSlave)
+	}
+
+	for offset := 0; offset < len(edid); offset += edidWritePage {
+		if err := driver.WriteI2C(edidSlave|uint32(offset)<<8, edid[offset:offset+edidWritePage]); err != nil {
+			return fmt.Errorf("edid: write offset 0x%02X: %w", offset, err)
+		}
+		time.Sleep(EDIDWriteDelay)
+	}
+
+	for block := 0; block*edidBlockSize < len(edid); block++ {
+		data, err := readEDIDBlock(driver, block)
+		if err != nil {
+			return fmt.Errorf("edid: read back block %d: %w", block, err)
+		}
+		for i, b := range data {
+			if want := edid[block*edidBlockSize+i]; b != want {
+				return fmt.Errorf("edid: verify offset 0x%02X: read 0x%02X, want 0x%02X", block*edidBlockSize+i, b, want)
+			}
+		}
+	}
+	return nil
+}
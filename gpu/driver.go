@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"sync"
+	"time"
 )
 
 var (
@@ -13,6 +14,9 @@ var (
 	ErrNotImplemented = errors.New("gpu: operation not implemented")
 )
 
+// IntelCUIDelay 為 Intel igfx CUI 每次 AUX 交易後的等待時間，於建立驅動時套用，可由設定檔調整。
+var IntelCUIDelay = 20 * time.Millisecond
+
 // Driver 介面定義與 GPU 通訊所需的方法。
 type Driver interface {
 	Name() string
@@ -22,6 +26,33 @@ type Driver interface {
 	WriteI2C(addr uint32, data []byte) error
 }
 
+// EDIDSegmentReader 為選用介面，驅動可在同一個 I2C 交易中寫入 E-DDC 區段指標（0x30）
+// 並自 0x50 讀取，避免區段指標在 STOP 後被重設。
+type EDIDSegmentReader interface {
+	ReadEDIDSegment(segment, offset byte, length uint32) ([]byte, error)
+}
+
+// DirectI2C 為選用介面，回報驅動能否對沒有索引的從屬位址（例如 DDC/CI 的 0x37）
+// 直接交易：暫存器為 0 的讀取不先寫入索引，寫入時暫存器位元組與多位元組資料一起送出。
+type DirectI2C interface {
+	DirectI2C() bool
+}
+
+// SupportsDirectI2C 判斷驅動是否支援直接 I2C 交易；包裝驅動會透過 Unwrap 檢查底層驅動。
+func SupportsDirectI2C(driver Driver) bool {
+	for driver != nil {
+		if direct, ok := driver.(DirectI2C); ok {
+			return direct.DirectI2C()
+		}
+		wrapper, ok := driver.(interface{ Unwrap() Driver })
+		if !ok {
+			return false
+		}
+		driver = wrapper.Unwrap()
+	}
+	return false
+}
+
 // providerFunc 為動態註冊驅動供應者的工廠函式定義。
 type providerFunc func() (Driver, error)
 
@@ -31,9 +62,13 @@ type providerEntry struct {
 	fn   providerFunc
 }
 
+// deviceProviderFunc 依顯示器的裝置識別碼建立驅動，供可直接對應到連接埠的平台使用。
+type deviceProviderFunc func(deviceID string) (Driver, error)
+
 var (
-	providersMu sync.RWMutex
-	providers   []providerEntry
+	providersMu     sync.RWMutex
+	providers       []providerEntry
+	deviceProviders []deviceProviderFunc
 )
 
 // registerProvider 以匿名名稱註冊新的驅動供應者。
@@ -48,6 +83,49 @@ func registerProviderNamed(name string, fn providerFunc) {
 	providers = append(providers, providerEntry{name: strings.ToLower(name), fn: fn})
 }
 
+// registerDeviceProvider 註冊依裝置識別碼建立驅動的供應者。
+func registerDeviceProvider(fn deviceProviderFunc) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	deviceProviders = append(deviceProviders, fn)
+}
+
+// Providers 依註冊順序回傳具名驅動供應者的名稱（不含重複與匿名供應者），供手動選擇驅動使用。
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	var names []string
+	seen := map[string]bool{}
+	for _, entry := range providers {
+		if entry.name == "" || seen[entry.name] {
+			continue
+		}
+		seen[entry.name] = true
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// DetectForDevice 建立與指定顯示器裝置識別碼對應的驅動；沒有供應者適用時回傳 ErrNoDriver。
+func DetectForDevice(deviceID string) (Driver, error) {
+	providersMu.RLock()
+	list := append([]deviceProviderFunc(nil), deviceProviders...)
+	providersMu.RUnlock()
+
+	if deviceID == "" {
+		return nil, ErrNoDriver
+	}
+	for _, fn := range list {
+		driver, err := fn(deviceID)
+		if errors.Is(err, ErrNoDriver) {
+			continue
+		}
+		return driver, err
+	}
+	return nil, ErrNoDriver
+}
+
 // Detect 依序呼叫所有註冊供應者，回傳第一個成功建立的驅動。
 func Detect() (Driver, error) {
 	providersMu.RLock()
@@ -83,7 +161,8 @@ func Detect() (Driver, error) {
 	return nil, ErrNoDriver
 }
 
-// DetectByName 僅嘗試與指定名稱相符的驅動供應者。
+// DetectByName 僅嘗試與指定名稱相符的驅動供應者；名稱也可指定家族，
+// 例如 "intel" 依序嘗試 "intel-igcl" 與 "intel-igfx"。
 func DetectByName(name string) (Driver, error) {
 	providersMu.RLock()
 	list := append([]providerEntry(nil), providers...)
@@ -96,7 +175,7 @@ func DetectByName(name string) (Driver, error) {
 	target := strings.ToLower(name)
 	var joined error
 	for _, entry := range list {
-		if entry.name == "" || entry.name != target {
+		if entry.name == "" || entry.name != target && !strings.HasPrefix(entry.name, target+"-") {
 			continue
 		}
 		driver, err := entry.fn()
@@ -0,0 +1,234 @@
+//go:build linux
+
+package gpu
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// DRMSysfsRoot 為 DRM 連接埠所在的 sysfs 目錄，可改指向測試用的目錄樹。
+	DRMSysfsRoot = "/sys/class/drm"
+	// DRMDevRoot 為 drm_dp_aux 字元裝置所在目錄；測試時可改指向以一般檔案代替裝置的目錄。
+	DRMDevRoot = "/dev"
+)
+
+// drmAuxDriver 透過核心的 /dev/drm_dp_auxN 以 pread/pwrite 存取 DPCD，
+// 檔案位移即為 DPCD 位址，適用 Intel、AMD 與 NVIDIA 的 Linux 驅動；
+// I2C 則經由連接埠 DDC 匯流排對應的 /dev/i2c-N 以 I2C_RDWR 存取。
+type drmAuxDriver struct {
+	connector string
+	device    string
+	i2cDevice string
+	mu        sync.Mutex
+}
+
+func init() {
+	registerProviderNamed("drm-aux", newDRMAuxDriver)
+	registerDeviceProvider(newDRMAuxDriverForDevice)
+}
+
+// newDRMAuxDriverForDevice 以 sysfs 連接埠名稱（edidhelper 的 DeviceID）建立驅動。
+func newDRMAuxDriverForDevice(deviceID string) (Driver, error) {
+	if _, err := os.Stat(filepath.Join(DRMSysfsRoot, deviceID, "status")); err != nil {
+		return nil, ErrNoDriver
+	}
+	return NewDRMAuxDriver(deviceID)
+}
+
+// newDRMAuxDriver 選擇第一個已連接且具備 AUX 裝置的連接埠，優先使用 eDP。
+func newDRMAuxDriver() (Driver, error) {
+	connectors, err := drmAuxConnectors()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(connectors, func(i, j int) bool {
+		return isEDPConnector(connectors[i]) && !isEDPConnector(connectors[j])
+	})
+	return NewDRMAuxDriver(connectors[0])
+}
+
+// NewDRMAuxDriver 建立指定 DRM 連接埠（例如 card0-eDP-1）的驅動；
+// 連接埠需至少具備 drm_dp_aux 或 DDC 匯流排其中之一，缺少的一方操作回傳 ErrNotImplemented。
+func NewDRMAuxDriver(connector string) (Driver, error) {
+	d := &drmAuxDriver{connector: connector}
+	aux, auxErr := drmAuxDeviceName(connector)
+	if auxErr == nil {
+		d.device = filepath.Join(DRMDevRoot, aux)
+		if _, err := os.Stat(d.device); err != nil {
+			return nil, fmt.Errorf("drm aux: %s: %w", connector, err)
+		}
+	}
+	if adapter, err := drmI2CAdapter(connector); err == nil {
+		d.i2cDevice = filepath.Join(DRMDevRoot, adapter)
+	}
+	if d.device == "" && d.i2cDevice == "" {
+		return nil, auxErr
+	}
+	return d, nil
+}
+
+// drmAuxConnectors 列出已連接且具備 drm_dp_aux 子裝置的連接埠。
+func drmAuxConnectors() ([]string, error) {
+	entries, err := filepath.Glob(filepath.Join(DRMSysfsRoot, "card*-*"))
+	if err != nil {
+		return nil, err
+	}
+	var connectors []string
+	for _, entry := range entries {
+		status, err := os.ReadFile(filepath.Join(entry, "status"))
+		if err != nil || strings.TrimSpace(string(status)) != "connected" {
+			continue
+		}
+		connector := filepath.Base(entry)
+		if _, err := drmAuxDeviceName(connector); err == nil {
+			connectors = append(connectors, connector)
+		}
+	}
+	if len(connectors) == 0 {
+		return nil, ErrNoDriver
+	}
+	sort.Strings(connectors)
+	return connectors, nil
+}
+
+// drmAuxDeviceName 由 sysfs 連接埠目錄下的 drm_dp_auxN 子目錄找出對應的裝置名稱。
+func drmAuxDeviceName(connector string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(DRMSysfsRoot, connector, "drm_dp_aux*"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("drm aux: connector %s has no drm_dp_aux device", connector)
+	}
+	sort.Strings(matches)
+	return filepath.Base(matches[0]), nil
+}
+
+// isEDPConnector 判斷連接埠是否為內建面板。
+func isEDPConnector(connector string) bool {
+	_, name, _ := strings.Cut(connector, "-")
+	return strings.HasPrefix(name, "eDP")
+}
+
+func (d *drmAuxDriver) Name() string {
+	return fmt.Sprintf("Linux drm_dp_aux (%s)", d.connector)
+}
+
+func (d *drmAuxDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
+	if d.device == "" {
+		return nil, ErrNotImplemented
+	}
+	if length == 0 {
+		return nil, classifyf(ErrInvalidParameter, "drm aux: invalid length")
+	}
+	if uint64(addr)+uint64(length) > dpcdAddressLimit {
+		return nil, classifyf(ErrInvalidParameter, "drm aux: dpcd range 0x%05X+%d exceeds address space", addr, length)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f, err := os.Open(d.device)
+	if err != nil {
+		return nil, fmt.Errorf("drm aux: open %s: %w", d.device, err)
+	}
+	defer f.Close()
+
+	buf := make([]byte, length)
+	n, err := f.ReadAt(buf, int64(addr))
+	if err != nil && !(errors.Is(err, io.EOF) && n == len(buf)) {
+		return nil, fmt.Errorf("drm aux: read dpcd 0x%05X: %w", addr, err)
+	}
+	return buf, nil
+}
+
+func (d *drmAuxDriver) WriteDPCD(addr uint32, data []byte) error {
+	if d.device == "" {
+		return ErrNotImplemented
+	}
+	if len(data) == 0 {
+		return classifyf(ErrInvalidParameter, "drm aux: empty payload")
+	}
+	if uint64(addr)+uint64(len(data)) > dpcdAddressLimit {
+		return classifyf(ErrInvalidParameter, "drm aux: dpcd range 0x%05X+%d exceeds address space", addr, len(data))
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f, err := os.OpenFile(d.device, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("drm aux: open %s: %w", d.device, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteAt(data, int64(addr)); err != nil {
+		return fmt.Errorf("drm aux: write dpcd 0x%05X: %w", addr, err)
+	}
+	return nil
+}
+
+func (d *drmAuxDriver) ReadI2C(addr uint32, length uint32) ([]byte, error) {
+	if length == 0 {
+		return []byte{}, nil
+	}
+	buf := make([]byte, length)
+	if err := d.transferI2C(i2cReadMessages(addr, buf)); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (d *drmAuxDriver) WriteI2C(addr uint32, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return d.transferI2C([]I2CMessage{i2cWriteMessage(addr, data)})
+}
+
+// DirectI2C 在連接埠有 DDC 匯流排時回傳 true；i2c-dev 依 i2cDirectSlaves 直接讀寫 0x30/0x37。
+func (d *drmAuxDriver) DirectI2C() bool {
+	return d.i2cDevice != ""
+}
+
+// ReadEDIDSegment 以單一 I2C_RDWR 交易寫入區段指標、索引並讀取 EDID，
+// 區段指標不會因中途的 STOP 被重設。
+func (d *drmAuxDriver) ReadEDIDSegment(segment, offset byte, length uint32) ([]byte, error) {
+	buf := make([]byte, length)
+	msgs := []I2CMessage{
+		{Addr: 0x50, Data: []byte{offset}},
+		{Addr: 0x50, Read: true, Data: buf},
+	}
+	if segment > 0 {
+		msgs = append([]I2CMessage{{Addr: 0x30, Data: []byte{segment}}}, msgs...)
+	}
+	if err := d.transferI2C(msgs); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// transferI2C 開啟連接埠的 DDC 匯流排並送出組合交易。
+func (d *drmAuxDriver) transferI2C(msgs []I2CMessage) error {
+	if d.i2cDevice == "" {
+		return ErrNotImplemented
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bus, err := OpenI2CBus(d.i2cDevice)
+	if err != nil {
+		return err
+	}
+	defer bus.Close()
+	return bus.Transfer(msgs)
+}
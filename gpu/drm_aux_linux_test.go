@@ -0,0 +1,167 @@
+//go:build linux
+
+package gpu
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeDRM 建立假的 sysfs 與 /dev 目錄樹並在測試結束時還原 DRMSysfsRoot、DRMDevRoot。
+// sysfs 中值為 nil 的項目建立為目錄，dev 中的項目以一般檔案代替字元裝置。
+func fakeDRM(t *testing.T, sysfs map[string][]byte, dev map[string][]byte) (string, string) {
+	t.Helper()
+	sysRoot, devRoot := t.TempDir(), t.TempDir()
+	for name, data := range sysfs {
+		path := filepath.Join(sysRoot, name)
+		if data == nil {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, data := range dev {
+		if err := os.WriteFile(filepath.Join(devRoot, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldSys, oldDev := DRMSysfsRoot, DRMDevRoot
+	DRMSysfsRoot, DRMDevRoot = sysRoot, devRoot
+	t.Cleanup(func() { DRMSysfsRoot, DRMDevRoot = oldSys, oldDev })
+	return sysRoot, devRoot
+}
+
+func TestDRMAuxConnectorSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		sysfs   map[string][]byte
+		want    string
+		wantErr error
+	}{
+		{
+			name: "prefers edp",
+			sysfs: map[string][]byte{
+				"card0-DP-1/status":       []byte("connected\n"),
+				"card0-DP-1/drm_dp_aux1":  nil,
+				"card0-eDP-1/status":      []byte("connected\n"),
+				"card0-eDP-1/drm_dp_aux0": nil,
+			},
+			want: "drm_dp_aux0",
+		},
+		{
+			name: "skips disconnected",
+			sysfs: map[string][]byte{
+				"card0-eDP-1/status":      []byte("disconnected\n"),
+				"card0-eDP-1/drm_dp_aux0": nil,
+				"card0-DP-2/status":       []byte("connected\n"),
+				"card0-DP-2/drm_dp_aux2":  nil,
+			},
+			want: "drm_dp_aux2",
+		},
+		{
+			name: "no aux device",
+			sysfs: map[string][]byte{
+				"card0-HDMI-A-1/status": []byte("connected\n"),
+			},
+			wantErr: ErrNoDriver,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, devRoot := fakeDRM(t, tt.sysfs, map[string][]byte{
+				"drm_dp_aux0": nil, "drm_dp_aux1": nil, "drm_dp_aux2": nil,
+			})
+			driver, err := newDRMAuxDriver()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("newDRMAuxDriver error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := driver.(*drmAuxDriver).device; got != filepath.Join(devRoot, tt.want) {
+				t.Errorf("device = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDRMAuxForDevice(t *testing.T) {
+	fakeDRM(t, map[string][]byte{
+		"card0-eDP-1/status":      []byte("connected\n"),
+		"card0-eDP-1/drm_dp_aux0": nil,
+	}, map[string][]byte{"drm_dp_aux0": nil})
+
+	if _, err := DetectForDevice("card0-eDP-1"); err != nil {
+		t.Fatalf("DetectForDevice(card0-eDP-1): %v", err)
+	}
+	if _, err := DetectForDevice("card1-DP-3"); !errors.Is(err, ErrNoDriver) {
+		t.Fatalf("DetectForDevice(card1-DP-3) error = %v, want ErrNoDriver", err)
+	}
+}
+
+func TestDRMAuxReadWriteDPCD(t *testing.T) {
+	image := make([]byte, 0x300)
+	copy(image, []byte{0x14, 0x1E, 0x84})
+	_, devRoot := fakeDRM(t, map[string][]byte{
+		"card0-eDP-1/status":      []byte("connected\n"),
+		"card0-eDP-1/drm_dp_aux0": nil,
+	}, map[string][]byte{"drm_dp_aux0": image})
+
+	driver, err := NewDRMAuxDriver("card0-eDP-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		addr    uint32
+		length  uint32
+		want    []byte
+		wantErr error
+	}{
+		{name: "revision", addr: 0x000, length: 3, want: []byte{0x14, 0x1E, 0x84}},
+		{name: "zero length", addr: 0x000, length: 0, wantErr: ErrInvalidParameter},
+		{name: "beyond address space", addr: 0xFFFFF, length: 2, wantErr: ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := driver.ReadDPCD(tt.addr, tt.length)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadDPCD error = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(data, tt.want) {
+				t.Errorf("ReadDPCD = % X, want % X", data, tt.want)
+			}
+		})
+	}
+
+	// 寫入以 pwrite 落在檔案位移即 DPCD 位址的位置。
+	if err := driver.WriteDPCD(0x200, []byte{0x01, 0x02}); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(devRoot, "drm_dp_aux0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw[0x200:0x202], []byte{0x01, 0x02}) {
+		t.Errorf("device bytes at 0x200 = % X, want 01 02", raw[0x200:0x202])
+	}
+	if err := driver.WriteDPCD(0x200, nil); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("WriteDPCD(empty) error = %v, want ErrInvalidParameter", err)
+	}
+
+	// 沒有 DDC 匯流排時 I2C 操作回傳 ErrNotImplemented。
+	if _, err := driver.ReadI2C(0x50, 1); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("ReadI2C error = %v, want ErrNotImplemented", err)
+	}
+}
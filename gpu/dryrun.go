@@ -0,0 +1,98 @@
+package gpu
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordedWrite 描述 dry-run 期間被攔截的一次寫入。
+type RecordedWrite struct {
+	Time    time.Time
+	Bus     string // "dpcd" 或 "i2c"
+	Address uint32 // 驅動收到的原始位址
+	Data    []byte
+}
+
+// String 以精簡格式描述寫入內容。
+func (w RecordedWrite) String() string {
+	if w.Bus == "i2c" {
+		slave, reg := splitI2CAddress(w.Address)
+		return fmt.Sprintf("I2C  0x%02X reg 0x%02X <= % X", slave, reg, w.Data)
+	}
+	return fmt.Sprintf("DPCD 0x%05X <= % X", w.Address, w.Data)
+}
+
+// DryRunDriver 攔截所有寫入並記錄，讀取則由模擬空間（可預先載入快照）提供。
+// 寫入會同步套用到模擬空間，讓後續讀回驗證看到預期結果，但不會觸及硬體。
+type DryRunDriver struct {
+	sim    *SimDriver
+	mu     sync.Mutex
+	writes []RecordedWrite
+}
+
+// NewDryRunDriver 以指定的模擬空間建立 dry-run 驅動；sim 為 nil 時使用空白空間。
+func NewDryRunDriver(sim *SimDriver) *DryRunDriver {
+	if sim == nil {
+		sim = NewSimDriver()
+	}
+	return &DryRunDriver{sim: sim}
+}
+
+func (d *DryRunDriver) Name() string {
+	return "Dry-run (" + d.sim.Name() + ")"
+}
+
+func (d *DryRunDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
+	return d.sim.ReadDPCD(addr, length)
+}
+
+func (d *DryRunDriver) WriteDPCD(addr uint32, data []byte) error {
+	d.record("dpcd", addr, data)
+	return d.sim.WriteDPCD(addr, data)
+}
+
+func (d *DryRunDriver) ReadI2C(addr uint32, length uint32) ([]byte, error) {
+	return d.sim.ReadI2C(addr, length)
+}
+
+func (d *DryRunDriver) WriteI2C(addr uint32, data []byte) error {
+	d.record("i2c", addr, data)
+	return d.sim.WriteI2C(addr, data)
+}
+
+func (d *DryRunDriver) DirectI2C() bool {
+	return d.sim.DirectI2C()
+}
+
+func (d *DryRunDriver) record(bus string, addr uint32, data []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.writes = append(d.writes, RecordedWrite{
+		Time:    time.Now(),
+		Bus:     bus,
+		Address: addr,
+		Data:    append([]byte(nil), data...),
+	})
+}
+
+// Writes 回傳目前為止記錄的所有寫入。
+func (d *DryRunDriver) Writes() []RecordedWrite {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]RecordedWrite(nil), d.writes...)
+}
+
+// FormatWrites 將記錄的寫入逐行編號輸出，供使用者審閱。
+func (d *DryRunDriver) FormatWrites() string {
+	writes := d.Writes()
+	if len(writes) == 0 {
+		return "(no writes)"
+	}
+	lines := make([]string, len(writes))
+	for i, w := range writes {
+		lines[i] = fmt.Sprintf("%3d. %s", i+1, w)
+	}
+	return strings.Join(lines, "\n")
+}
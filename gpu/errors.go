@@ -0,0 +1,129 @@
+package gpu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AUX/I2C 交易錯誤的分類。各驅動回傳的錯誤可用 errors.Is 比對，
+// 或以 ErrorKind 取得分類名稱；原始訊息保留不變。
+var (
+	// ErrTimeout 表示 AUX/I2C 交易逾時，sink 未回應。
+	ErrTimeout = errors.New("gpu: aux transaction timed out")
+	// ErrNACK 表示 sink 或 I2C 從屬裝置拒絕交易（AUX_NACK / I2C_NACK）。
+	ErrNACK = errors.New("gpu: aux transaction not acknowledged")
+	// ErrDefer 表示 sink 要求稍後重送（AUX_DEFER / I2C_DEFER）。
+	ErrDefer = errors.New("gpu: aux transaction deferred")
+	// ErrBusy 表示裝置或驅動忙碌中。
+	ErrBusy = errors.New("gpu: device busy")
+	// ErrInvalidParameter 表示位址、長度或資料不符合驅動限制。
+	ErrInvalidParameter = errors.New("gpu: invalid parameter")
+)
+
+// 錯誤分類名稱，供統計、Lua 腳本與遠端協定使用。
+const (
+	KindTimeout          = "timeout"
+	KindNACK             = "nack"
+	KindDefer            = "defer"
+	KindBusy             = "busy"
+	KindNotImplemented   = "not_implemented"
+	KindInvalidParameter = "invalid_parameter"
+)
+
+// errorKinds 依比對順序列出分類與對應的 sentinel 錯誤。
+var errorKinds = []struct {
+	name     string
+	sentinel error
+}{
+	{KindTimeout, ErrTimeout},
+	{KindNACK, ErrNACK},
+	{KindDefer, ErrDefer},
+	{KindBusy, ErrBusy},
+	{KindNotImplemented, ErrNotImplemented},
+	{KindInvalidParameter, ErrInvalidParameter},
+}
+
+// classifiedError 保留驅動原始的錯誤並可以 errors.Is 比對分類 sentinel 錯誤。
+type classifiedError struct {
+	err  error
+	kind error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// classifyf 以指定分類建立錯誤，訊息格式與 fmt.Errorf 相同。
+func classifyf(kind error, format string, args ...interface{}) error {
+	return &classifiedError{err: fmt.Errorf(format, args...), kind: kind}
+}
+
+// KindError 回傳分類名稱對應的 sentinel 錯誤，未知名稱回傳 nil。
+func KindError(name string) error {
+	for _, k := range errorKinds {
+		if k.name == name {
+			return k.sentinel
+		}
+	}
+	return nil
+}
+
+// WithKind 將 err 標記為指定分類的 sentinel 錯誤，訊息保持不變；err 為 nil 時回傳 nil。
+func WithKind(err, kind error) error {
+	if err == nil || kind == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &classifiedError{err: err, kind: kind}
+}
+
+// Classify 判斷錯誤的分類並回傳對應的 sentinel 錯誤；無法判斷時回傳 nil。
+// 除了已包裝的 sentinel 外，也會辨識作業系統錯誤碼、網路逾時與常見的驅動訊息。
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.sentinel) {
+			return k.sentinel
+		}
+	}
+	if kind := classifyErrno(err); kind != nil {
+		return kind
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return ErrTimeout
+	}
+
+	// 最後以訊息判斷，涵蓋遠端或舊版驅動只提供文字的錯誤。
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "timed out") || strings.Contains(message, "timeout"):
+		return ErrTimeout
+	case strings.Contains(message, "defer"):
+		return ErrDefer
+	case strings.Contains(message, "nack"):
+		return ErrNACK
+	case strings.Contains(message, "busy"):
+		return ErrBusy
+	case strings.Contains(message, "not implemented"):
+		return ErrNotImplemented
+	}
+	return nil
+}
+
+// ErrorKind 回傳錯誤的分類名稱（例如 "defer"），無法分類時回傳空字串。
+func ErrorKind(err error) string {
+	kind := Classify(err)
+	for _, k := range errorKinds {
+		if k.sentinel == kind {
+			return k.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+//go:build linux
+
+package gpu
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// classifyErrno 依 drm_dp_aux 與 i2c-dev 回傳的 errno 分類錯誤。
+func classifyErrno(err error) error {
+	var errno unix.Errno
+	if !errors.As(err, &errno) {
+		return nil
+	}
+	switch errno {
+	case unix.ETIMEDOUT:
+		return ErrTimeout
+	case unix.EIO, unix.ENXIO, unix.EREMOTEIO:
+		// drm_dp_aux 以 EIO 回報 AUX_NACK，i2c-dev 以 ENXIO/EREMOTEIO 回報從屬位址無回應。
+		return ErrNACK
+	case unix.EBUSY, unix.EAGAIN:
+		// drm 核心在 DEFER 重試次數用盡後回傳 EBUSY。
+		return ErrBusy
+	case unix.EINVAL, unix.ERANGE:
+		return ErrInvalidParameter
+	case unix.ENOSYS, unix.EOPNOTSUPP, unix.ENOTTY:
+		return ErrNotImplemented
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package gpu
+
+// classifyErrno 在非 Linux 平台上不辨識作業系統錯誤碼，驅動直接回傳分類錯誤。
+func classifyErrno(err error) error {
+	return nil
+}
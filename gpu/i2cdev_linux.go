@@ -0,0 +1,139 @@
+//go:build linux
+
+package gpu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// i2cRDWR 為 linux/i2c-dev.h 的 I2C_RDWR ioctl。
+	i2cRDWR = 0x0707
+	// i2cMsgRead 為 linux/i2c.h 的 I2C_M_RD 旗標。
+	i2cMsgRead = 0x0001
+)
+
+// I2CMessage 為 I2C_RDWR 組合交易中的單一訊息；讀取時 Data 為接收緩衝區。
+type I2CMessage struct {
+	Addr byte
+	Read bool
+	Data []byte
+}
+
+// I2CBus 抽象化 i2c-dev 的 I2C_RDWR ioctl，所有訊息在同一個交易中送出，
+// 中間以 repeated START 連接。測試時可替換為假匯流排。
+type I2CBus interface {
+	Transfer(msgs []I2CMessage) error
+	Close() error
+}
+
+// OpenI2CBus 開啟 /dev/i2c-N 匯流排，測試時可替換為回傳假匯流排的函式。
+var OpenI2CBus = openI2CDev
+
+// i2cDirectSlaves 為暫存器不是 1 位元組索引的從屬位址：E-DDC 區段指標（0x30）
+// 與 DDC/CI（0x37）。這些位址的讀取不先寫入索引，寫入時暫存器為 0 則不送出。
+var i2cDirectSlaves = map[byte]bool{
+	0x30: true,
+	0x37: true,
+}
+
+// i2cMsg 對應 struct i2c_msg。
+type i2cMsg struct {
+	addr  uint16
+	flags uint16
+	len   uint16
+	buf   *byte
+}
+
+// i2cRdwrData 對應 struct i2c_rdwr_ioctl_data。
+type i2cRdwrData struct {
+	msgs  *i2cMsg
+	nmsgs uint32
+}
+
+// i2cDevBus 以 I2C_RDWR ioctl 操作 /dev/i2c-N。
+type i2cDevBus struct {
+	file *os.File
+}
+
+func openI2CDev(path string) (I2CBus, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("i2c-dev: open %s: %w", path, err)
+	}
+	return &i2cDevBus{file: f}, nil
+}
+
+func (b *i2cDevBus) Transfer(msgs []I2CMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	raw := make([]i2cMsg, len(msgs))
+	for i, m := range msgs {
+		if len(m.Data) == 0 || len(m.Data) > 0xFFFF {
+			return classifyf(ErrInvalidParameter, "i2c-dev: invalid message length %d", len(m.Data))
+		}
+		raw[i] = i2cMsg{addr: uint16(m.Addr), len: uint16(len(m.Data)), buf: &m.Data[0]}
+		if m.Read {
+			raw[i].flags = i2cMsgRead
+		}
+	}
+	data := i2cRdwrData{msgs: &raw[0], nmsgs: uint32(len(raw))}
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, b.file.Fd(), i2cRDWR, uintptr(unsafe.Pointer(&data)))
+	runtime.KeepAlive(raw)
+	runtime.KeepAlive(msgs)
+	if errno != 0 {
+		return fmt.Errorf("i2c-dev: I2C_RDWR on %s: %w", b.file.Name(), errno)
+	}
+	return nil
+}
+
+func (b *i2cDevBus) Close() error {
+	return b.file.Close()
+}
+
+// drmI2CAdapter 找出 DRM 連接埠的 DDC 匯流排：優先使用 ddc 連結，
+// 否則使用連接埠目錄下由 DP AUX 註冊的 i2c-N。
+func drmI2CAdapter(connector string) (string, error) {
+	dir := filepath.Join(DRMSysfsRoot, connector)
+	if target, err := os.Readlink(filepath.Join(dir, "ddc")); err == nil {
+		return filepath.Base(target), nil
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "i2c-*"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("i2c-dev: connector %s has no DDC adapter", connector)
+	}
+	sort.Strings(matches)
+	return filepath.Base(matches[0]), nil
+}
+
+// i2cReadMessages 依位址編碼組成讀取交易：一般裝置先寫入 1 位元組索引再讀取。
+func i2cReadMessages(addr uint32, buf []byte) []I2CMessage {
+	slave, reg := splitI2CAddress(addr)
+	if i2cDirectSlaves[slave] && reg == 0 {
+		return []I2CMessage{{Addr: slave, Read: true, Data: buf}}
+	}
+	return []I2CMessage{
+		{Addr: slave, Data: []byte{byte(reg)}},
+		{Addr: slave, Read: true, Data: buf},
+	}
+}
+
+// i2cWriteMessage 依位址編碼組成寫入訊息：暫存器作為第一個位元組送出。
+func i2cWriteMessage(addr uint32, data []byte) I2CMessage {
+	slave, reg := splitI2CAddress(addr)
+	if i2cDirectSlaves[slave] && reg == 0 {
+		return I2CMessage{Addr: slave, Data: append([]byte(nil), data...)}
+	}
+	return I2CMessage{Addr: slave, Data: append([]byte{byte(reg)}, data...)}
+}
@@ -0,0 +1,182 @@
+//go:build linux
+
+package gpu
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// fakeI2CBus 記錄每次 Transfer 的訊息，讀取訊息以 fill 填滿。
+type fakeI2CBus struct {
+	path      string
+	fill      byte
+	transfers [][]I2CMessage
+	closed    int
+}
+
+func (b *fakeI2CBus) Transfer(msgs []I2CMessage) error {
+	recorded := make([]I2CMessage, len(msgs))
+	for i, m := range msgs {
+		if m.Read {
+			for j := range m.Data {
+				m.Data[j] = b.fill + byte(j)
+			}
+			recorded[i] = I2CMessage{Addr: m.Addr, Read: true, Data: make([]byte, len(m.Data))}
+			continue
+		}
+		recorded[i] = I2CMessage{Addr: m.Addr, Data: append([]byte(nil), m.Data...)}
+	}
+	b.transfers = append(b.transfers, recorded)
+	return nil
+}
+
+func (b *fakeI2CBus) Close() error {
+	b.closed++
+	return nil
+}
+
+// newFakeI2CDriver 建立只有 DDC 匯流排的 drm-aux 驅動，I2C 交易送往假匯流排。
+func newFakeI2CDriver(t *testing.T) (*drmAuxDriver, *fakeI2CBus) {
+	t.Helper()
+	fakeDRM(t, map[string][]byte{
+		"card0-DP-1/status": []byte("connected\n"),
+		"card0-DP-1/i2c-7":  nil,
+	}, nil)
+	bus := &fakeI2CBus{fill: 0xA0}
+	old := OpenI2CBus
+	OpenI2CBus = func(path string) (I2CBus, error) {
+		bus.path = path
+		return bus, nil
+	}
+	t.Cleanup(func() { OpenI2CBus = old })
+
+	driver, err := NewDRMAuxDriver("card0-DP-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return driver.(*drmAuxDriver), bus
+}
+
+func TestI2CDevFraming(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(d *drmAuxDriver) ([]byte, error)
+		want []I2CMessage
+		data []byte // 讀取時預期回傳的資料
+	}{
+		{
+			name: "eeprom read writes index first",
+			run:  func(d *drmAuxDriver) ([]byte, error) { return d.ReadI2C(0x50|0x10<<8, 4) },
+			want: []I2CMessage{
+				{Addr: 0x50, Data: []byte{0x10}},
+				{Addr: 0x50, Read: true, Data: make([]byte, 4)},
+			},
+			data: []byte{0xA0, 0xA1, 0xA2, 0xA3},
+		},
+		{
+			name: "eeprom write prepends register",
+			run:  func(d *drmAuxDriver) ([]byte, error) { return nil, d.WriteI2C(0x50|0x08<<8, []byte{0x12, 0x34}) },
+			want: []I2CMessage{{Addr: 0x50, Data: []byte{0x08, 0x12, 0x34}}},
+		},
+		{
+			name: "ddc/ci read has no index",
+			run:  func(d *drmAuxDriver) ([]byte, error) { return d.ReadI2C(0x37, 3) },
+			want: []I2CMessage{{Addr: 0x37, Read: true, Data: make([]byte, 3)}},
+			data: []byte{0xA0, 0xA1, 0xA2},
+		},
+		{
+			name: "ddc/ci write sends source address as first byte",
+			run: func(d *drmAuxDriver) ([]byte, error) {
+				return nil, d.WriteI2C(0x37|0x51<<8, []byte{0x82, 0x01, 0x10, 0xAC})
+			},
+			want: []I2CMessage{{Addr: 0x37, Data: []byte{0x51, 0x82, 0x01, 0x10, 0xAC}}},
+		},
+		{
+			name: "segment pointer write has no register",
+			run:  func(d *drmAuxDriver) ([]byte, error) { return nil, d.WriteI2C(0x30, []byte{0x01}) },
+			want: []I2CMessage{{Addr: 0x30, Data: []byte{0x01}}},
+		},
+		{
+			name: "edid segment read in one transaction",
+			run:  func(d *drmAuxDriver) ([]byte, error) { return d.ReadEDIDSegment(1, 0x80, 2) },
+			want: []I2CMessage{
+				{Addr: 0x30, Data: []byte{0x01}},
+				{Addr: 0x50, Data: []byte{0x80}},
+				{Addr: 0x50, Read: true, Data: make([]byte, 2)},
+			},
+			data: []byte{0xA0, 0xA1},
+		},
+		{
+			name: "edid segment 0 skips pointer",
+			run:  func(d *drmAuxDriver) ([]byte, error) { return d.ReadEDIDSegment(0, 0x00, 1) },
+			want: []I2CMessage{
+				{Addr: 0x50, Data: []byte{0x00}},
+				{Addr: 0x50, Read: true, Data: make([]byte, 1)},
+			},
+			data: []byte{0xA0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, bus := newFakeI2CDriver(t)
+			data, err := tt.run(driver)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(bus.transfers) != 1 {
+				t.Fatalf("got %d transfers, want 1", len(bus.transfers))
+			}
+			if !reflect.DeepEqual(bus.transfers[0], tt.want) {
+				t.Errorf("messages = %+v, want %+v", bus.transfers[0], tt.want)
+			}
+			if !bytes.Equal(data, tt.data) {
+				t.Errorf("data = % X, want % X", data, tt.data)
+			}
+			if filepath.Base(bus.path) != "i2c-7" {
+				t.Errorf("opened %s, want i2c-7", bus.path)
+			}
+			if bus.closed != 1 {
+				t.Errorf("bus closed %d times, want 1", bus.closed)
+			}
+		})
+	}
+}
+
+func TestI2CAdapterLookup(t *testing.T) {
+	sysRoot, _ := fakeDRM(t, map[string][]byte{
+		"card0-HDMI-A-1/status": []byte("connected\n"),
+		"card0-HDMI-A-1/i2c-9":  nil,
+		"card0-DP-1/status":     []byte("connected\n"),
+		"card0-DP-1/i2c-4":      nil,
+		"card0-DP-1/i2c-3":      nil,
+		"i2c-12":                nil,
+	}, nil)
+	// ddc 連結優先於連接埠目錄下的 i2c-N。
+	if err := os.Symlink(filepath.Join(sysRoot, "i2c-12"), filepath.Join(sysRoot, "card0-HDMI-A-1", "ddc")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		connector string
+		want      string
+		wantErr   bool
+	}{
+		{connector: "card0-HDMI-A-1", want: "i2c-12"},
+		{connector: "card0-DP-1", want: "i2c-3"},
+		{connector: "card0-eDP-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := drmI2CAdapter(tt.connector)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("drmI2CAdapter(%s) error = %v, wantErr %v", tt.connector, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("drmI2CAdapter(%s) = %q, want %q", tt.connector, got, tt.want)
+		}
+	}
+}
@@ -27,7 +27,7 @@ var (
 
 func init() {
 	registerProvider(newIntelPreferredDriver)
-	registerProviderNamed("intel", newIntelIGCLDriver)
+	registerProviderNamed("intel-igcl", newIntelIGCLDriver)
 }
 
 func newIntelPreferredDriver() (Driver, error) {
@@ -83,7 +83,7 @@ func (d *intelIGCLDriver) Name() string {
 
 func (d *intelIGCLDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
 	if length == 0 {
-		return nil, fmt.Errorf("dpcd read length must be greater than zero")
+		return nil, classifyf(ErrInvalidParameter, "dpcd read length must be greater than zero")
 	}
 
 	const maxChunk = uint32(CTL_AUX_MAX_DATA_SIZE)
@@ -206,6 +206,18 @@ IGCL Control context
 // CTL API return codes.
 const (
 	ctlResultSuccess = 0
+
+	// 以下為 ctl_result_t 中可分類的錯誤碼。
+	ctlResultErrorUnsupportedFeature   = 0x4000000A
+	ctlResultErrorInvalidArgument      = 0x4000000B
+	ctlResultErrorInvalidSize          = 0x4000000F
+	ctlResultErrorUnsupportedSize      = 0x40000010
+	ctlResultErrorDataRead             = 0x40000012
+	ctlResultErrorDataWrite            = 0x40000013
+	ctlResultErrorNotImplemented       = 0x40000015
+	ctlResultErrorWaitTimeout          = 0x4000001E
+	ctlResultErrorRetryOperation       = 0x40010000
+	ctlResultErrorInvalidAuxAccessFlag = 0x48000001
 )
 
 // Operation types for ctl AUX/I2C requests.
@@ -482,9 +494,28 @@ func (c *igclContext) Close() {
 	c.api = nil
 }
 
+// ctlResultError 將 AUX/I2C 存取的 ctl_result_t 轉為分類錯誤。
+func ctlResultError(op string, r uint32) error {
+	var kind error
+	switch r {
+	case ctlResultErrorWaitTimeout:
+		kind = ErrTimeout
+	case ctlResultErrorRetryOperation:
+		kind = ErrDefer
+	case ctlResultErrorDataRead, ctlResultErrorDataWrite:
+		// 驅動在 sink 回覆 NACK 或重試用盡時回報資料讀寫失敗。
+		kind = ErrNACK
+	case ctlResultErrorInvalidArgument, ctlResultErrorInvalidSize, ctlResultErrorUnsupportedSize, ctlResultErrorInvalidAuxAccessFlag:
+		kind = ErrInvalidParameter
+	case ctlResultErrorUnsupportedFeature, ctlResultErrorNotImplemented:
+		kind = ErrNotImplemented
+	}
+	return WithKind(fmt.Errorf("%s failed: 0x%08x", op, r), kind)
+}
+
 func (c *igclContext) ReadDPCD(addr uint32, n int) ([]byte, error) {
 	if n <= 0 || n > CTL_AUX_MAX_DATA_SIZE {
-		return nil, fmt.Errorf("invalid dpcd length %d (1..%d)", n, CTL_AUX_MAX_DATA_SIZE)
+		return nil, classifyf(ErrInvalidParameter, "invalid dpcd length %d (1..%d)", n, CTL_AUX_MAX_DATA_SIZE)
 	}
 
 	var args ctlAuxAccessArgs
@@ -496,7 +527,7 @@ func (c *igclContext) ReadDPCD(addr uint32, n int) ([]byte, error) {
 	args.DataSize = uint32(n)
 
 	if r := ctlAUXAccess(c.output, &args); r != ctlResultSuccess {
-		return nil, fmt.Errorf("ctlAUXAccess(read) failed: 0x%08x", r)
+		return nil, ctlResultError("ctlAUXAccess(read)", r)
 	}
 	out := make([]byte, n)
 	copy(out, args.Data[:n])
@@ -505,7 +536,7 @@ func (c *igclContext) ReadDPCD(addr uint32, n int) ([]byte, error) {
 
 func (c *igclContext) WriteDPCD(addr uint32, data []byte) error {
 	if len(data) == 0 || len(data) > CTL_AUX_MAX_DATA_SIZE {
-		return fmt.Errorf("invalid dpcd payload %d (1..%d)", len(data), CTL_AUX_MAX_DATA_SIZE)
+		return classifyf(ErrInvalidParameter, "invalid dpcd payload %d (1..%d)", len(data), CTL_AUX_MAX_DATA_SIZE)
 	}
 
 	var args ctlAuxAccessArgs
@@ -518,14 +549,14 @@ func (c *igclContext) WriteDPCD(addr uint32, data []byte) error {
 	copy(args.Data[:], data)
 
 	if r := ctlAUXAccess(c.output, &args); r != ctlResultSuccess {
-		return fmt.Errorf("ctlAUXAccess(write) failed: 0x%08x", r)
+		return ctlResultError("ctlAUXAccess(write)", r)
 	}
 	return nil
 }
 
 func (c *igclContext) ReadI2C(slave7bit byte, offset uint32, n int) ([]byte, error) {
 	if n <= 0 || n > CTL_AUX_MAX_DATA_SIZE {
-		return nil, fmt.Errorf("invalid i2c length %d (1..%d)", n, CTL_AUX_MAX_DATA_SIZE)
+		return nil, classifyf(ErrInvalidParameter, "invalid i2c length %d (1..%d)", n, CTL_AUX_MAX_DATA_SIZE)
 	}
 
 	var args ctlI2CAccessArgs
@@ -538,7 +569,7 @@ func (c *igclContext) ReadI2C(slave7bit byte, offset uint32, n int) ([]byte, err
 	args.DataSize = uint32(n)
 
 	if r := ctlI2CAccess(c.output, &args); r != ctlResultSuccess {
-		return nil, fmt.Errorf("ctlI2CAccess(read) failed: 0x%08x", r)
+		return nil, ctlResultError("ctlI2CAccess(read)", r)
 	}
 	out := make([]byte, n)
 	copy(out, args.Data[:n])
@@ -547,7 +578,7 @@ func (c *igclContext) ReadI2C(slave7bit byte, offset uint32, n int) ([]byte, err
 
 func (c *igclContext) WriteI2C(slave7bit byte, offset uint32, data []byte) error {
 	if len(data) == 0 || len(data) > CTL_AUX_MAX_DATA_SIZE {
-		return fmt.Errorf("invalid i2c payload %d (1..%d)", len(data), CTL_AUX_MAX_DATA_SIZE)
+		return classifyf(ErrInvalidParameter, "invalid i2c payload %d (1..%d)", len(data), CTL_AUX_MAX_DATA_SIZE)
 	}
 
 	var args ctlI2CAccessArgs
@@ -561,7 +592,7 @@ func (c *igclContext) WriteI2C(slave7bit byte, offset uint32, data []byte) error
 	copy(args.Data[:], data)
 
 	if r := ctlI2CAccess(c.output, &args); r != ctlResultSuccess {
-		return fmt.Errorf("ctlI2CAccess(write) failed: 0x%08x", r)
+		return ctlResultError("ctlI2CAccess(write)", r)
 	}
 	return nil
 }
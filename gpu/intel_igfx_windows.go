@@ -71,7 +71,7 @@ type intelDriver struct {
 }
 
 func init() {
-	registerProviderNamed("intel", newIntelIGFXDriver)
+	registerProviderNamed("intel-igfx", newIntelIGFXDriver)
 }
 
 func newIntelIGFXDriver() (Driver, error) {
@@ -108,7 +108,7 @@ func (d *intelDriver) Name() string {
 
 func (d *intelDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
 	if length == 0 {
-		return nil, fmt.Errorf("dpcd read length must be greater than zero")
+		return nil, classifyf(ErrInvalidParameter, "dpcd read length must be greater than zero")
 	}
 
 	const maxChunk = uint32(16)
@@ -226,7 +226,7 @@ func NewIntelCUI() (*IntelCUI, error) {
 		}
 		return nil, fmt.Errorf("CoCreateInstance failed: 0x%08X", uint32(hr))
 	}
-	return &IntelCUI{obj: (*IUnknown)(ifPtr), delayMS: 20}, nil
+	return &IntelCUI{obj: (*IUnknown)(ifPtr), delayMS: uint16(IntelCUIDelay / time.Millisecond)}, nil
 }
 
 func (c *IntelCUI) Close() {
@@ -286,7 +286,7 @@ func (c *IntelCUI) ReadDPCD(offset uint32, length uint32) ([]byte, error) {
 		return nil, fmt.Errorf("intel igfx: display not acquired")
 	}
 	if length == 0 || length > 16 {
-		return nil, fmt.Errorf("intel igfx: invalid length %d", length)
+		return nil, classifyf(ErrInvalidParameter, "intel igfx: invalid length %d", length)
 	}
 
 	type ioRead struct {
@@ -336,7 +336,7 @@ func (c *IntelCUI) WriteDPCD(offset uint32, data []byte) error {
 		return fmt.Errorf("intel igfx: display not acquired")
 	}
 	if len(data) == 0 || len(data) > 16 {
-		return fmt.Errorf("intel igfx: invalid payload size %d", len(data))
+		return classifyf(ErrInvalidParameter, "intel igfx: invalid payload size %d", len(data))
 	}
 
 	type ioWrite struct {
@@ -468,17 +468,18 @@ func (c *IntelCUI) getSlot(slot int) (uintptr, error) {
 
 func (c *IntelCUI) auxErr(op string, hr int32, code int32) error {
 	var msg string
+	var kind error
 	switch code {
 	case 67:
-		msg = "Invalid AUX device"
+		msg, kind = "Invalid AUX device", ErrInvalidParameter
 	case 68:
-		msg = "Invalid AUX address"
+		msg, kind = "Invalid AUX address", ErrInvalidParameter
 	case 69:
-		msg = "Invalid AUX data size"
+		msg, kind = "Invalid AUX data size", ErrInvalidParameter
 	case 70:
-		msg = "AUX defer"
+		msg, kind = "AUX defer", ErrDefer
 	case 71:
-		msg = "AUX timeout"
+		msg, kind = "AUX timeout", ErrTimeout
 	case 0:
 		// leave empty
 	default:
@@ -496,7 +497,7 @@ func (c *IntelCUI) auxErr(op string, hr int32, code int32) error {
 	if msg == "" {
 		msg = "intel igfx: unexpected AUX error"
 	}
-	return fmt.Errorf("%s", msg)
+	return WithKind(errors.New(msg), kind)
 }
 
 func (c *IntelCUI) i2cWriteSetup(slave7bit byte, reg byte) error {
@@ -538,7 +539,7 @@ func (c *IntelCUI) i2cWriteSetup(slave7bit byte, reg byte) error {
 
 func (c *IntelCUI) i2cReadChunk(slave7bit byte, size int, last bool) ([]byte, error) {
 	if size <= 0 || size > 16 {
-		return nil, fmt.Errorf("intel igfx: invalid chunk size %d", size)
+		return nil, classifyf(ErrInvalidParameter, "intel igfx: invalid chunk size %d", size)
 	}
 
 	type ioRead struct {
@@ -17,6 +17,12 @@ const (
 	nvapiStatusEndEnumeration = 0xFFFFFFF9
 	nvapiDpAuxTimeout         = 0x000000FF
 
+	// NvAPI_Status 中可分類的錯誤碼。
+	nvapiStatusNoImplementation   = 0xFFFFFFFD // NVAPI_NO_IMPLEMENTATION (-3)
+	nvapiStatusInvalidArgument    = 0xFFFFFFFB // NVAPI_INVALID_ARGUMENT (-5)
+	nvapiStatusIncompatibleStruct = 0xFFFFFFF7 // NVAPI_INCOMPATIBLE_STRUCT_VERSION (-9)
+	nvapiStatusNotSupported       = 0xFFFFFF98 // NVAPI_NOT_SUPPORTED (-104)
+
 	qiInit                         = 0x000000000150E828
 	qiEnumPhysicalGPUs             = 0x00000000E5AC921F
 	qiEnumNvidiaDisplayHandle      = 0x000000009ABDD40D
@@ -33,6 +39,15 @@ const (
 	nvDpAuxParamsV1Version = 0x00010028
 )
 
+// DP AUX 回覆類型，對應 params.Status。
+const (
+	dpAuxReplyNACK     = 0x1
+	dpAuxReplyDefer    = 0x2
+	dpAuxReplyTimeout  = 0x3
+	dpAuxReplyI2CNACK  = 0x4
+	dpAuxReplyI2CDefer = 0x8
+)
+
 const (
 	dpAuxOpWriteDPCD = 0
 	dpAuxOpReadDPCD  = 1
@@ -235,10 +250,10 @@ func (d *nvapiDriver) Name() string {
 
 func (d *nvapiDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
 	if length == 0 {
-		return nil, fmt.Errorf("dpcd read length must be greater than zero")
+		return nil, classifyf(ErrInvalidParameter, "dpcd read length must be greater than zero")
 	}
 	if length > dpAuxMaxPayload {
-		return nil, fmt.Errorf("dpcd read length %d exceeds 16-byte limit", length)
+		return nil, classifyf(ErrInvalidParameter, "dpcd read length %d exceeds 16-byte limit", length)
 	}
 
 	d.mu.Lock()
@@ -255,16 +270,13 @@ func (d *nvapiDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
 
 	status, _ := call3(d.procs.dpAux, d.displayHandle, uintptr(unsafe.Pointer(&params)), uintptr(unsafe.Sizeof(params)))
 	if uint32(status) != nvapiStatusOK {
-		if params.Status == nvapiDpAuxTimeout {
-			return nil, fmt.Errorf("nvapi: dp aux transaction timed out")
+		if err := dpAuxStatusError(params.Status); err != nil {
+			return nil, err
 		}
 		return nil, d.procs.statusError(uint32(status), "NvAPI_Disp_DpAuxChannelControl")
 	}
-	if params.Status == nvapiDpAuxTimeout {
-		return nil, fmt.Errorf("nvapi: dp aux transaction timed out")
-	}
-	if params.Status != 0 {
-		return nil, fmt.Errorf("nvapi: dp aux error status 0x%X", uint32(params.Status))
+	if err := dpAuxStatusError(params.Status); err != nil {
+		return nil, err
 	}
 
 	// LenMinus1 回報實際讀取的位元組數，需再加 1 才是真實長度。
@@ -293,6 +305,34 @@ func (d *nvapiDriver) WriteI2C(addr uint32, data []byte) error {
 	return ErrNotImplemented
 }
 
+// dpAuxStatusError 將 DP AUX 回覆狀態轉為分類錯誤，成功時回傳 nil。
+func dpAuxStatusError(status int32) error {
+	switch uint32(status) {
+	case 0:
+		return nil
+	case nvapiDpAuxTimeout, dpAuxReplyTimeout:
+		return classifyf(ErrTimeout, "nvapi: dp aux transaction timed out")
+	case dpAuxReplyNACK, dpAuxReplyI2CNACK:
+		return classifyf(ErrNACK, "nvapi: dp aux transaction nacked (status 0x%X)", uint32(status))
+	case dpAuxReplyDefer, dpAuxReplyI2CDefer:
+		return classifyf(ErrDefer, "nvapi: dp aux transaction deferred (status 0x%X)", uint32(status))
+	default:
+		return fmt.Errorf("nvapi: dp aux error status 0x%X", uint32(status))
+	}
+}
+
+// nvapiStatusKind 回傳 NvAPI_Status 對應的錯誤分類，無對應時回傳 nil。
+func nvapiStatusKind(status uint32) error {
+	switch status {
+	case nvapiStatusInvalidArgument, nvapiStatusIncompatibleStruct:
+		return ErrInvalidParameter
+	case nvapiStatusNoImplementation, nvapiStatusNotSupported:
+		return ErrNotImplemented
+	default:
+		return nil
+	}
+}
+
 func (p *nvapiProcs) statusError(status uint32, context string) error {
 	if status == nvapiStatusOK {
 		return nil
@@ -311,9 +351,9 @@ func (p *nvapiProcs) statusError(status uint32, context string) error {
 	}
 	if context != "" {
 		// 若提供 context，將其加入錯誤訊息中便於追蹤。
-		return fmt.Errorf("%s: %s (0x%08X)", context, message, status)
+		return WithKind(fmt.Errorf("%s: %s (0x%08X)", context, message, status), nvapiStatusKind(status))
 	}
-	return fmt.Errorf("nvapi error: %s (0x%08X)", message, status)
+	return WithKind(fmt.Errorf("nvapi error: %s (0x%08X)", message, status), nvapiStatusKind(status))
 }
 
 func call0(fn uintptr) (uintptr, syscall.Errno) {
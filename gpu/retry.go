@@ -0,0 +1,244 @@
+package gpu
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy 描述暫時性錯誤的重試方式。
+type RetryPolicy struct {
+	MaxAttempts int           // 含第一次的總嘗試次數，1 以下表示不重試
+	Backoff     time.Duration // 第一次重試前的等待時間，之後每次加倍
+	MaxBackoff  time.Duration // 等待時間上限，0 表示不設上限
+	RetryOn     []error       // 需要重試的錯誤分類，nil 時使用 ErrDefer、ErrBusy 與 ErrTimeout
+
+	// RetryWriteTimeout 為 true 時寫入逾時也重試。逾時的寫入可能已送達 sink，
+	// 重送非冪等的 TCON 序列（解鎖、燒錄、EEPROM 頁寫入）可能重複執行，因此預設只重試讀取。
+	RetryWriteTimeout bool
+}
+
+// DefaultRetryPolicy 回傳預設的重試設定：最多 4 次、由 5 ms 起加倍至 100 ms。
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 4, Backoff: 5 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}
+}
+
+// retryable 判斷分類是否需要重試；write 表示寫入交易，逾時時只在 RetryWriteTimeout 啟用時重試。
+func (p RetryPolicy) retryable(kind error, write bool) bool {
+	if kind == nil {
+		return false
+	}
+	if write && kind == ErrTimeout && !p.RetryWriteTimeout {
+		return false
+	}
+	retryOn := p.RetryOn
+	if retryOn == nil {
+		retryOn = []error{ErrDefer, ErrBusy, ErrTimeout}
+	}
+	for _, k := range retryOn {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// delay 回傳第 retry 次重試（由 1 起算）前的等待時間。
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// RetryStats 為重試統計。
+type RetryStats struct {
+	Calls     uint64            // 交易次數
+	Retries   uint64            // 重試次數
+	Recovered uint64            // 重試後成功的交易
+	Failures  uint64            // 最終失敗的交易
+	Errors    map[string]uint64 // 每次嘗試失敗時依分類累計，無法分類者計入 "other"
+}
+
+// String 以一行文字摘要統計結果。
+func (s RetryStats) String() string {
+	text := fmt.Sprintf("calls=%d retries=%d recovered=%d failures=%d", s.Calls, s.Retries, s.Recovered, s.Failures)
+	if len(s.Errors) == 0 {
+		return text
+	}
+	kinds := make([]string, 0, len(s.Errors))
+	for kind := range s.Errors {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		parts[i] = fmt.Sprintf("%s=%d", kind, s.Errors[kind])
+	}
+	return text + " errors[" + strings.Join(parts, " ") + "]"
+}
+
+// RetryDriver 包裝驅動，將錯誤分類為 sentinel 錯誤並依 RetryPolicy 重試暫時性錯誤。
+// 回傳的錯誤保留原始訊息，可用 errors.Is 比對 ErrTimeout、ErrDefer 等分類。
+type RetryDriver struct {
+	driver Driver
+	policy RetryPolicy
+	sleep  func(time.Duration)
+
+	mu    sync.Mutex
+	stats RetryStats
+}
+
+// NewRetryDriver 以指定的重試設定包裝驅動；driver 已是 RetryDriver 時改包裝其底層驅動，避免重複重試。
+func NewRetryDriver(driver Driver, policy RetryPolicy) *RetryDriver {
+	if r, ok := driver.(*RetryDriver); ok {
+		driver = r.driver
+	}
+	return &RetryDriver{driver: driver, policy: policy, sleep: time.Sleep}
+}
+
+// Unwrap 回傳被包裝的驅動。
+func (d *RetryDriver) Unwrap() Driver {
+	return d.driver
+}
+
+// WithoutRetry 回傳不重試的底層驅動，供以錯誤判斷裝置是否存在的操作（例如 I2C 掃描）使用。
+func WithoutRetry(driver Driver) Driver {
+	if r, ok := driver.(*RetryDriver); ok {
+		return r.driver
+	}
+	return driver
+}
+
+// Stats 回傳目前的統計快照。
+func (d *RetryDriver) Stats() RetryStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := d.stats
+	stats.Errors = make(map[string]uint64, len(d.stats.Errors))
+	for kind, n := range d.stats.Errors {
+		stats.Errors[kind] = n
+	}
+	return stats
+}
+
+// ResetStats 清除統計。
+func (d *RetryDriver) ResetStats() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stats = RetryStats{}
+}
+
+func (d *RetryDriver) Name() string {
+	return d.driver.Name()
+}
+
+func (d *RetryDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
+	var data []byte
+	err := d.do(false, func() (err error) {
+		data, err = d.driver.ReadDPCD(addr, length)
+		return err
+	})
+	return data, err
+}
+
+func (d *RetryDriver) WriteDPCD(addr uint32, data []byte) error {
+	return d.do(true, func() error {
+		return d.driver.WriteDPCD(addr, data)
+	})
+}
+
+func (d *RetryDriver) ReadI2C(addr uint32, length uint32) ([]byte, error) {
+	var data []byte
+	err := d.do(false, func() (err error) {
+		data, err = d.driver.ReadI2C(addr, length)
+		return err
+	})
+	return data, err
+}
+
+func (d *RetryDriver) WriteI2C(addr uint32, data []byte) error {
+	return d.do(true, func() error {
+		return d.driver.WriteI2C(addr, data)
+	})
+}
+
+// ReadEDIDSegment 在底層驅動實作 EDIDSegmentReader 時轉呼叫並套用重試；
+// 不支援時回傳 ErrNotImplemented，呼叫端應改用分開的 0x30/0x50 交易。
+func (d *RetryDriver) ReadEDIDSegment(segment, offset byte, length uint32) ([]byte, error) {
+	reader, ok := d.driver.(EDIDSegmentReader)
+	if !ok {
+		return nil, ErrNotImplemented
+	}
+	var data []byte
+	err := d.do(false, func() (err error) {
+		data, err = reader.ReadEDIDSegment(segment, offset, length)
+		return err
+	})
+	return data, err
+}
+
+// Close 關閉底層驅動（若支援），例如遠端連線。
+func (d *RetryDriver) Close() error {
+	if closer, ok := d.driver.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// do 執行一次交易，遇到可重試的分類時依退避時間重試，並更新統計；write 表示寫入交易。
+func (d *RetryDriver) do(write bool, op func() error) error {
+	attempts := max(d.policy.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := op()
+		kind := Classify(err)
+		last := err == nil || attempt >= attempts || !d.policy.retryable(kind, write)
+		d.record(attempt, err, kind, last)
+		if err == nil {
+			return nil
+		}
+		if last {
+			err = WithKind(err, kind)
+			if attempt > 1 {
+				return fmt.Errorf("%w (after %d attempts)", err, attempt)
+			}
+			return err
+		}
+		d.sleep(d.policy.delay(attempt))
+	}
+}
+
+// record 更新一次嘗試的統計；last 表示這是該交易的最後一次嘗試。
+func (d *RetryDriver) record(attempt int, err, kind error, last bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if attempt == 1 {
+		d.stats.Calls++
+	} else {
+		d.stats.Retries++
+	}
+	if err == nil {
+		if attempt > 1 {
+			d.stats.Recovered++
+		}
+		return
+	}
+	if d.stats.Errors == nil {
+		d.stats.Errors = map[string]uint64{}
+	}
+	name := ErrorKind(kind)
+	if name == "" {
+		name = "other"
+	}
+	d.stats.Errors[name]++
+	if last {
+		d.stats.Failures++
+	}
+}
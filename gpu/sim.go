@@ -0,0 +1,253 @@
+package gpu
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// dpcdAddressLimit 為 DPCD 20-bit 位址空間的上限。
+const dpcdAddressLimit = 0x100000
+
+// SimDriver 以記憶體模擬 DPCD 與 I2C 空間，不會存取任何硬體。
+// 未寫入過的位址讀取時回傳 0x00；從未載入或寫入過的 I2C 從屬位址視為無回應。
+type SimDriver struct {
+	mu     sync.Mutex
+	dpcd   map[uint32]byte
+	i2c    map[uint32]byte // 以 slave<<16 | register 為索引
+	slaves map[byte]bool
+}
+
+// NewSimDriver 建立空白的模擬驅動。
+func NewSimDriver() *SimDriver {
+	return &SimDriver{
+		dpcd:   make(map[uint32]byte),
+		i2c:    make(map[uint32]byte),
+		slaves: make(map[byte]bool),
+	}
+}
+
+func (d *SimDriver) Name() string {
+	return "Simulated AUX"
+}
+
+func (d *SimDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
+	if length == 0 {
+		return nil, classifyf(ErrInvalidParameter, "dpcd read length must be greater than zero")
+	}
+	if addr+length > dpcdAddressLimit {
+		return nil, classifyf(ErrInvalidParameter, "dpcd read 0x%05X+%d exceeds 20-bit address space", addr, length)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	data := make([]byte, length)
+	for i := range data {
+		data[i] = d.dpcd[addr+uint32(i)]
+	}
+	return data, nil
+}
+
+func (d *SimDriver) WriteDPCD(addr uint32, data []byte) error {
+	if addr+uint32(len(data)) > dpcdAddressLimit {
+		return classifyf(ErrInvalidParameter, "dpcd write 0x%05X+%d exceeds 20-bit address space", addr, len(data))
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, b := range data {
+		d.dpcd[addr+uint32(i)] = b
+	}
+	return nil
+}
+
+func (d *SimDriver) ReadI2C(addr uint32, length uint32) ([]byte, error) {
+	if length == 0 {
+		return []byte{}, nil
+	}
+	slave, reg := splitI2CAddress(addr)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.slaves[slave] {
+		return nil, classifyf(ErrNACK, "i2c slave 0x%02X did not acknowledge", slave)
+	}
+	data := make([]byte, length)
+	for i := range data {
+		data[i] = d.i2c[i2cKey(slave, reg+uint32(i))]
+	}
+	return data, nil
+}
+
+func (d *SimDriver) WriteI2C(addr uint32, data []byte) error {
+	slave, reg := splitI2CAddress(addr)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.slaves[slave] = true
+	for i, b := range data {
+		d.i2c[i2cKey(slave, reg+uint32(i))] = b
+	}
+	return nil
+}
+
+// DirectI2C 回傳 true：模擬空間接受任意長度的寫入，讓 DDC/CI 可在模擬與 dry-run 下執行。
+func (d *SimDriver) DirectI2C() bool {
+	return true
+}
+
+// splitI2CAddress 依 read_i2c 的慣例拆出 7-bit 從屬位址與暫存器位址。
+func splitI2CAddress(addr uint32) (byte, uint32) {
+	return byte(addr & 0x7F), addr >> 8
+}
+
+func i2cKey(slave byte, reg uint32) uint32 {
+	return uint32(slave)<<16 | reg&0xFFFF
+}
+
+// Snapshot 以 JSON 保存 DPCD 與 I2C 內容，鍵為起始位址，值為十六進位位元組字串。
+//
+//	{"dpcd": {"0x00000": "12 14 C4"}, "i2c": {"0x50": "00 FF FF FF FF FF FF 00"}}
+//
+// I2C 的鍵可寫成 "0x50" 或 "0x50:0x80"（從屬位址:起始暫存器）。
+type Snapshot struct {
+	DPCD map[string]string `json:"dpcd,omitempty"`
+	I2C  map[string]string `json:"i2c,omitempty"`
+}
+
+// LoadSnapshot 讀取快照檔並寫入模擬空間。
+func (d *SimDriver) LoadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("snapshot %s: %w", path, err)
+	}
+	return d.Apply(snap)
+}
+
+// Apply 將快照內容寫入模擬空間。
+func (d *SimDriver) Apply(snap Snapshot) error {
+	for key, value := range snap.DPCD {
+		addr, err := strconv.ParseUint(strings.TrimSpace(key), 0, 32)
+		if err != nil {
+			return fmt.Errorf("snapshot dpcd address %q: %w", key, err)
+		}
+		bytes, err := decodeHexBytes(value)
+		if err != nil {
+			return fmt.Errorf("snapshot dpcd 0x%05X: %w", addr, err)
+		}
+		if err := d.WriteDPCD(uint32(addr), bytes); err != nil {
+			return err
+		}
+	}
+	for key, value := range snap.I2C {
+		slaveText, regText, _ := strings.Cut(key, ":")
+		slave, err := strconv.ParseUint(strings.TrimSpace(slaveText), 0, 8)
+		if err != nil || slave > 0x7F {
+			return fmt.Errorf("snapshot i2c slave %q is invalid", key)
+		}
+		reg := uint64(0)
+		if regText != "" {
+			if reg, err = strconv.ParseUint(strings.TrimSpace(regText), 0, 16); err != nil {
+				return fmt.Errorf("snapshot i2c register %q: %w", key, err)
+			}
+		}
+		bytes, err := decodeHexBytes(value)
+		if err != nil {
+			return fmt.Errorf("snapshot i2c %s: %w", key, err)
+		}
+		if err := d.WriteI2C(uint32(slave)|uint32(reg)<<8, bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SnapshotRange 描述擷取快照時要讀取的區段。
+type SnapshotRange struct {
+	I2C     bool
+	Address uint32 // DPCD 位址或 I2C 從屬位址
+	Start   uint32 // I2C 起始暫存器
+	Length  uint32
+}
+
+// snapshotChunkSize 為擷取快照時單次讀取的位元組數。
+const snapshotChunkSize = 16
+
+// DefaultSnapshotRanges 涵蓋常用的 DPCD 能力、連結、狀態、背光與 EDID 區段。
+var DefaultSnapshotRanges = []SnapshotRange{
+	{Address: 0x00000, Length: 0x100},
+	{Address: 0x00100, Length: 0x100},
+	{Address: 0x00200, Length: 0x100},
+	{Address: 0x00400, Length: 0x30},
+	{Address: 0x00500, Length: 0x30},
+	{Address: 0x00600, Length: 0x10},
+	{Address: 0x00700, Length: 0x100},
+	{Address: 0x02000, Length: 0x100},
+	{I2C: true, Address: 0x50, Length: 0x100},
+}
+
+// CaptureSnapshot 以唯讀方式從驅動擷取指定區段，失敗的區段略過並回傳彙整錯誤。
+func CaptureSnapshot(d Driver, ranges []SnapshotRange) (Snapshot, error) {
+	snap := Snapshot{DPCD: map[string]string{}, I2C: map[string]string{}}
+	var failed []string
+	for _, r := range ranges {
+		var (
+			data []byte
+			err  error
+			key  string
+		)
+		if r.I2C {
+			key = fmt.Sprintf("0x%02X:0x%02X", r.Address, r.Start)
+		} else {
+			key = fmt.Sprintf("0x%05X", r.Address)
+		}
+		// 分段讀取：原生 AUX 交易每次最多 16 位元組，部分驅動（例如 NVAPI）不會自行拆分。
+		for offset := uint32(0); offset < r.Length && err == nil; offset += snapshotChunkSize {
+			n := min(r.Length-offset, snapshotChunkSize)
+			var chunk []byte
+			if r.I2C {
+				chunk, err = d.ReadI2C(r.Address|(r.Start+offset)<<8, n)
+			} else {
+				chunk, err = d.ReadDPCD(r.Address+offset, n)
+			}
+			data = append(data, chunk...)
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		if r.I2C {
+			snap.I2C[key] = encodeHexBytes(data)
+		} else {
+			snap.DPCD[key] = encodeHexBytes(data)
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return snap, fmt.Errorf("snapshot: %s", strings.Join(failed, "; "))
+	}
+	return snap, nil
+}
+
+// Save 將快照以縮排 JSON 寫入檔案。
+func (s Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func decodeHexBytes(text string) ([]byte, error) {
+	cleaned := strings.NewReplacer(" ", "", ",", "", "\n", "", "\t", "").Replace(text)
+	return hex.DecodeString(cleaned)
+}
+
+func encodeHexBytes(data []byte) string {
+	return strings.TrimSpace(fmt.Sprintf("% X", data))
+}
@@ -0,0 +1,163 @@
+// Package hotplug 監看顯示器的連接與移除：Windows 使用 WM_DEVICECHANGE 裝置通知，
+// Linux 使用 udev 的 kobject uevent netlink。事件經去彈跳後重新列舉顯示器，
+// 只有顯示器組合確實改變時才通知呼叫端。
+package hotplug
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+// 事件動作。
+const (
+	ActionAdd    = "add"
+	ActionRemove = "remove"
+	ActionChange = "change"
+)
+
+// ErrUnsupported 表示目前平台沒有熱插拔通知來源。
+var ErrUnsupported = errors.New("hotplug notifications are only supported on Windows and Linux")
+
+// Event 為作業系統送出的顯示裝置變更通知；Device 為裝置路徑或介面名稱，僅供記錄。
+type Event struct {
+	Action string
+	Device string
+}
+
+// Source 提供裝置變更事件；Close 後 Events 的通道會被關閉。
+type Source interface {
+	Events() <-chan Event
+	Close() error
+}
+
+// FakeSource 為手動送出事件的來源，供測試或模擬產線換片使用。
+type FakeSource struct {
+	events chan Event
+	once   sync.Once
+}
+
+// NewFakeSource 建立模擬事件來源。
+func NewFakeSource() *FakeSource {
+	return &FakeSource{events: make(chan Event, 16)}
+}
+
+// Emit 送出一個事件。
+func (s *FakeSource) Emit(event Event) {
+	s.events <- event
+}
+
+// Events 回傳事件通道。
+func (s *FakeSource) Events() <-chan Event {
+	return s.events
+}
+
+// Close 關閉事件通道。
+func (s *FakeSource) Close() error {
+	s.once.Do(func() { close(s.events) })
+	return nil
+}
+
+// Change 為一次重新列舉後的差異。
+type Change struct {
+	Added    []*display.Display
+	Removed  []*display.Display
+	Displays []*display.Display // 重新列舉的完整結果
+	Err      error              // 列舉時的錯誤，可能與部分結果同時出現
+}
+
+// DefaultDebounce 為收到事件後等待的時間；插拔時通常會連續送出多個事件，
+// 且作業系統需要一點時間才會更新 EDID。
+const DefaultDebounce = 500 * time.Millisecond
+
+// Watcher 接收 Source 的事件，去彈跳後以 List 重新列舉，顯示器組合改變時呼叫 OnChange。
+type Watcher struct {
+	Source   Source
+	List     func() ([]*display.Display, error)
+	OnChange func(Change)
+	Debounce time.Duration
+
+	known map[string]*display.Display
+	done  chan struct{}
+}
+
+// NewWatcher 建立監看器；initial 為目前已知的顯示器，作為比較的基準。
+func NewWatcher(source Source, list func() ([]*display.Display, error), initial []*display.Display, onChange func(Change)) *Watcher {
+	return &Watcher{
+		Source:   source,
+		List:     list,
+		OnChange: onChange,
+		Debounce: DefaultDebounce,
+		known:    displaySet(initial),
+		done:     make(chan struct{}),
+	}
+}
+
+// Run 處理事件直到 Source 關閉；通常在獨立 goroutine 中執行。
+func (w *Watcher) Run() {
+	defer close(w.done)
+	events := w.Source.Events()
+	var timer <-chan time.Time
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			// 連續事件只在最後一個事件後重新列舉一次。
+			timer = time.After(w.Debounce)
+		case <-timer:
+			timer = nil
+			w.rescan()
+		}
+	}
+}
+
+// Close 關閉事件來源並等待 Run 結束。
+func (w *Watcher) Close() error {
+	err := w.Source.Close()
+	<-w.done
+	return err
+}
+
+// rescan 重新列舉並與上次結果比較。
+func (w *Watcher) rescan() {
+	displays, err := w.List()
+	current := displaySet(displays)
+
+	change := Change{Displays: displays, Err: err}
+	for key, d := range current {
+		if _, ok := w.known[key]; !ok {
+			change.Added = append(change.Added, d)
+		}
+	}
+	for key, d := range w.known {
+		if _, ok := current[key]; !ok {
+			change.Removed = append(change.Removed, d)
+		}
+	}
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		return
+	}
+	w.known = current
+	if w.OnChange != nil {
+		w.OnChange(change)
+	}
+}
+
+// displaySet 以連接埠與 EDID 內容作為識別，同一連接埠換上不同面板也視為變更。
+func displaySet(displays []*display.Display) map[string]*display.Display {
+	set := make(map[string]*display.Display, len(displays))
+	for _, d := range displays {
+		if d.Source == display.SourceFile {
+			continue
+		}
+		sum := sha1.Sum(d.EDID)
+		set[d.DeviceID+"|"+hex.EncodeToString(sum[:])] = d
+	}
+	return set
+}
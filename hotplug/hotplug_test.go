@@ -0,0 +1,197 @@
+package hotplug
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+func testDisplay(id string, edid ...byte) *display.Display {
+	return &display.Display{DeviceID: id, EDID: edid, Source: display.SourceSysfs}
+}
+
+func deviceIDs(displays []*display.Display) []string {
+	ids := make([]string, 0, len(displays))
+	for _, d := range displays {
+		ids = append(ids, d.DeviceID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestWatcherDiff(t *testing.T) {
+	edp := testDisplay("card0-eDP-1", 0x01)
+	hdmi := testDisplay("card0-HDMI-A-1", 0x02)
+	swapped := testDisplay("card0-HDMI-A-1", 0x03)
+	file := &display.Display{DeviceID: "panel.bin", EDID: []byte{0x04}, Source: display.SourceFile}
+	listErr := errors.New("enumerate failed")
+
+	tests := []struct {
+		name        string
+		initial     []*display.Display
+		next        []*display.Display
+		err         error
+		wantChange  bool
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name:       "display added",
+			initial:    []*display.Display{edp},
+			next:       []*display.Display{edp, hdmi},
+			wantChange: true,
+			wantAdded:  []string{"card0-HDMI-A-1"},
+		},
+		{
+			name:        "display removed",
+			initial:     []*display.Display{edp, hdmi},
+			next:        []*display.Display{edp},
+			wantChange:  true,
+			wantRemoved: []string{"card0-HDMI-A-1"},
+		},
+		{
+			name:        "panel swapped on same connector",
+			initial:     []*display.Display{edp, hdmi},
+			next:        []*display.Display{edp, swapped},
+			wantChange:  true,
+			wantAdded:   []string{"card0-HDMI-A-1"},
+			wantRemoved: []string{"card0-HDMI-A-1"},
+		},
+		{
+			name:    "unchanged set is not reported",
+			initial: []*display.Display{edp, hdmi},
+			next:    []*display.Display{hdmi, edp},
+		},
+		{
+			name:    "file sources are ignored",
+			initial: []*display.Display{edp, file},
+			next:    []*display.Display{edp},
+		},
+		{
+			name:        "partial result with error",
+			initial:     []*display.Display{edp, hdmi},
+			next:        []*display.Display{hdmi},
+			err:         listErr,
+			wantChange:  true,
+			wantRemoved: []string{"card0-eDP-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := NewFakeSource()
+			var calls atomic.Int32
+			list := func() ([]*display.Display, error) {
+				calls.Add(1)
+				return tt.next, tt.err
+			}
+			changes := make(chan Change, 4)
+			watcher := NewWatcher(source, list, tt.initial, func(c Change) { changes <- c })
+			watcher.Debounce = 10 * time.Millisecond
+			go watcher.Run()
+
+			// 連續事件經去彈跳後只重新列舉一次。
+			source.Emit(Event{Action: ActionRemove, Device: "card0-HDMI-A-1"})
+			source.Emit(Event{Action: ActionAdd, Device: "card0-HDMI-A-1"})
+			source.Emit(Event{Action: ActionChange, Device: "card0"})
+
+			var got *Change
+			select {
+			case c := <-changes:
+				got = &c
+			case <-time.After(200 * time.Millisecond):
+			}
+			if err := watcher.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if n := calls.Load(); n != 1 {
+				t.Errorf("List called %d times, want 1", n)
+			}
+
+			if !tt.wantChange {
+				if got != nil {
+					t.Fatalf("unexpected change: added %v removed %v", deviceIDs(got.Added), deviceIDs(got.Removed))
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("no change reported")
+			}
+			if ids := deviceIDs(got.Added); !slices.Equal(ids, tt.wantAdded) {
+				t.Errorf("Added = %v, want %v", ids, tt.wantAdded)
+			}
+			if ids := deviceIDs(got.Removed); !slices.Equal(ids, tt.wantRemoved) {
+				t.Errorf("Removed = %v, want %v", ids, tt.wantRemoved)
+			}
+			if len(got.Displays) != len(tt.next) {
+				t.Errorf("Displays has %d entries, want %d", len(got.Displays), len(tt.next))
+			}
+			if !errors.Is(got.Err, tt.err) {
+				t.Errorf("Err = %v, want %v", got.Err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWatcherTracksLastResult(t *testing.T) {
+	edp := testDisplay("card0-eDP-1", 0x01)
+	hdmi := testDisplay("card0-HDMI-A-1", 0x02)
+	// 每次事件依序回傳下一份列舉結果；基準在每次回報變更後更新。
+	results := [][]*display.Display{
+		{edp, hdmi},
+		{edp, hdmi},
+		{edp},
+	}
+	want := []struct {
+		added, removed []string
+	}{
+		{added: []string{"card0-HDMI-A-1"}},
+		{removed: []string{"card0-HDMI-A-1"}},
+	}
+
+	source := NewFakeSource()
+	var calls atomic.Int32
+	list := func() ([]*display.Display, error) {
+		n := calls.Add(1)
+		return results[n-1], nil
+	}
+	changes := make(chan Change, len(results))
+	watcher := NewWatcher(source, list, []*display.Display{edp}, func(c Change) { changes <- c })
+	watcher.Debounce = time.Millisecond
+	go watcher.Run()
+	defer watcher.Close()
+
+	next := 0
+	for i := range results {
+		source.Emit(Event{Action: ActionChange})
+		deadline := time.After(time.Second)
+		for int(calls.Load()) <= i {
+			select {
+			case <-deadline:
+				t.Fatalf("event %d: List not called", i)
+			case <-time.After(time.Millisecond):
+			}
+		}
+		select {
+		case c := <-changes:
+			if next >= len(want) {
+				t.Fatalf("event %d: unexpected change", i)
+			}
+			if ids := deviceIDs(c.Added); !slices.Equal(ids, want[next].added) {
+				t.Errorf("change %d Added = %v, want %v", next, ids, want[next].added)
+			}
+			if ids := deviceIDs(c.Removed); !slices.Equal(ids, want[next].removed) {
+				t.Errorf("change %d Removed = %v, want %v", next, ids, want[next].removed)
+			}
+			next++
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	if next != len(want) {
+		t.Errorf("got %d changes, want %d", next, len(want))
+	}
+}
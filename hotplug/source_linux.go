@@ -0,0 +1,91 @@
+//go:build linux
+
+package hotplug
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// ueventGroupKernel 為核心直接送出 uevent 的多播群組（udev 重新廣播前的原始事件）。
+const ueventGroupKernel = 1
+
+// netlinkSource 由 NETLINK_KOBJECT_UEVENT 接收 drm 子系統的 uevent。
+type netlinkSource struct {
+	file   *os.File
+	events chan Event
+}
+
+// NewSource 開啟 udev 使用的 kobject uevent netlink，只轉送 SUBSYSTEM=drm 的事件。
+func NewSource() (Source, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, fmt.Errorf("open uevent netlink: %w", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: ueventGroupKernel}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("bind uevent netlink: %w", err)
+	}
+
+	// 非阻塞的檔案描述元交給 runtime poller，Close 時可中斷阻塞中的 Read。
+	s := &netlinkSource{
+		file:   os.NewFile(uintptr(fd), "uevent"),
+		events: make(chan Event, 16),
+	}
+	go s.loop()
+	return s, nil
+}
+
+func (s *netlinkSource) loop() {
+	defer close(s.events)
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := s.file.Read(buf)
+		if err != nil {
+			return
+		}
+		if event, ok := parseUevent(buf[:n]); ok {
+			s.events <- event
+		}
+	}
+}
+
+// parseUevent 解析「action@devpath\0KEY=VALUE\0...」格式的 uevent，
+// 非 drm 子系統的事件回傳 false。
+func parseUevent(msg []byte) (Event, bool) {
+	fields := bytes.Split(msg, []byte{0})
+	if len(fields) == 0 || !bytes.Contains(fields[0], []byte("@")) {
+		return Event{}, false
+	}
+	var event Event
+	var subsystem string
+	for _, field := range fields[1:] {
+		key, value, ok := bytes.Cut(field, []byte("="))
+		if !ok {
+			continue
+		}
+		switch string(key) {
+		case "ACTION":
+			event.Action = string(value)
+		case "DEVPATH":
+			event.Device = string(value)
+		case "SUBSYSTEM":
+			subsystem = string(value)
+		}
+	}
+	if subsystem != "drm" {
+		return Event{}, false
+	}
+	return event, true
+}
+
+func (s *netlinkSource) Events() <-chan Event {
+	return s.events
+}
+
+func (s *netlinkSource) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,8 @@
+//go:build !windows && !linux
+
+package hotplug
+
+// NewSource 在不支援的平台上回傳 ErrUnsupported。
+func NewSource() (Source, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,239 @@
+//go:build windows
+
+package hotplug
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	wmClose                  = 0x0010
+	wmDeviceChange           = 0x0219
+	dbtDeviceArrival         = 0x8000
+	dbtDeviceRemoveComplete  = 0x8004
+	dbtDevTypDeviceInterface = 5
+	deviceNotifyWindowHandle = 0
+	errorClassAlreadyExists  = 1410
+)
+
+// hwndMessage 為 HWND_MESSAGE（(HWND)-3），建立不顯示的訊息專用視窗。
+const hwndMessage = ^uintptr(2)
+
+// guid 對應 Win32 GUID 結構。
+type guid struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// guidDevInterfaceMonitor 為 GUID_DEVINTERFACE_MONITOR {E6F07B5F-EE97-4A90-B076-33F57BF4EAA7}。
+var guidDevInterfaceMonitor = guid{0xE6F07B5F, 0xEE97, 0x4A90, [8]byte{0xB0, 0x76, 0x33, 0xF5, 0x7B, 0xF4, 0xEA, 0xA7}}
+
+// devBroadcastDeviceInterface 對應 DEV_BROADCAST_DEVICEINTERFACE_W，Name 為可變長度字串的起點。
+type devBroadcastDeviceInterface struct {
+	Size       uint32
+	DeviceType uint32
+	Reserved   uint32
+	ClassGUID  guid
+	Name       [1]uint16
+}
+
+// wndClassEx 對應 WNDCLASSEXW。
+type wndClassEx struct {
+	Size       uint32
+	Style      uint32
+	WndProc    uintptr
+	ClsExtra   int32
+	WndExtra   int32
+	Instance   uintptr
+	Icon       uintptr
+	Cursor     uintptr
+	Background uintptr
+	MenuName   *uint16
+	ClassName  *uint16
+	IconSm     uintptr
+}
+
+// winMsg 對應 MSG。
+type winMsg struct {
+	Hwnd     uintptr
+	Message  uint32
+	WParam   uintptr
+	LParam   uintptr
+	Time     uint32
+	PtX      int32
+	PtY      int32
+	LPrivate uint32
+}
+
+var (
+	user32                           = syscall.NewLazyDLL("user32.dll")
+	kernel32                         = syscall.NewLazyDLL("kernel32.dll")
+	procRegisterClassExW             = user32.NewProc("RegisterClassExW")
+	procCreateWindowExW              = user32.NewProc("CreateWindowExW")
+	procDestroyWindow                = user32.NewProc("DestroyWindow")
+	procDefWindowProcW               = user32.NewProc("DefWindowProcW")
+	procGetMessageW                  = user32.NewProc("GetMessageW")
+	procDispatchMessageW             = user32.NewProc("DispatchMessageW")
+	procPostMessageW                 = user32.NewProc("PostMessageW")
+	procPostQuitMessage              = user32.NewProc("PostQuitMessage")
+	procRegisterDeviceNotificationW  = user32.NewProc("RegisterDeviceNotificationW")
+	procUnregisterDeviceNotification = user32.NewProc("UnregisterDeviceNotification")
+	procGetModuleHandleW             = kernel32.NewProc("GetModuleHandleW")
+)
+
+var (
+	// 視窗程序由所有來源共用，依視窗代碼找到對應的來源。
+	windowClassOnce sync.Once
+	windowClassErr  error
+	windowClassName = syscall.StringToUTF16Ptr("GMTAUXHotplugWindow")
+	sourcesMu       sync.Mutex
+	sources         = map[uintptr]*windowSource{}
+)
+
+// windowSource 以訊息專用視窗註冊 GUID_DEVINTERFACE_MONITOR 的 WM_DEVICECHANGE 通知。
+type windowSource struct {
+	hwnd   uintptr
+	events chan Event
+}
+
+// NewSource 建立訊息專用視窗並註冊顯示器介面的裝置通知，訊息迴圈在專屬的 OS 執行緒上執行。
+func NewSource() (Source, error) {
+	s := &windowSource{events: make(chan Event, 16)}
+	ready := make(chan error, 1)
+	go s.loop(ready)
+	if err := <-ready; err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func registerWindowClass() error {
+	windowClassOnce.Do(func() {
+		instance, _, _ := procGetModuleHandleW.Call(0)
+		class := wndClassEx{
+			WndProc:   syscall.NewCallback(windowProc),
+			Instance:  instance,
+			ClassName: windowClassName,
+		}
+		class.Size = uint32(unsafe.Sizeof(class))
+		if r, _, err := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&class))); r == 0 {
+			if errno, ok := err.(syscall.Errno); !ok || errno != errorClassAlreadyExists {
+				windowClassErr = fmt.Errorf("RegisterClassExW: %w", err)
+			}
+		}
+	})
+	return windowClassErr
+}
+
+func (s *windowSource) loop(ready chan<- error) {
+	// 視窗與訊息迴圈必須留在同一個 OS 執行緒。
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(s.events)
+
+	if err := registerWindowClass(); err != nil {
+		ready <- err
+		return
+	}
+	instance, _, _ := procGetModuleHandleW.Call(0)
+	hwnd, _, err := procCreateWindowExW.Call(0, uintptr(unsafe.Pointer(windowClassName)), 0, 0,
+		0, 0, 0, 0, hwndMessage, 0, instance, 0)
+	if hwnd == 0 {
+		ready <- fmt.Errorf("CreateWindowExW: %w", err)
+		return
+	}
+	defer procDestroyWindow.Call(hwnd)
+
+	sourcesMu.Lock()
+	s.hwnd = hwnd
+	sources[hwnd] = s
+	sourcesMu.Unlock()
+	defer func() {
+		sourcesMu.Lock()
+		delete(sources, hwnd)
+		sourcesMu.Unlock()
+	}()
+
+	filter := devBroadcastDeviceInterface{DeviceType: dbtDevTypDeviceInterface, ClassGUID: guidDevInterfaceMonitor}
+	filter.Size = uint32(unsafe.Sizeof(filter))
+	notify, _, err := procRegisterDeviceNotificationW.Call(hwnd, uintptr(unsafe.Pointer(&filter)), deviceNotifyWindowHandle)
+	if notify == 0 {
+		ready <- fmt.Errorf("RegisterDeviceNotificationW: %w", err)
+		return
+	}
+	defer procUnregisterDeviceNotification.Call(notify)
+	ready <- nil
+
+	var msg winMsg
+	for {
+		// GetMessageW 收到 WM_QUIT 時回傳 0，錯誤時回傳 -1。
+		r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+		if int32(r) <= 0 {
+			return
+		}
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&msg)))
+	}
+}
+
+// windowProc 處理裝置通知；WM_CLOSE 結束訊息迴圈。
+func windowProc(hwnd, message, wParam, lParam uintptr) uintptr {
+	switch message {
+	case wmDeviceChange:
+		if wParam != dbtDeviceArrival && wParam != dbtDeviceRemoveComplete || lParam == 0 {
+			break
+		}
+		// lParam 指向系統配置的 DEV_BROADCAST_HDR，以雙重指標轉換避免 uintptr 直接轉指標。
+		hdr := *(**devBroadcastDeviceInterface)(unsafe.Pointer(&lParam))
+		if hdr.DeviceType != dbtDevTypDeviceInterface {
+			break
+		}
+		sourcesMu.Lock()
+		s := sources[hwnd]
+		sourcesMu.Unlock()
+		if s == nil {
+			break
+		}
+		event := Event{Action: ActionAdd, Device: deviceInterfaceName(hdr)}
+		if wParam == dbtDeviceRemoveComplete {
+			event.Action = ActionRemove
+		}
+		// 訊息執行緒不可阻塞，通道已滿時捨棄；去彈跳後仍會重新列舉。
+		select {
+		case s.events <- event:
+		default:
+		}
+		return 1
+	case wmClose:
+		procPostQuitMessage.Call(0)
+		return 0
+	}
+	r, _, _ := procDefWindowProcW.Call(hwnd, message, wParam, lParam)
+	return r
+}
+
+// deviceInterfaceName 取出 DEV_BROADCAST_DEVICEINTERFACE_W 結尾的裝置介面路徑。
+func deviceInterfaceName(hdr *devBroadcastDeviceInterface) string {
+	offset := unsafe.Offsetof(hdr.Name)
+	if uintptr(hdr.Size) <= offset {
+		return ""
+	}
+	chars := unsafe.Slice(&hdr.Name[0], (uintptr(hdr.Size)-offset)/2)
+	return syscall.UTF16ToString(chars)
+}
+
+func (s *windowSource) Events() <-chan Event {
+	return s.events
+}
+
+func (s *windowSource) Close() error {
+	if r, _, err := procPostMessageW.Call(s.hwnd, wmClose, 0, 0); r == 0 {
+		return fmt.Errorf("PostMessageW: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Package i2cscan 透過 GPU 驅動的 I2C-over-AUX 通道探測從屬位址，
+// 找出面板上有回應的 EEPROM、DDC/CI 與 TCON 等裝置。
+package i2cscan
+
+import (
+	"fmt"
+	"strings"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// 7-bit 位址中 0x00~0x07 與 0x78~0x7F 為 I2C 保留位址，預設不掃描。
+const (
+	DefaultStart = 0x08
+	DefaultEnd   = 0x77
+)
+
+// dumpChunk 為傾印時單次讀取的位元組數，對應單一 AUX 交易的上限。
+const dumpChunk = 16
+
+// KnownDevices 為常見的從屬位址與用途；TCON 位址取自 TCONAUX 應用說明。
+var KnownDevices = map[byte]string{
+	0x30: "E-DDC segment pointer",
+	0x37: "DDC/CI (MCCS)",
+	0x4F: "Novatek DVCOM / VCOM DAC",
+	0x50: "EDID EEPROM",
+	0x51: "EDID EEPROM (second bank)",
+	0x60: "Novatek TCON status",
+	0x62: "Novatek TCON flash / ACC",
+	0x64: "Novatek TCON EE_I2C control",
+}
+
+// Options 控制掃描範圍與是否傾印內容。
+type Options struct {
+	Start    byte
+	End      byte
+	Dump     bool            // 對有回應的裝置讀取前 256 位元組
+	Progress func(addr byte) // 每探測一個位址前呼叫，可為 nil
+}
+
+// Device 為有回應的從屬位址。
+type Device struct {
+	Address byte
+	Name    string // 已知用途，未知時為空字串
+	Dump    []byte
+	DumpErr error
+}
+
+// String 以單行文字描述裝置。
+func (d Device) String() string {
+	name := d.Name
+	if name == "" {
+		name = "unknown"
+	}
+	return fmt.Sprintf("0x%02X  %s", d.Address, name)
+}
+
+// Scan 依序以 1 位元組讀取探測每個位址，讀取成功即視為有回應。
+// 探測只會發出讀取，但仍可能觸發少數裝置的讀取副作用。
+func Scan(driver gpu.Driver, opts Options) ([]Device, error) {
+	if driver == nil {
+		return nil, gpu.ErrNoDriver
+	}
+	if opts.Start == 0 && opts.End == 0 {
+		opts.Start, opts.End = DefaultStart, DefaultEnd
+	}
+	if opts.End > 0x7F || opts.Start > opts.End {
+		return nil, fmt.Errorf("i2cscan: invalid range 0x%02X-0x%02X", opts.Start, opts.End)
+	}
+
+	// 沒有裝置的位址常以逾時回應，探測時不重試以免掃描變慢；傾印仍使用原驅動。
+	probe := gpu.WithoutRetry(driver)
+	var devices []Device
+	for addr := int(opts.Start); addr <= int(opts.End); addr++ {
+		if opts.Progress != nil {
+			opts.Progress(byte(addr))
+		}
+		if _, err := probe.ReadI2C(uint32(addr), 1); err != nil {
+			continue
+		}
+		device := Device{Address: byte(addr), Name: KnownDevices[byte(addr)]}
+		if opts.Dump {
+			device.Dump, device.DumpErr = Dump(driver, byte(addr))
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// Dump 讀取從屬位址暫存器 0x00~0xFF 的內容。
+func Dump(driver gpu.Driver, addr byte) ([]byte, error) {
+	data := make([]byte, 0, 256)
+	for reg := 0; reg < 256; reg += dumpChunk {
+		chunk, err := driver.ReadI2C(uint32(addr)|uint32(reg)<<8, dumpChunk)
+		if err != nil {
+			return data, fmt.Errorf("i2cscan: read 0x%02X reg 0x%02X: %w", addr, reg, err)
+		}
+		data = append(data, chunk...)
+	}
+	return data, nil
+}
+
+// FormatDump 以每列 16 位元組的十六進位與 ASCII 呈現傾印內容。
+func FormatDump(data []byte) string {
+	var b strings.Builder
+	for offset := 0; offset < len(data); offset += 16 {
+		end := offset + 16
+		if end > len(data) {
+			end = len(data)
+		}
+		row := data[offset:end]
+		fmt.Fprintf(&b, "%02X: %-47s  ", offset, fmt.Sprintf("% X", row))
+		for _, c := range row {
+			if c < 0x20 || c > 0x7E {
+				c = '.'
+			}
+			b.WriteByte(c)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
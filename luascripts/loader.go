@@ -21,6 +21,8 @@ type Script struct {
 type RuntimeOptions struct {
 	Functions map[string]lua.LGFunction
 	Globals   map[string]interface{}
+	// Modules 以 table 形式注入的函式群組，例如 dpcd.decode。
+	Modules map[string]map[string]lua.LGFunction
 }
 
 // ListScripts 掃描指定資料夾內的 .lua 檔案，並回傳排序後的腳本清單。
@@ -70,6 +72,11 @@ func ExecuteScript(path string, opts RuntimeOptions) ([]lua.LValue, error) {
 		L.SetGlobal(name, L.NewFunction(fn))
 	}
 
+	for name, functions := range opts.Modules {
+		// 將同一模組的函式收進同名 table，避免污染全域命名空間。
+		L.SetGlobal(name, L.SetFuncs(L.NewTable(), functions))
+	}
+
 	for name, value := range opts.Globals {
 		// 把預設變數寫入 Lua 環境，讓腳本可直接使用。
 		L.SetGlobal(name, toLValue(L, value))
@@ -191,3 +198,55 @@ func toLValue(L *lua.LState, v interface{}) lua.LValue {
 		}
 	}
 }
+
+// ToLua 將 Go 值轉換成 Lua 值，規則與注入 Globals 時相同。
+func ToLua(L *lua.LState, v interface{}) lua.LValue {
+	return toLValue(L, v)
+}
+
+// ToGo 將 Lua 值轉換成可序列化為 JSON 的 Go 值：連續索引的 table 轉成 slice，
+// 其他 table 轉成以字串為鍵的 map；函式等無法表示的型態以字串呈現。
+func ToGo(value lua.LValue) interface{} {
+	return toGo(value, 0)
+}
+
+// maxToGoDepth 限制巢狀 table 的轉換深度，避免自我參照造成無窮遞迴。
+const maxToGoDepth = 32
+
+func toGo(value lua.LValue, depth int) interface{} {
+	switch v := value.(type) {
+	case *lua.LNilType:
+		return nil
+	case lua.LBool:
+		return bool(v)
+	case lua.LNumber:
+		f := float64(v)
+		if f == float64(int64(f)) {
+			// 整數以 int64 輸出，避免 JSON 出現 1e+06 之類的表示法。
+			return int64(f)
+		}
+		return f
+	case lua.LString:
+		return string(v)
+	case *lua.LTable:
+		if depth >= maxToGoDepth {
+			return nil
+		}
+		count := 0
+		v.ForEach(func(lua.LValue, lua.LValue) { count++ })
+		if n := v.Len(); n > 0 && v.MaxN() == n && count == n {
+			list := make([]interface{}, n)
+			for i := 1; i <= n; i++ {
+				list[i-1] = toGo(v.RawGetInt(i), depth+1)
+			}
+			return list
+		}
+		m := map[string]interface{}{}
+		v.ForEach(func(key, item lua.LValue) {
+			m[key.String()] = toGo(item, depth+1)
+		})
+		return m
+	default:
+		return value.String()
+	}
+}
@@ -1,14 +1,76 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"io/fs"
 	"log"
+	"os"
 
+	"GMTAUXOneKeyBuild/config"
+	"GMTAUXOneKeyBuild/edidhelper"
+	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/remote"
+	display "GMTAUXOneKeyBuild/struct"
 	"GMTAUXOneKeyBuild/ui"
 )
 
 // main 是應用程式的進入點，負責啟動文字介面應用程式。
 func main() {
+	dryRun := flag.Bool("dry-run", false, "record script and recipe writes instead of sending them to hardware")
+	snapshot := flag.String("snapshot", "", "DPCD/I2C snapshot JSON used to serve reads in dry-run mode")
+	serve := flag.String("serve", "", "serve the local GPU driver to remote clients on this address (e.g. :7420) instead of starting the UI")
+	serveDriver := flag.String("serve-driver", "", "driver name to serve (intel, intel-igfx, intel-igcl, nvidia, drm-aux); empty detects automatically")
+	remoteAddr := flag.String("remote", "", "forward all hardware access to a bench PC started with -serve (host[:port])")
+	apiAddr := flag.String("api", "", "start the local HTTP JSON API on this address (e.g. 127.0.0.1:8080) alongside the UI")
+	exportJSON := flag.String("export-json", "", "write detected displays, raw EDID and parse warnings as JSON to this file (- for stdout) and exit")
+	hotplugEnabled := flag.Bool("hotplug", true, "watch for display arrival and removal and refresh the display list automatically")
+	hotplugScript := flag.String("hotplug-script", "", "Lua script (name in the scripts directory) to run when a new panel is detected")
+	configPath := flag.String("config", config.DefaultPath, "JSON settings file (directories, drivers, delays, timeouts, logs, menu language, key bindings)")
+	token := flag.String("token", os.Getenv("GMTAUX_REMOTE_TOKEN"), "shared token for -serve, -remote and -api (default $GMTAUX_REMOTE_TOKEN)")
+	flag.Parse()
+
+	if *exportJSON != "" || *serve != "" {
+		cfg, err := config.Load(*configPath)
+		if err != nil {
+			log.Fatalf("failed to load config: %v", err)
+		}
+		gpu.IntelCUIDelay = config.Millis(cfg.AUX.IntelCUIDelayMS)
+		if *exportJSON != "" {
+			runExport(*exportJSON, cfg.PanelsFile)
+		} else {
+			runServer(*serve, *serveDriver, *token)
+		}
+		return
+	}
+
 	app := ui.NewApp()
+	if *configPath != config.DefaultPath {
+		if err := app.LoadConfig(*configPath); err != nil {
+			log.Fatalf("failed to load config: %v", err)
+		}
+	}
+	app.SetDryRun(*dryRun, *snapshot)
+
+	// 命令列明確指定的熱插拔參數優先於設定檔。
+	hotplug := app.Config().Hotplug
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "hotplug":
+			hotplug.Enabled = *hotplugEnabled
+		case "hotplug-script":
+			hotplug.Script = *hotplugScript
+		}
+	})
+	app.SetHotplug(hotplug.Enabled, hotplug.Script)
+	if *remoteAddr != "" {
+		app.SetRemote(*remoteAddr, *token)
+	}
+	if *apiAddr != "" {
+		if err := app.StartAPI(*apiAddr, *token); err != nil {
+			log.Fatalf("failed to start API: %v", err)
+		}
+	}
 
 	// 當使用者於主選單選擇「切換至螢幕列表」時，執行自訂行為。
 	app.SetSwitchToDisplayListHandler(func(app *ui.App) {
@@ -21,3 +83,51 @@ func main() {
 		log.Fatalf("failed to run application: %v", err)
 	}
 }
+
+// runServer 以無介面模式將本機驅動公開給遠端客戶端；未提供權杖時隨機產生。
+func runServer(addr, driverName, token string) {
+	driver, err := gpu.DetectByName(driverName)
+	if err != nil {
+		log.Fatalf("failed to detect GPU driver: %v", err)
+	}
+	if token == "" {
+		token = generateToken()
+	}
+
+	server := remote.NewServer(driver, token)
+	server.Logf = log.Printf
+	log.Printf("serving %s on %s", driver.Name(), addr)
+	if err := server.ListenAndServe(addr); err != nil {
+		log.Fatalf("remote server stopped: %v", err)
+	}
+}
+
+// generateToken 產生隨機權杖並記錄於日誌，失敗時中止。
+func generateToken() string {
+	token, err := remote.GenerateToken()
+	if err != nil {
+		log.Fatalf("failed to generate token: %v", err)
+	}
+	log.Printf("generated token: %s", token)
+	return token
+}
+
+// runExport 列舉顯示器並輸出 JSON；完全沒有顯示器時以非零狀態結束。
+func runExport(path, panelsPath string) {
+	if err := display.LoadPanelModels(panelsPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("failed to load panel models: %v", err)
+	}
+	displays, enumErr := edidhelper.GetScreens()
+	data, err := display.MarshalDisplays(displays, enumErr)
+	if err != nil {
+		log.Fatalf("failed to encode displays: %v", err)
+	}
+	if path == "-" {
+		os.Stdout.Write(data)
+	} else if err := os.WriteFile(path, data, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", path, err)
+	}
+	if len(displays) == 0 {
+		log.Fatalf("no displays exported: %v", enumErr)
+	}
+}
@@ -0,0 +1,475 @@
+package recipe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// ErrMismatch 表示讀回資料與預期不符。
+var ErrMismatch = errors.New("recipe: readback mismatch")
+
+// StepStatus 表示步驟的執行結果。
+type StepStatus string
+
+const (
+	StatusOK      StepStatus = "ok"
+	StatusFailed  StepStatus = "failed"
+	StatusSkipped StepStatus = "skipped"
+)
+
+// RollbackStatus 表示步驟復原操作的結果；未執行復原時為空字串。
+type RollbackStatus string
+
+const (
+	RollbackDone   RollbackStatus = "done"
+	RollbackFailed RollbackStatus = "failed"
+)
+
+// StepResult 紀錄單一步驟的執行過程。
+type StepResult struct {
+	Index    int        `json:"index"`
+	Name     string     `json:"name"`
+	Action   Action     `json:"action"`
+	Status   StepStatus `json:"status"`
+	Attempts int        `json:"attempts"`
+	Error    string     `json:"error,omitempty"`
+	Duration string     `json:"duration"`
+	Log      []string   `json:"log,omitempty"`
+	// Rollback 與 RollbackError 另外記錄復原結果，Status 維持步驟本身的結果。
+	Rollback      RollbackStatus `json:"rollback,omitempty"`
+	RollbackError string         `json:"rollback_error,omitempty"`
+}
+
+// Report 為整份配方的統一執行報告，可序列化成 JSON 存檔。
+type Report struct {
+	Recipe        string       `json:"recipe"`
+	Driver        string       `json:"driver"`
+	Started       time.Time    `json:"started"`
+	Finished      time.Time    `json:"finished"`
+	Success       bool         `json:"success"`
+	LastCompleted int          `json:"last_completed"` // 最後完成的步驟序號（1 起始），可作為續跑的檢查點
+	Steps         []StepResult `json:"steps"`
+	DryRun        bool         `json:"dry_run,omitempty"`
+	Writes        []string     `json:"writes,omitempty"` // dry-run 時攔截到的寫入，依發生順序排列
+}
+
+// String 以逐行文字呈現報告內容。
+func (r *Report) String() string {
+	var b strings.Builder
+	result := "FAILED"
+	if r.Success {
+		result = "OK"
+	}
+	fmt.Fprintf(&b, "%s [%s] via %s (%s)\n", r.Recipe, result, r.Driver, r.Finished.Sub(r.Started).Round(time.Millisecond))
+	for _, step := range r.Steps {
+		fmt.Fprintf(&b, "%2d. %-12s %-24s %s", step.Index, step.Status, step.Name, step.Duration)
+		if step.Attempts > 1 {
+			fmt.Fprintf(&b, " (attempts %d)", step.Attempts)
+		}
+		if step.Error != "" {
+			fmt.Fprintf(&b, " - %s", step.Error)
+		}
+		if step.Rollback != "" {
+			fmt.Fprintf(&b, " [rollback %s", step.Rollback)
+			if step.RollbackError != "" {
+				fmt.Fprintf(&b, ": %s", step.RollbackError)
+			}
+			b.WriteByte(']')
+		}
+		b.WriteByte('\n')
+	}
+	if r.DryRun {
+		fmt.Fprintf(&b, "dry-run: %d write(s) recorded, hardware untouched\n", len(r.Writes))
+		for i, w := range r.Writes {
+			fmt.Fprintf(&b, "%3d. %s\n", i+1, w)
+		}
+	}
+	return b.String()
+}
+
+// Save 將報告以 JSON 寫入檔案。
+func (r *Report) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// FileName 回傳報告的存檔名稱：<配方檔名>_<開始時間>.report.json，dry-run 時為 .dryrun.report.json。
+func (r *Report) FileName(recipePath string) string {
+	suffix := "report"
+	if r.DryRun {
+		suffix = "dryrun.report"
+	}
+	return fmt.Sprintf("%s_%s.%s.json",
+		strings.TrimSuffix(filepath.Base(recipePath), filepath.Ext(recipePath)),
+		r.Started.Format("20060102_150405"), suffix)
+}
+
+// Executor 依序對指定的 GPU 驅動執行配方步驟。
+type Executor struct {
+	Driver gpu.Driver
+	// Vars 提供 ${name} 範本使用的變數，例如 serial 或 vcom。
+	Vars map[string]string
+	// StartAt 指定從第幾個步驟開始（1 起始），用於由檢查點續跑。
+	StartAt int
+	// Logf 若不為 nil，每個步驟的進度都會同步輸出。
+	Logf func(format string, args ...interface{})
+
+	sleep func(time.Duration)
+}
+
+// NewExecutor 建立執行器。
+func NewExecutor(driver gpu.Driver) *Executor {
+	return &Executor{Driver: driver, Vars: map[string]string{}, sleep: time.Sleep}
+}
+
+// Run 執行整份配方並回傳報告；任一步驟最終失敗時停止並回傳錯誤。
+func (e *Executor) Run(r *Recipe) (*Report, error) {
+	if e.Driver == nil {
+		return nil, gpu.ErrNoDriver
+	}
+	if e.sleep == nil {
+		e.sleep = time.Sleep
+	}
+
+	report := &Report{Recipe: r.Name, Driver: e.Driver.Name(), Started: time.Now()}
+	dryRun, _ := e.Driver.(*gpu.DryRunDriver)
+	if dryRun != nil {
+		// dry-run 不觸及硬體，等待時間沒有意義，直接略過。
+		report.DryRun = true
+		sleep := e.sleep
+		e.sleep = func(time.Duration) {}
+		defer func() { e.sleep = sleep }()
+	}
+	start := e.StartAt
+	if start < 1 {
+		start = 1
+	}
+
+	var runErr error
+	for i, step := range r.Steps {
+		result := StepResult{Index: i + 1, Name: step.Name, Action: step.Action}
+		if result.Name == "" {
+			result.Name = string(step.Action)
+		}
+		if i+1 < start {
+			result.Status = StatusSkipped
+			result.Duration = "0s"
+			report.Steps = append(report.Steps, result)
+			continue
+		}
+
+		e.logf("[%d/%d] %s", i+1, len(r.Steps), result.Name)
+		began := time.Now()
+		err := e.runWithRetry(r, step, &result)
+		result.Duration = time.Since(began).Round(time.Millisecond).String()
+		if err == nil {
+			result.Status = StatusOK
+			report.LastCompleted = i + 1
+			report.Steps = append(report.Steps, result)
+			continue
+		}
+
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		e.logf("[%d/%d] %s 失敗: %v", i+1, len(r.Steps), result.Name, err)
+		report.Steps = append(report.Steps, result)
+		e.rollback(r, i, report)
+		runErr = fmt.Errorf("step %d (%s): %w", i+1, result.Name, err)
+		break
+	}
+
+	if dryRun != nil {
+		for _, w := range dryRun.Writes() {
+			report.Writes = append(report.Writes, w.String())
+		}
+	}
+	report.Finished = time.Now()
+	report.Success = runErr == nil
+	return report, runErr
+}
+
+// runWithRetry 依步驟的重試策略重複執行。
+func (e *Executor) runWithRetry(r *Recipe, step Step, result *StepResult) error {
+	attempts := step.Retry.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		result.Attempts = attempt
+		if err = e.runStep(r, step, result); err == nil {
+			return nil
+		}
+		if attempt < attempts {
+			result.Log = append(result.Log, fmt.Sprintf("attempt %d failed: %v", attempt, err))
+			e.logf("  重試 %d/%d: %v", attempt, attempts-1, err)
+			e.sleep(time.Duration(step.Retry.DelayMS) * time.Millisecond)
+		}
+	}
+	return err
+}
+
+// rollback 依失敗步驟的策略執行復原操作。
+func (e *Executor) rollback(r *Recipe, failed int, report *Report) {
+	policy := r.Steps[failed].Rollback
+	if policy.Mode == RollbackNone {
+		return
+	}
+
+	indexes := []int{failed}
+	if policy.Mode == RollbackAll {
+		for i := failed - 1; i >= 0; i-- {
+			if report.Steps[i].Status == StatusOK {
+				indexes = append(indexes, i)
+			}
+		}
+	}
+
+	for _, i := range indexes {
+		ops := r.Steps[i].Rollback.Ops
+		if len(ops) == 0 {
+			continue
+		}
+		result := &report.Steps[i]
+		e.logf("  復原步驟 %d (%s)", i+1, result.Name)
+		if err := e.runOps(ops, result); err != nil {
+			result.Rollback = RollbackFailed
+			result.RollbackError = err.Error()
+			e.logf("  復原步驟 %d 失敗: %v", i+1, err)
+			continue
+		}
+		result.Rollback = RollbackDone
+	}
+}
+
+// runStep 執行單一步驟一次。
+func (e *Executor) runStep(r *Recipe, step Step, result *StepResult) error {
+	switch step.Action {
+	case ActionDetect, ActionUnlock, ActionWrite, ActionVerify, ActionLock:
+		return e.runOps(step.Ops, result)
+	case ActionDelay:
+		e.sleep(time.Duration(step.DelayMS) * time.Millisecond)
+		return nil
+	case ActionSetVCOM:
+		return e.setVCOM(step, result)
+	case ActionWriteSerial:
+		return e.writeSerial(step, result)
+	case ActionProgramEDID:
+		return e.programEDID(r, step, result)
+	default:
+		return fmt.Errorf("unknown action %q", step.Action)
+	}
+}
+
+// runOps 依序執行一組操作，讀取操作會比對 Expect/Mask。
+func (e *Executor) runOps(ops []Operation, result *StepResult) error {
+	for _, op := range ops {
+		if err := e.runOp(op, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Executor) runOp(op Operation, result *StepResult) error {
+	result.Log = append(result.Log, op.String())
+	if op.IsWrite() {
+		if err := e.write(op, op.Data); err != nil {
+			return err
+		}
+	} else {
+		data, err := e.read(op, op.readLength())
+		if err != nil {
+			return err
+		}
+		result.Log = append(result.Log, fmt.Sprintf("  => % X", data))
+		if err := compare(data, op.Expect, op.Mask); err != nil {
+			return err
+		}
+	}
+	if op.DelayMS > 0 {
+		e.sleep(time.Duration(op.DelayMS) * time.Millisecond)
+	}
+	return nil
+}
+
+func (e *Executor) write(op Operation, data []byte) error {
+	if op.Bus == BusI2C {
+		return e.Driver.WriteI2C(op.driverAddress(), data)
+	}
+	return e.Driver.WriteDPCD(op.driverAddress(), data)
+}
+
+func (e *Executor) read(op Operation, length int) ([]byte, error) {
+	if op.Bus == BusI2C {
+		return e.Driver.ReadI2C(op.driverAddress(), uint32(length))
+	}
+	return e.Driver.ReadDPCD(op.driverAddress(), uint32(length))
+}
+
+// compare 以遮罩比對讀回資料；未指定 expect 時視為通過。
+func compare(actual, expect, mask []byte) error {
+	if len(expect) == 0 {
+		return nil
+	}
+	if len(actual) < len(expect) {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrMismatch, len(actual), len(expect))
+	}
+	for i := range expect {
+		m := byte(0xFF)
+		if len(mask) > 0 {
+			m = mask[i]
+		}
+		if actual[i]&m != expect[i]&m {
+			return fmt.Errorf("%w at byte %d: got 0x%02X, want 0x%02X", ErrMismatch, i, actual[i], expect[i])
+		}
+	}
+	return nil
+}
+
+// setVCOM 將數值依 Width 以 big-endian 寫入目標並讀回確認。
+func (e *Executor) setVCOM(step Step, result *StepResult) error {
+	text, err := expand(step.Value, e.Vars)
+	if err != nil {
+		return err
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(text), 0, 32)
+	if err != nil {
+		return fmt.Errorf("invalid vcom value %q", text)
+	}
+	width := step.Width
+	if width <= 0 {
+		width = 1
+	}
+	if width < 4 && value >= 1<<(8*uint(width)) {
+		return fmt.Errorf("vcom value 0x%X exceeds %d byte(s)", value, width)
+	}
+	data := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		data[i] = byte(value)
+		value >>= 8
+	}
+	return e.writeAndVerify(*step.Target, data, result)
+}
+
+// writeSerial 將序號字串以 ASCII 寫入，Width 指定固定長度（不足補 0x00）。
+func (e *Executor) writeSerial(step Step, result *StepResult) error {
+	serial, err := expand(step.Value, e.Vars)
+	if err != nil {
+		return err
+	}
+	data := []byte(serial)
+	if step.Width > 0 {
+		if len(data) > step.Width {
+			return fmt.Errorf("serial %q exceeds %d bytes", serial, step.Width)
+		}
+		padded := make([]byte, step.Width)
+		copy(padded, data)
+		data = padded
+	}
+	if len(data) == 0 {
+		return errors.New("serial is empty")
+	}
+	return e.writeAndVerify(*step.Target, data, result)
+}
+
+// programEDID 將 EDID 檔案分段寫入 EEPROM 並讀回比對。
+func (e *Executor) programEDID(r *Recipe, step Step, result *StepResult) error {
+	path := step.File
+	if !filepath.IsAbs(path) && r.Path != "" {
+		path = filepath.Join(filepath.Dir(r.Path), path)
+	}
+	edid, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if len(edid) == 0 || len(edid)%128 != 0 {
+		return fmt.Errorf("edid file %s has invalid length %d", filepath.Base(path), len(edid))
+	}
+	if len(edid) > 256 {
+		return fmt.Errorf("edid file %s exceeds 256 bytes", filepath.Base(path))
+	}
+
+	target := Operation{Bus: BusI2C, Address: 0x50}
+	if step.Target != nil {
+		target = *step.Target
+	}
+	base := uint32(0)
+	if target.Register != nil {
+		base = uint32(*target.Register)
+	}
+	chunk := step.Chunk
+	if chunk <= 0 {
+		chunk = 8
+	}
+
+	result.Log = append(result.Log, fmt.Sprintf("program %d bytes from %s", len(edid), filepath.Base(path)))
+	for offset := 0; offset < len(edid); offset += chunk {
+		end := offset + chunk
+		if end > len(edid) {
+			end = len(edid)
+		}
+		op := target
+		reg := Number(base + uint32(offset))
+		op.Register = &reg
+		if err := e.write(op, edid[offset:end]); err != nil {
+			return fmt.Errorf("write offset 0x%02X: %w", offset, err)
+		}
+		// EEPROM 寫入週期需要等待，預設 10ms。
+		delay := step.DelayMS
+		if delay <= 0 {
+			delay = 10
+		}
+		e.sleep(time.Duration(delay) * time.Millisecond)
+	}
+
+	reg := Number(base)
+	target.Register = &reg
+	actual, err := e.read(target, len(edid))
+	if err != nil {
+		return fmt.Errorf("read back edid: %w", err)
+	}
+	for i := range edid {
+		if i >= len(actual) || actual[i] != edid[i] {
+			return fmt.Errorf("%w at edid offset 0x%02X", ErrMismatch, i)
+		}
+	}
+	result.Log = append(result.Log, "edid verified")
+	return nil
+}
+
+// writeAndVerify 寫入後以相同位址讀回比對。
+func (e *Executor) writeAndVerify(target Operation, data []byte, result *StepResult) error {
+	op := target
+	op.Data = data
+	result.Log = append(result.Log, op.String())
+	if err := e.write(op, data); err != nil {
+		return err
+	}
+	if target.DelayMS > 0 {
+		e.sleep(time.Duration(target.DelayMS) * time.Millisecond)
+	}
+	actual, err := e.read(target, len(data))
+	if err != nil {
+		return fmt.Errorf("read back: %w", err)
+	}
+	result.Log = append(result.Log, fmt.Sprintf("  => % X", actual))
+	return compare(actual, data, nil)
+}
+
+func (e *Executor) logf(format string, args ...interface{}) {
+	if e.Logf != nil {
+		e.Logf(format, args...)
+	}
+}
@@ -0,0 +1,217 @@
+package recipe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// parseRecipe 解析並驗證測試用的配方 JSON。
+func parseRecipe(t *testing.T, text string) *Recipe {
+	t.Helper()
+	var r Recipe
+	if err := json.Unmarshal([]byte(text), &r); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return &r
+}
+
+// flakyDriver 讓前 failures 次 DPCD 讀取回傳 NACK，之後交給內含的驅動。
+type flakyDriver struct {
+	gpu.Driver
+	failures int
+	reads    int
+}
+
+func (d *flakyDriver) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
+	d.reads++
+	if d.reads <= d.failures {
+		return nil, gpu.ErrNACK
+	}
+	return d.Driver.ReadDPCD(addr, length)
+}
+
+// newTestExecutor 建立以 driver 執行、並記錄等待時間而不實際等待的執行器。
+func newTestExecutor(driver gpu.Driver) (*Executor, *[]time.Duration) {
+	var sleeps []time.Duration
+	e := NewExecutor(driver)
+	e.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return e, &sleeps
+}
+
+func TestExecutorRetry(t *testing.T) {
+	r := parseRecipe(t, `{"name": "retry", "steps": [
+		{"name": "detect", "action": "detect",
+		 "ops": [{"bus": "dpcd", "address": "0x00000", "expect": "14"}],
+		 "retry": {"attempts": 3, "delay_ms": 25}}
+	]}`)
+
+	tests := []struct {
+		name         string
+		failures     int
+		wantErr      error
+		wantStatus   StepStatus
+		wantAttempts int
+		wantSleeps   int
+	}{
+		{name: "first try", failures: 0, wantStatus: StatusOK, wantAttempts: 1},
+		{name: "succeeds within budget", failures: 2, wantStatus: StatusOK, wantAttempts: 3, wantSleeps: 2},
+		{name: "budget exhausted", failures: 3, wantErr: gpu.ErrNACK, wantStatus: StatusFailed, wantAttempts: 3, wantSleeps: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := gpu.NewSimDriver()
+			sim.WriteDPCD(0x000, []byte{0x14})
+			e, sleeps := newTestExecutor(&flakyDriver{Driver: sim, failures: tt.failures})
+
+			report, err := e.Run(r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run error = %v, want %v", err, tt.wantErr)
+			}
+			step := report.Steps[0]
+			if step.Status != tt.wantStatus || step.Attempts != tt.wantAttempts {
+				t.Errorf("step = %s after %d attempts, want %s after %d", step.Status, step.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if len(*sleeps) != tt.wantSleeps {
+				t.Errorf("slept %d times, want %d", len(*sleeps), tt.wantSleeps)
+			}
+			for _, d := range *sleeps {
+				if d != 25*time.Millisecond {
+					t.Errorf("retry delay = %s, want 25ms", d)
+				}
+			}
+			if report.Success != (tt.wantErr == nil) {
+				t.Errorf("Success = %v", report.Success)
+			}
+		})
+	}
+}
+
+func TestExecutorRollback(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   RollbackMode
+		undo   string // 失敗步驟的復原操作
+		want   []StepResult
+		wantAt map[uint32]byte // 執行後的 DPCD 內容
+	}{
+		{
+			name: "step",
+			mode: RollbackStep,
+			undo: `{"bus": "dpcd", "address": "0x00102", "data": "00"}`,
+			want: []StepResult{
+				{Status: StatusOK},
+				{Status: StatusFailed, Rollback: RollbackDone},
+			},
+			wantAt: map[uint32]byte{0x100: 0x0A, 0x102: 0x00},
+		},
+		{
+			name: "all",
+			mode: RollbackAll,
+			undo: `{"bus": "dpcd", "address": "0x00102", "data": "00"}`,
+			want: []StepResult{
+				{Status: StatusOK, Rollback: RollbackDone},
+				{Status: StatusFailed, Rollback: RollbackDone},
+			},
+			wantAt: map[uint32]byte{0x100: 0x06, 0x102: 0x00},
+		},
+		{
+			name: "rollback failure",
+			mode: RollbackStep,
+			undo: `{"bus": "dpcd", "address": "0x00102", "expect": "FF"}`,
+			want: []StepResult{
+				{Status: StatusOK},
+				{Status: StatusFailed, Rollback: RollbackFailed, RollbackError: "recipe: readback mismatch at byte 0: got 0x21, want 0xFF"},
+			},
+			wantAt: map[uint32]byte{0x100: 0x0A, 0x102: 0x21},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := parseRecipe(t, `{"name": "rollback", "steps": [
+				{"name": "link rate", "action": "write",
+				 "ops": [{"bus": "dpcd", "address": "0x00100", "data": "0A"}],
+				 "rollback": {"ops": [{"bus": "dpcd", "address": "0x00100", "data": "06"}]}},
+				{"name": "training", "action": "write",
+				 "ops": [{"bus": "dpcd", "address": "0x00102", "data": "21"},
+				         {"bus": "dpcd", "address": "0x00202", "expect": "77"}],
+				 "rollback": {"mode": "`+string(tt.mode)+`", "ops": [`+tt.undo+`]}}
+			]}`)
+			sim := gpu.NewSimDriver()
+			e, _ := newTestExecutor(sim)
+
+			report, err := e.Run(r)
+			if !errors.Is(err, ErrMismatch) {
+				t.Fatalf("Run error = %v, want ErrMismatch", err)
+			}
+			if report.Success || report.LastCompleted != 1 {
+				t.Errorf("Success = %v, LastCompleted = %d", report.Success, report.LastCompleted)
+			}
+			if len(report.Steps) != len(tt.want) {
+				t.Fatalf("got %d steps, want %d", len(report.Steps), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := report.Steps[i]
+				if got.Status != want.Status || got.Rollback != want.Rollback || got.RollbackError != want.RollbackError {
+					t.Errorf("step %d = {%s %q %q}, want {%s %q %q}", i+1,
+						got.Status, got.Rollback, got.RollbackError, want.Status, want.Rollback, want.RollbackError)
+				}
+			}
+			if report.Steps[1].Error == "" {
+				t.Error("failed step lost its own error")
+			}
+			for addr, want := range tt.wantAt {
+				got, err := sim.ReadDPCD(addr, 1)
+				if err != nil || got[0] != want {
+					t.Errorf("DPCD 0x%05X = % X, want %02X", addr, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExecutorDryRun(t *testing.T) {
+	r := parseRecipe(t, `{"name": "dry", "steps": [
+		{"name": "unlock", "action": "unlock",
+		 "ops": [{"bus": "i2c", "address": "0x64", "register": "0x04", "data": "80", "delay_ms": 100}]},
+		{"name": "wait", "action": "delay", "delay_ms": 500},
+		{"name": "vcom", "action": "write",
+		 "ops": [{"bus": "dpcd", "address": "0x00100", "data": "1E 84"}]}
+	]}`)
+	dryRun := gpu.NewDryRunDriver(gpu.NewSimDriver())
+	e, sleeps := newTestExecutor(dryRun)
+
+	report, err := e.Run(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun {
+		t.Error("report not marked as dry-run")
+	}
+	if len(*sleeps) != 0 {
+		t.Errorf("dry run slept %v", *sleeps)
+	}
+	if len(report.Writes) != 2 {
+		t.Fatalf("recorded %d writes, want 2: %v", len(report.Writes), report.Writes)
+	}
+	writes := dryRun.Writes()
+	if writes[0].Bus != "i2c" || writes[0].Address != 0x64|0x04<<8 || !bytes.Equal(writes[0].Data, []byte{0x80}) {
+		t.Errorf("first write = %+v", writes[0])
+	}
+	if writes[1].Bus != "dpcd" || writes[1].Address != 0x100 || !bytes.Equal(writes[1].Data, []byte{0x1E, 0x84}) {
+		t.Errorf("second write = %+v", writes[1])
+	}
+
+	// dry-run 結束後恢復執行器原本的等待函式。
+	e.sleep(time.Millisecond)
+	if len(*sleeps) != 1 {
+		t.Error("executor sleep not restored after dry run")
+	}
+}
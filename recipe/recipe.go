@@ -0,0 +1,363 @@
+package recipe
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Action 表示配方步驟的動作種類。
+type Action string
+
+const (
+	ActionDetect      Action = "detect"       // 讀取並比對暫存器以確認 TCON 型號
+	ActionUnlock      Action = "unlock"       // 送出解鎖序列
+	ActionWrite       Action = "write"        // 一般暫存器寫入
+	ActionProgramEDID Action = "program_edid" // 將 EDID 檔案寫入 EEPROM
+	ActionSetVCOM     Action = "set_vcom"     // 寫入 VCOM 數值
+	ActionWriteSerial Action = "write_serial" // 寫入序號字串
+	ActionVerify      Action = "verify"       // 讀回並比對
+	ActionLock        Action = "lock"         // 送出上鎖序列
+	ActionDelay       Action = "delay"        // 單純等待
+)
+
+// Bus 表示操作使用的通道。
+type Bus string
+
+const (
+	BusDPCD Bus = "dpcd"
+	BusI2C  Bus = "i2c"
+)
+
+// Number 可由 JSON 數字或 "0x62" 形式的字串解析。
+type Number uint32
+
+// UnmarshalJSON 同時接受數字與十六進位字串。
+func (n *Number) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		v, err := strconv.ParseUint(strings.TrimSpace(text), 0, 32)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		*n = Number(v)
+		return nil
+	}
+	var v uint32
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = Number(v)
+	return nil
+}
+
+// MarshalJSON 以十六進位字串輸出，方便閱讀。
+func (n Number) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("0x%X", uint32(n)))
+}
+
+// Bytes 可由數字陣列或 "FF 3C C3" 形式的十六進位字串解析。
+type Bytes []byte
+
+// UnmarshalJSON 同時接受數字陣列與十六進位字串。
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		cleaned := strings.NewReplacer(" ", "", ",", "", "0x", "", "0X", "").Replace(text)
+		decoded, err := hex.DecodeString(cleaned)
+		if err != nil {
+			return fmt.Errorf("invalid hex bytes %q", text)
+		}
+		*b = decoded
+		return nil
+	}
+	var values []Number
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	out := make([]byte, len(values))
+	for i, v := range values {
+		if v > 0xFF {
+			return fmt.Errorf("byte %d value 0x%X out of range", i, uint32(v))
+		}
+		out[i] = byte(v)
+	}
+	*b = out
+	return nil
+}
+
+// MarshalJSON 以空白分隔的十六進位字串輸出。
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	parts := make([]string, len(b))
+	for i, v := range b {
+		parts[i] = fmt.Sprintf("%02X", v)
+	}
+	return json.Marshal(strings.Join(parts, " "))
+}
+
+// Operation 描述一次 DPCD 或 I2C 存取；有 Data 時寫入，否則依 Length 讀取並比對 Expect。
+type Operation struct {
+	Bus      Bus     `json:"bus"`
+	Address  Number  `json:"address"`            // DPCD 位址或 I2C 7-bit 從屬位址
+	Register *Number `json:"register,omitempty"` // I2C 暫存器位址
+	Data     Bytes   `json:"data,omitempty"`
+	Length   int     `json:"length,omitempty"`
+	Expect   Bytes   `json:"expect,omitempty"`
+	Mask     Bytes   `json:"mask,omitempty"`
+	DelayMS  int     `json:"delay_ms,omitempty"` // 操作完成後的等待時間
+}
+
+// IsWrite 回傳此操作是否為寫入。
+func (op Operation) IsWrite() bool {
+	return len(op.Data) > 0
+}
+
+// driverAddress 依 read_i2c/write_i2c 的慣例組合驅動使用的位址。
+func (op Operation) driverAddress() uint32 {
+	if op.Bus != BusI2C {
+		return uint32(op.Address)
+	}
+	addr := uint32(op.Address) & 0x7F
+	if op.Register != nil {
+		addr |= uint32(*op.Register) << 8
+	}
+	return addr
+}
+
+// String 以精簡格式描述操作，用於日誌。
+func (op Operation) String() string {
+	target := fmt.Sprintf("DPCD 0x%05X", uint32(op.Address))
+	if op.Bus == BusI2C {
+		target = fmt.Sprintf("I2C 0x%02X", uint32(op.Address))
+		if op.Register != nil {
+			target += fmt.Sprintf(" reg 0x%02X", uint32(*op.Register))
+		}
+	}
+	if op.IsWrite() {
+		return fmt.Sprintf("write %s = % X", target, []byte(op.Data))
+	}
+	return fmt.Sprintf("read %s (%d bytes)", target, op.readLength())
+}
+
+func (op Operation) readLength() int {
+	if op.Length > 0 {
+		return op.Length
+	}
+	if len(op.Expect) > 0 {
+		return len(op.Expect)
+	}
+	return 1
+}
+
+func (op Operation) validate() error {
+	switch op.Bus {
+	case BusDPCD:
+		if op.Address > 0xFFFFF {
+			return fmt.Errorf("dpcd address 0x%X exceeds 20-bit range", uint32(op.Address))
+		}
+	case BusI2C:
+		if op.Address > 0x7F {
+			return fmt.Errorf("i2c slave 0x%X exceeds 7-bit range", uint32(op.Address))
+		}
+	default:
+		return fmt.Errorf("unknown bus %q", op.Bus)
+	}
+	if len(op.Mask) > 0 && len(op.Mask) != len(op.Expect) {
+		return errors.New("mask length must match expect length")
+	}
+	return nil
+}
+
+// RetryPolicy 指定步驟失敗時的重試次數與間隔。
+type RetryPolicy struct {
+	Attempts int `json:"attempts,omitempty"` // 總嘗試次數，0 或 1 代表不重試
+	DelayMS  int `json:"delay_ms,omitempty"`
+}
+
+// RollbackMode 指定步驟最終失敗時的復原範圍。
+type RollbackMode string
+
+const (
+	RollbackNone RollbackMode = ""     // 不復原
+	RollbackStep RollbackMode = "step" // 僅執行本步驟的復原操作
+	RollbackAll  RollbackMode = "all"  // 另依反序執行已完成步驟的復原操作
+)
+
+// RollbackPolicy 描述步驟的復原操作。
+type RollbackPolicy struct {
+	Mode RollbackMode `json:"mode,omitempty"`
+	Ops  []Operation  `json:"ops,omitempty"`
+}
+
+// Step 是配方中的單一步驟。
+type Step struct {
+	Name     string         `json:"name"`
+	Action   Action         `json:"action"`
+	Ops      []Operation    `json:"ops,omitempty"`
+	Target   *Operation     `json:"target,omitempty"`   // set_vcom、write_serial、program_edid 的寫入目標
+	File     string         `json:"file,omitempty"`     // program_edid 使用的 EDID 檔案（相對於配方）
+	Value    string         `json:"value,omitempty"`    // 數值或 ${變數} 範本
+	Width    int            `json:"width,omitempty"`    // set_vcom 位元組數或 write_serial 固定長度
+	Chunk    int            `json:"chunk,omitempty"`    // program_edid 每次寫入的位元組數
+	DelayMS  int            `json:"delay_ms,omitempty"` // delay 或 program_edid 每段寫入後的等待
+	Retry    RetryPolicy    `json:"retry,omitempty"`
+	Rollback RollbackPolicy `json:"rollback,omitempty"`
+}
+
+// Recipe 是一份宣告式的 One Key Build 流程。
+type Recipe struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Steps       []Step `json:"steps"`
+
+	// Path 為配方檔案路徑，用於解析相對檔案位置。
+	Path string `json:"-"`
+}
+
+// Load 讀取並驗證 JSON 格式的配方檔案。
+func Load(path string) (*Recipe, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Recipe
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("recipe %s: %w", filepath.Base(path), err)
+	}
+	r.Path = path
+	if r.Name == "" {
+		r.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("recipe %s: %w", filepath.Base(path), err)
+	}
+	return &r, nil
+}
+
+// Validate 檢查每個步驟的必要欄位。
+func (r *Recipe) Validate() error {
+	if len(r.Steps) == 0 {
+		return errors.New("recipe has no steps")
+	}
+	for i, step := range r.Steps {
+		if err := step.validate(); err != nil {
+			name := step.Name
+			if name == "" {
+				name = string(step.Action)
+			}
+			return fmt.Errorf("step %d (%s): %w", i+1, name, err)
+		}
+	}
+	return nil
+}
+
+func (s Step) validate() error {
+	switch s.Action {
+	case ActionDetect, ActionUnlock, ActionWrite, ActionVerify, ActionLock:
+		if len(s.Ops) == 0 {
+			return errors.New("ops are required")
+		}
+	case ActionProgramEDID:
+		if s.File == "" {
+			return errors.New("file is required")
+		}
+	case ActionSetVCOM, ActionWriteSerial:
+		if s.Target == nil {
+			return errors.New("target is required")
+		}
+		if s.Value == "" {
+			return errors.New("value is required")
+		}
+	case ActionDelay:
+		if s.DelayMS <= 0 {
+			return errors.New("delay_ms must be greater than zero")
+		}
+	default:
+		return fmt.Errorf("unknown action %q", s.Action)
+	}
+	for _, op := range s.Ops {
+		if err := op.validate(); err != nil {
+			return err
+		}
+	}
+	if s.Target != nil {
+		if err := s.Target.validate(); err != nil {
+			return err
+		}
+	}
+	for _, op := range s.Rollback.Ops {
+		if err := op.validate(); err != nil {
+			return fmt.Errorf("rollback: %w", err)
+		}
+	}
+	switch s.Rollback.Mode {
+	case RollbackNone, RollbackStep, RollbackAll:
+	default:
+		return fmt.Errorf("unknown rollback mode %q", s.Rollback.Mode)
+	}
+	return nil
+}
+
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+
+// Variables 回傳配方中以 ${name} 引用、需由執行者提供的變數名稱。
+func (r *Recipe) Variables() []string {
+	seen := map[string]bool{}
+	for _, step := range r.Steps {
+		for _, match := range variablePattern.FindAllStringSubmatch(step.Value, -1) {
+			seen[match[1]] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expand 以變數表取代 ${name}，遇到未定義的變數時回傳錯誤。
+func expand(text string, vars map[string]string) (string, error) {
+	var missing []string
+	result := variablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined variable %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// List 掃描資料夾內的 .json 配方檔案並依名稱排序。
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// 目錄不存在時視為沒有配方。
+			return nil, nil
+		}
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
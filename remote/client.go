@@ -0,0 +1,204 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// Client 為轉送至遠端伺服器的 gpu.Driver；連線中斷後下一次呼叫會自動重新連線。
+// 斷線時伺服器會釋放此客戶端的鎖，若先前已 Lock，重新連線後的硬體操作回傳
+// ErrLockLost，需再次 Lock 才能繼續，避免在失去獨占的情況下繼續寫入。
+type Client struct {
+	addr  string
+	token string
+
+	// Timeout 為連線與單一請求的逾時時間。
+	Timeout time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	nextID uint64
+	name   string
+	caps   []string
+	locked bool // 已取得伺服器的鎖
+}
+
+// DefaultTimeout 為 Dial 使用的連線與請求逾時。
+const DefaultTimeout = 10 * time.Second
+
+// Dial 連線至遠端伺服器並完成權杖驗證；addr 未指定連接埠時使用 DefaultPort。
+func Dial(addr, token string) (*Client, error) {
+	return DialTimeout(addr, token, DefaultTimeout)
+}
+
+// DialTimeout 與 Dial 相同，但以 timeout 作為連線與單一請求的逾時。
+func DialTimeout(addr, token string, timeout time.Duration) (*Client, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(strings.Trim(addr, "[]"), strconv.Itoa(DefaultPort))
+	}
+	c := &Client{addr: addr, token: token, Timeout: timeout}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// connect 建立連線並送出 hello，呼叫前需持有 mu。
+func (c *Client) connect() error {
+	conn, err := net.DialTimeout("tcp", c.addr, c.Timeout)
+	if err != nil {
+		return fmt.Errorf("remote: dial %s: %w", c.addr, err)
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+	resp, err := c.roundTrip(Request{Op: OpHello, Token: c.token})
+	if err != nil {
+		c.closeConn()
+		return err
+	}
+	c.name = resp.Name
+	c.caps = resp.Caps
+	return nil
+}
+
+// roundTrip 送出請求並等待回應，呼叫前需持有 mu。
+func (c *Client) roundTrip(req Request) (*Response, error) {
+	c.nextID++
+	req.ID = c.nextID
+	payload, err := json.Marshal(&req)
+	if err != nil {
+		return nil, err
+	}
+	if c.Timeout > 0 {
+		c.conn.SetDeadline(time.Now().Add(c.Timeout))
+	}
+	if _, err := c.conn.Write(append(payload, '\n')); err != nil {
+		return nil, fmt.Errorf("remote: send %s: %w", req.Op, err)
+	}
+	line, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("remote: receive %s: %w", req.Op, err)
+	}
+	var resp Response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return nil, fmt.Errorf("remote: decode %s reply: %w", req.Op, err)
+	}
+	if resp.ID != req.ID {
+		return nil, fmt.Errorf("remote: reply id %d does not match request %d", resp.ID, req.ID)
+	}
+	if !resp.OK {
+		return &resp, responseError(&resp)
+	}
+	return &resp, nil
+}
+
+// call 在必要時重新連線後送出請求；傳輸錯誤會關閉連線以便下次重試。
+func (c *Client) call(req Request) (*Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		if err := c.connect(); err != nil {
+			return nil, err
+		}
+		if c.locked && req.Op != OpLock && req.Op != OpUnlock {
+			c.locked = false
+			return nil, ErrLockLost
+		}
+	}
+	resp, err := c.roundTrip(req)
+	if err != nil && resp == nil {
+		c.closeConn()
+	}
+	if err == nil {
+		switch req.Op {
+		case OpLock:
+			c.locked = true
+		case OpUnlock:
+			c.locked = false
+		}
+	}
+	return resp, err
+}
+
+func (c *Client) closeConn() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+		c.reader = nil
+	}
+}
+
+// DirectI2C 回報伺服器端驅動是否支援直接 I2C 交易（hello 的 caps）。
+func (c *Client) DirectI2C() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Contains(c.caps, capDirectI2C)
+}
+
+// Close 關閉連線，伺服器會釋放此客戶端持有的鎖。
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeConn()
+	c.locked = false
+	return nil
+}
+
+// Lock 獨占遠端硬體，直到 Unlock 或斷線。
+func (c *Client) Lock() error {
+	_, err := c.call(Request{Op: OpLock})
+	return err
+}
+
+// Unlock 釋放 Lock 取得的獨占權。
+func (c *Client) Unlock() error {
+	_, err := c.call(Request{Op: OpUnlock})
+	return err
+}
+
+// Name 回傳遠端驅動名稱與伺服器位址。
+func (c *Client) Name() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fmt.Sprintf("Remote %s (%s)", c.addr, c.name)
+}
+
+func (c *Client) ReadDPCD(addr uint32, length uint32) ([]byte, error) {
+	resp, err := c.call(Request{Op: OpReadDPCD, Address: addr, Length: length})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *Client) WriteDPCD(addr uint32, data []byte) error {
+	_, err := c.call(Request{Op: OpWriteDPCD, Address: addr, Data: data})
+	return err
+}
+
+func (c *Client) ReadI2C(addr uint32, length uint32) ([]byte, error) {
+	resp, err := c.call(Request{Op: OpReadI2C, Address: addr, Length: length})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *Client) WriteI2C(addr uint32, data []byte) error {
+	_, err := c.call(Request{Op: OpWriteI2C, Address: addr, Data: data})
+	return err
+}
+
+var _ gpu.Driver = (*Client)(nil)
@@ -0,0 +1,151 @@
+// Package remote 讓開發者在自己的電腦上透過網路操作實驗室測試機的 AUX 通道。
+//
+// 伺服器將本機的 gpu.Driver 以 TCP 上的 JSON 協定公開，客戶端 Client 實作 gpu.Driver
+// 並將每個呼叫轉送至伺服器。每個請求與回應各為一行 JSON：
+//
+//	→ {"id":1,"op":"hello","token":"secret"}
+//	← {"id":1,"ok":true,"name":"drm_dp_aux0","caps":["direct_i2c"]}
+//	→ {"id":2,"op":"read_dpcd","address":0,"length":16}
+//	← {"id":2,"ok":true,"data":"EhQBgQ..."}
+//	→ {"id":3,"op":"write_i2c","address":20560,"data":"AQI="}
+//	← {"id":3,"ok":false,"error":"...","code":"locked"}
+//
+// 連線後第一個請求必須是帶有正確權杖的 hello。data 為 base64 編碼的位元組；
+// address 與 length 的意義與 gpu.Driver 相同（I2C 位址低 7 位元為從屬位址、
+// 高位元組為暫存器）。op 可為 hello、read_dpcd、write_dpcd、read_i2c、write_i2c、
+// lock 與 unlock。
+//
+// hello 的 caps 列出伺服器驅動的選用能力，目前只有 direct_i2c（見 gpu.DirectI2C）。
+//
+// lock 讓單一客戶端獨占硬體直到 unlock 或斷線，其他客戶端的請求會收到 code 為
+// "locked" 的錯誤；未上鎖時各客戶端的請求依序執行，不會交錯。單一請求的長度
+// 上限為 DPCD 64 KiB、I2C 256 位元組。
+package remote
+
+import (
+	"errors"
+	"fmt"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// DefaultPort 為伺服器預設的 TCP 連接埠。
+const DefaultPort = 7420
+
+// 單一請求的讀寫長度上限；超過時伺服器回應 bad_request。
+const (
+	MaxDPCDLength = 64 * 1024
+	MaxI2CLength  = 256
+)
+
+// 請求種類。
+const (
+	OpHello     = "hello"
+	OpReadDPCD  = "read_dpcd"
+	OpWriteDPCD = "write_dpcd"
+	OpReadI2C   = "read_i2c"
+	OpWriteI2C  = "write_i2c"
+	OpLock      = "lock"
+	OpUnlock    = "unlock"
+)
+
+// hello 回應中 caps 列出的伺服器驅動能力。
+const (
+	capDirectI2C = "direct_i2c" // 支援 gpu.DirectI2C（DDC/CI 所需）
+)
+
+// 錯誤代碼，讓客戶端還原成對應的 sentinel 錯誤。
+const (
+	codeUnauthorized   = "unauthorized"
+	codeLocked         = "locked"
+	codeNotImplemented = "not_implemented"
+	codeNoDriver       = "no_driver"
+	codeBadRequest     = "bad_request"
+)
+
+var (
+	// ErrUnauthorized 表示權杖錯誤或尚未完成 hello。
+	ErrUnauthorized = errors.New("remote: unauthorized")
+	// ErrLocked 表示硬體目前由其他客戶端鎖定。
+	ErrLocked = errors.New("remote: hardware locked by another client")
+	// ErrBadRequest 表示請求格式或參數錯誤。
+	ErrBadRequest = errors.New("remote: bad request")
+	// ErrLockLost 表示連線中斷後伺服器已釋放此客戶端的鎖，需重新 Lock 才能繼續操作。
+	ErrLockLost = errors.New("remote: lock lost after reconnect")
+)
+
+// Request 為客戶端送出的單一請求。
+type Request struct {
+	ID      uint64 `json:"id"`
+	Op      string `json:"op"`
+	Token   string `json:"token,omitempty"`
+	Address uint32 `json:"address,omitempty"`
+	Length  uint32 `json:"length,omitempty"`
+	Data    []byte `json:"data,omitempty"`
+}
+
+// Response 為伺服器對請求的回應。
+type Response struct {
+	ID    uint64   `json:"id"`
+	OK    bool     `json:"ok"`
+	Name  string   `json:"name,omitempty"`
+	Caps  []string `json:"caps,omitempty"`
+	Data  []byte   `json:"data,omitempty"`
+	Error string   `json:"error,omitempty"`
+	Code  string   `json:"code,omitempty"`
+}
+
+// errorCode 將錯誤對應為協定中的錯誤代碼。
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return codeUnauthorized
+	case errors.Is(err, ErrLocked):
+		return codeLocked
+	case errors.Is(err, ErrBadRequest):
+		return codeBadRequest
+	case errors.Is(err, gpu.ErrNotImplemented):
+		return codeNotImplemented
+	case errors.Is(err, gpu.ErrNoDriver):
+		return codeNoDriver
+	default:
+		// AUX 錯誤分類（timeout、defer 等）直接以分類名稱作為代碼。
+		return gpu.ErrorKind(err)
+	}
+}
+
+// responseError 將回應中的錯誤還原，已知代碼會包裝對應的 sentinel 錯誤。
+func responseError(resp *Response) error {
+	var sentinel error
+	switch resp.Code {
+	case codeUnauthorized:
+		sentinel = ErrUnauthorized
+	case codeLocked:
+		sentinel = ErrLocked
+	case codeBadRequest:
+		sentinel = ErrBadRequest
+	case codeNotImplemented:
+		sentinel = gpu.ErrNotImplemented
+	case codeNoDriver:
+		sentinel = gpu.ErrNoDriver
+	default:
+		if sentinel = gpu.KindError(resp.Code); sentinel == nil {
+			return fmt.Errorf("remote: %s", resp.Error)
+		}
+	}
+	return &remoteError{message: resp.Error, sentinel: sentinel}
+}
+
+// remoteError 保留伺服器端的錯誤訊息並可以 errors.Is 比對 sentinel 錯誤。
+type remoteError struct {
+	message  string
+	sentinel error
+}
+
+func (e *remoteError) Error() string {
+	return e.message
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.sentinel
+}
@@ -0,0 +1,256 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+const testToken = "secret"
+
+// startServer 在迴路位址啟動以模擬驅動為後端的伺服器，測試結束時關閉。
+func startServer(t *testing.T) (*Server, *gpu.SimDriver, string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := gpu.NewSimDriver()
+	server := NewServer(sim, testToken)
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ln) }()
+	t.Cleanup(func() {
+		server.Close()
+		if err := <-done; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return server, sim, ln.Addr().String()
+}
+
+func dialTest(t *testing.T, addr, token string) *Client {
+	t.Helper()
+	client, err := DialTimeout(addr, token, 2*time.Second)
+	if err != nil {
+		t.Fatalf("DialTimeout: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestTokenRejection(t *testing.T) {
+	_, _, addr := startServer(t)
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{name: "wrong token", token: "guess", want: ErrUnauthorized},
+		{name: "empty token", token: "", want: ErrUnauthorized},
+		{name: "valid token", token: testToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := DialTimeout(addr, tt.token, 2*time.Second)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("DialTimeout error = %v, want %v", err, tt.want)
+			}
+			if client != nil {
+				client.Close()
+			}
+		})
+	}
+}
+
+func TestRequestBeforeHello(t *testing.T) {
+	_, _, addr := startServer(t)
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	if err := json.NewEncoder(conn).Encode(Request{ID: 1, Op: OpReadDPCD, Length: 1}); err != nil {
+		t.Fatal(err)
+	}
+	reader := bufio.NewReader(conn)
+	var resp Response
+	if err := json.NewDecoder(reader).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.OK || resp.Code != codeUnauthorized {
+		t.Fatalf("response = %+v, want unauthorized", resp)
+	}
+	// 驗證失敗後伺服器會中斷連線。
+	if _, err := reader.ReadByte(); err == nil {
+		t.Fatal("connection still open after unauthorized request")
+	}
+}
+
+func TestForwarding(t *testing.T) {
+	_, sim, addr := startServer(t)
+	client := dialTest(t, addr, testToken)
+
+	if err := client.WriteDPCD(0x100, []byte{0x1E, 0x84}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := sim.ReadDPCD(0x100, 2)
+	if err != nil || !bytes.Equal(got, []byte{0x1E, 0x84}) {
+		t.Fatalf("server side DPCD = % X, %v", got, err)
+	}
+	got, err = client.ReadDPCD(0x100, 2)
+	if err != nil || !bytes.Equal(got, []byte{0x1E, 0x84}) {
+		t.Fatalf("ReadDPCD = % X, %v", got, err)
+	}
+
+	// 伺服器端的錯誤分類經錯誤代碼還原，可在客戶端以 errors.Is 比對。
+	if _, err := client.ReadI2C(0x50, 1); !errors.Is(err, gpu.ErrNACK) {
+		t.Errorf("ReadI2C on absent slave error = %v, want ErrNACK", err)
+	}
+	if !client.DirectI2C() {
+		t.Error("DirectI2C = false, want true for sim backend")
+	}
+}
+
+func TestLockOwnership(t *testing.T) {
+	_, _, addr := startServer(t)
+	a := dialTest(t, addr, testToken)
+	b := dialTest(t, addr, testToken)
+
+	read := func(c *Client) error {
+		_, err := c.ReadDPCD(0, 1)
+		return err
+	}
+
+	steps := []struct {
+		name  string
+		do    func() error
+		wantA error
+		wantB error
+	}{
+		{name: "unlocked", do: func() error { return nil }},
+		{name: "a locks", do: a.Lock, wantB: ErrLocked},
+		{name: "b cannot take lock", do: func() error {
+			if err := b.Lock(); !errors.Is(err, ErrLocked) {
+				return errors.New("b acquired a held lock")
+			}
+			return nil
+		}, wantB: ErrLocked},
+		{name: "b unlock does not release", do: b.Unlock, wantB: ErrLocked},
+		{name: "a unlocks", do: a.Unlock},
+		{name: "b locks", do: b.Lock, wantA: ErrLocked},
+	}
+	for _, step := range steps {
+		if err := step.do(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if err := read(a); !errors.Is(err, step.wantA) {
+			t.Errorf("%s: client a read error = %v, want %v", step.name, err, step.wantA)
+		}
+		if err := read(b); !errors.Is(err, step.wantB) {
+			t.Errorf("%s: client b read error = %v, want %v", step.name, err, step.wantB)
+		}
+	}
+
+	// 持有鎖的客戶端斷線後，伺服器釋放鎖。
+	b.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err := read(a)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrLocked) || time.Now().After(deadline) {
+			t.Fatalf("lock not released after disconnect: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLockLostAfterReconnect(t *testing.T) {
+	_, _, addr := startServer(t)
+	a := dialTest(t, addr, testToken)
+	b := dialTest(t, addr, testToken)
+
+	if err := a.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	// 模擬網路中斷：伺服器釋放 a 的鎖，b 可以取得。
+	a.mu.Lock()
+	a.conn.Close()
+	a.mu.Unlock()
+	if _, err := a.ReadDPCD(0, 1); err == nil {
+		t.Fatal("read on dropped connection succeeded")
+	}
+	if _, err := a.ReadDPCD(0, 1); !errors.Is(err, ErrLockLost) {
+		t.Fatalf("read after reconnect error = %v, want ErrLockLost", err)
+	}
+	// 伺服器在偵測到舊連線關閉後才釋放鎖，之前的操作仍會收到 ErrLocked。
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err := a.WriteDPCD(0x100, []byte{0x01})
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrLocked) || time.Now().After(deadline) {
+			t.Fatalf("write after ErrLockLost without lock: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := b.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Lock(); !errors.Is(err, ErrLocked) {
+		t.Fatalf("relock while b holds the lock error = %v, want ErrLocked", err)
+	}
+	if err := b.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Lock(); err != nil {
+		t.Fatalf("relock: %v", err)
+	}
+	if err := a.WriteDPCD(0x100, []byte{0x02}); err != nil {
+		t.Fatalf("write after relock: %v", err)
+	}
+}
+
+func TestLengthLimits(t *testing.T) {
+	_, _, addr := startServer(t)
+	client := dialTest(t, addr, testToken)
+
+	tests := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{name: "dpcd read at limit", run: func() error {
+			_, err := client.ReadDPCD(0, MaxDPCDLength)
+			return err
+		}},
+		{name: "dpcd read over limit", run: func() error {
+			_, err := client.ReadDPCD(0, MaxDPCDLength+1)
+			return err
+		}, want: ErrBadRequest},
+		{name: "i2c read over limit", run: func() error {
+			_, err := client.ReadI2C(0x50, MaxI2CLength+1)
+			return err
+		}, want: ErrBadRequest},
+		{name: "i2c write over limit", run: func() error {
+			return client.WriteI2C(0x50, make([]byte, MaxI2CLength+1))
+		}, want: ErrBadRequest},
+	}
+	for _, tt := range tests {
+		if err := tt.run(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package remote
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"GMTAUXOneKeyBuild/gpu"
+)
+
+// maxRequestSize 為單一請求行的大小上限。
+const maxRequestSize = 1 << 20
+
+// GenerateToken 產生 32 個十六進位字元的隨機權杖，供未指定權杖時使用。
+func GenerateToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("remote: generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Server 將本機驅動公開給遠端客戶端。
+type Server struct {
+	driver gpu.Driver
+	token  string
+
+	// Logf 記錄連線與鎖定事件，為 nil 時不輸出。
+	Logf func(format string, args ...any)
+
+	hwMu  sync.Mutex // 序列化硬體存取
+	mu    sync.Mutex
+	owner *session
+	ln    net.Listener
+	conns map[*session]struct{}
+}
+
+// session 為單一客戶端連線的狀態。
+type session struct {
+	conn   net.Conn
+	authed bool
+}
+
+// NewServer 建立使用指定驅動與權杖的伺服器。
+func NewServer(driver gpu.Driver, token string) *Server {
+	return &Server{driver: driver, token: token, conns: map[*session]struct{}{}}
+}
+
+// ListenAndServe 監聽 addr 並處理連線，直到 Close 被呼叫。
+func (s *Server) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ln)
+}
+
+// Serve 在既有的 listener 上處理連線，方便以迴路位址在同一行程內測試。
+func (s *Server) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	s.ln = ln
+	s.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// Close 停止監聽並中斷所有連線。
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.ln != nil {
+		err = s.ln.Close()
+	}
+	for sess := range s.conns {
+		sess.conn.Close()
+	}
+	return err
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
+
+// handle 逐行讀取請求並回應，斷線時釋放該客戶端持有的鎖。
+func (s *Server) handle(conn net.Conn) {
+	sess := &session{conn: conn}
+	s.mu.Lock()
+	s.conns[sess] = struct{}{}
+	s.mu.Unlock()
+	s.logf("remote: %s connected", conn.RemoteAddr())
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, sess)
+		if s.owner == sess {
+			s.owner = nil
+			s.logf("remote: %s disconnected, lock released", conn.RemoteAddr())
+		}
+		s.mu.Unlock()
+		conn.Close()
+		s.logf("remote: %s closed", conn.RemoteAddr())
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), maxRequestSize)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req Request
+		var resp Response
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp = failure(req.ID, fmt.Errorf("%w: %v", ErrBadRequest, err))
+		} else {
+			resp = s.dispatch(sess, &req)
+		}
+		if err := encoder.Encode(&resp); err != nil {
+			return
+		}
+		if resp.Code == codeUnauthorized {
+			// 驗證失敗即中斷連線，避免暴力嘗試權杖。
+			return
+		}
+	}
+}
+
+// dispatch 驗證權限與鎖定後執行請求。
+func (s *Server) dispatch(sess *session, req *Request) Response {
+	if req.Op == OpHello {
+		if subtle.ConstantTimeCompare([]byte(req.Token), []byte(s.token)) != 1 {
+			s.logf("remote: %s rejected: invalid token", sess.conn.RemoteAddr())
+			return failure(req.ID, ErrUnauthorized)
+		}
+		sess.authed = true
+		resp := Response{ID: req.ID, OK: true, Name: s.driver.Name()}
+		if gpu.SupportsDirectI2C(s.driver) {
+			resp.Caps = append(resp.Caps, capDirectI2C)
+		}
+		return resp
+	}
+	if !sess.authed {
+		return failure(req.ID, ErrUnauthorized)
+	}
+
+	switch req.Op {
+	case OpLock:
+		// 等待進行中的硬體操作結束後才取得鎖，鎖生效後不會再有其他客戶端的操作。
+		s.hwMu.Lock()
+		defer s.hwMu.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.owner != nil && s.owner != sess {
+			return failure(req.ID, fmt.Errorf("%w (%s)", ErrLocked, s.owner.conn.RemoteAddr()))
+		}
+		s.owner = sess
+		s.logf("remote: %s acquired lock", sess.conn.RemoteAddr())
+		return Response{ID: req.ID, OK: true}
+	case OpUnlock:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.owner == sess {
+			s.owner = nil
+			s.logf("remote: %s released lock", sess.conn.RemoteAddr())
+		}
+		return Response{ID: req.ID, OK: true}
+	}
+
+	if err := checkLength(req); err != nil {
+		return failure(req.ID, err)
+	}
+
+	// 持有 hwMu 檢查鎖的擁有者，檢查與硬體操作之間不會有其他客戶端取得鎖。
+	s.hwMu.Lock()
+	defer s.hwMu.Unlock()
+	s.mu.Lock()
+	owner := s.owner
+	s.mu.Unlock()
+	if owner != nil && owner != sess {
+		return failure(req.ID, fmt.Errorf("%w (%s)", ErrLocked, owner.conn.RemoteAddr()))
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch req.Op {
+	case OpReadDPCD:
+		data, err = s.driver.ReadDPCD(req.Address, req.Length)
+	case OpWriteDPCD:
+		err = s.driver.WriteDPCD(req.Address, req.Data)
+	case OpReadI2C:
+		data, err = s.driver.ReadI2C(req.Address, req.Length)
+	case OpWriteI2C:
+		err = s.driver.WriteI2C(req.Address, req.Data)
+	default:
+		err = fmt.Errorf("%w: unknown op %q", ErrBadRequest, req.Op)
+	}
+	if err != nil {
+		return failure(req.ID, err)
+	}
+	return Response{ID: req.ID, OK: true, Data: data}
+}
+
+// checkLength 拒絕超過上限的讀寫長度，避免單一請求長時間占用硬體。
+func checkLength(req *Request) error {
+	limit := uint32(MaxDPCDLength)
+	if req.Op == OpReadI2C || req.Op == OpWriteI2C {
+		limit = MaxI2CLength
+	}
+	length := req.Length
+	if req.Op == OpWriteDPCD || req.Op == OpWriteI2C {
+		length = uint32(len(req.Data))
+	}
+	if length > limit {
+		return fmt.Errorf("%w: %s length %d exceeds %d", ErrBadRequest, req.Op, length, limit)
+	}
+	return nil
+}
+
+// failure 組成錯誤回應。
+func failure(id uint64, err error) Response {
+	return Response{ID: id, Error: err.Error(), Code: errorCode(err)}
+}
@@ -1,6 +1,6 @@
--- 讀取選定顯示器的 DPCD 位址 0x0000~0x0005，並以十六進位顯示結果。
+-- 讀取選定顯示器的 DPCD 位址 0x0000~0x000F，並以十六進位與解碼後的欄位顯示結果。
 local start_addr = 0x0000
-local length = 6
+local length = 16
 
 if not context or context.selected_display_index == 0 then
   return "尚未選擇任何顯示器，無法讀取 DPCD。"
@@ -34,11 +34,13 @@ end
 
 set_status("[green]DPCD 讀取完成[-]")
 
+-- dpcd.format 依 DP/eDP 規格將原始位元組轉成具名欄位。
 return string.format(
-  "%s (%s) 的 DPCD[0x%04X-0x%04X]：%s",
+  "%s (%s) 的 DPCD[0x%04X-0x%04X]：%s\n\n%s",
   displayName,
   vendor,
   start_addr,
   start_addr + length - 1,
-  table.concat(bytes, " ")
+  table.concat(bytes, " "),
+  dpcd.format(start_addr, data)
 )
@@ -0,0 +1,158 @@
+package display
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// FieldDiff 為單一 EDID 欄位的比較結果；A、B 為格式化後的內容，超出資料長度時為空字串。
+type FieldDiff struct {
+	Key     string `json:"key"`
+	Label   string `json:"label"`
+	Offset  int    `json:"offset"`
+	Length  int    `json:"length"`
+	A       string `json:"a"`
+	B       string `json:"b"`
+	Changed bool   `json:"changed"`
+}
+
+// ByteDiff 為單一位元組的差異；某一側沒有該位元組時為 -1。
+type ByteDiff struct {
+	Offset int `json:"offset"`
+	A      int `json:"a"`
+	B      int `json:"b"`
+}
+
+// EDIDDiff 為兩份 EDID 逐欄位與逐位元組的比較結果。
+type EDIDDiff struct {
+	LengthA int         `json:"length_a"`
+	LengthB int         `json:"length_b"`
+	Fields  []FieldDiff `json:"fields"` // 依位移排序的所有欄位，含未變更者
+	Bytes   []ByteDiff  `json:"bytes"`  // 僅列出不同的位元組
+}
+
+// Equal 回傳兩份 EDID 是否完全相同。
+func (d *EDIDDiff) Equal() bool {
+	return len(d.Bytes) == 0
+}
+
+// ChangedFields 回傳內容不同的欄位。
+func (d *EDIDDiff) ChangedFields() []FieldDiff {
+	var changed []FieldDiff
+	for _, f := range d.Fields {
+		if f.Changed {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+// edidField 描述 EDID 中的一個欄位與其格式化方式。
+type edidField struct {
+	key    string
+	label  string
+	offset int
+	length int
+	format func([]byte) string
+}
+
+// baseBlockFields 為區塊 0 的欄位定義，依 EDID 1.4 規格排列。
+var baseBlockFields = []edidField{
+	{"header", "標頭", 0x00, 8, formatHex},
+	{"manufacturer_id", "製造商ID", 0x08, 2, parseManufacturerID},
+	{"product_code", "產品ID", 0x0A, 2, func(b []byte) string { return fmt.Sprintf("0x%04X", binary.LittleEndian.Uint16(b)) }},
+	{"serial_number", "序號", 0x0C, 4, func(b []byte) string { return fmt.Sprintf("0x%08X", binary.LittleEndian.Uint32(b)) }},
+	{"week", "製造週次", 0x10, 1, formatDecimal},
+	{"year", "製造年份", 0x11, 1, func(b []byte) string { return fmt.Sprintf("%d", int(b[0])+1990) }},
+	{"edid_version", "EDID 版本", 0x12, 1, formatDecimal},
+	{"edid_revision", "EDID 修訂版", 0x13, 1, formatDecimal},
+	{"video_input", "視訊輸入定義", 0x14, 1, formatHex},
+	{"screen_size", "螢幕尺寸 (cm)", 0x15, 2, func(b []byte) string { return fmt.Sprintf("%dx%d", b[0], b[1]) }},
+	{"gamma", "Gamma", 0x17, 1, func(b []byte) string { return fmt.Sprintf("%.2f", (float64(b[0])+100)/100) }},
+	{"features", "功能支援", 0x18, 1, formatHex},
+	{"chromaticity", "色度座標", 0x19, 10, formatHex},
+	{"established_timings", "既定時序", 0x23, 3, formatHex},
+	{"standard_timings", "標準時序", 0x26, 16, formatHex},
+	{"descriptor1", "描述 1", 0x36, 18, formatDescriptor},
+	{"descriptor2", "描述 2", 0x48, 18, formatDescriptor},
+	{"descriptor3", "描述 3", 0x5A, 18, formatDescriptor},
+	{"descriptor4", "描述 4", 0x6C, 18, formatDescriptor},
+	{"extension_count", "擴充區塊數", 0x7E, 1, formatDecimal},
+	{"checksum", "檢查碼", 0x7F, 1, formatHex},
+}
+
+// extensionBlockFields 回傳第 block 個擴充區塊的欄位定義。
+func extensionBlockFields(block int) []edidField {
+	base := block * 128
+	prefix := fmt.Sprintf("block%d_", block)
+	label := fmt.Sprintf("區塊 %d ", block)
+	return []edidField{
+		{prefix + "tag", label + "標籤", base, 1, formatHex},
+		{prefix + "revision", label + "修訂版", base + 1, 1, formatDecimal},
+		{prefix + "data", label + "內容", base + 2, 125, formatHex},
+		{prefix + "checksum", label + "檢查碼", base + 127, 1, formatHex},
+	}
+}
+
+// DiffEDID 逐欄位與逐位元組比較兩份 EDID；長度不同時，較長一方多出的位元組也列為差異。
+func DiffEDID(a, b []byte) *EDIDDiff {
+	diff := &EDIDDiff{LengthA: len(a), LengthB: len(b)}
+
+	length := max(len(a), len(b))
+	for offset := 0; offset < length; offset++ {
+		va, vb := byteAt(a, offset), byteAt(b, offset)
+		if va != vb {
+			diff.Bytes = append(diff.Bytes, ByteDiff{Offset: offset, A: va, B: vb})
+		}
+	}
+
+	fields := baseBlockFields
+	for block := 1; block*128 < length; block++ {
+		fields = append(fields[:len(fields):len(fields)], extensionBlockFields(block)...)
+	}
+	for _, f := range fields {
+		rawA, rawB := fieldBytes(a, f), fieldBytes(b, f)
+		fd := FieldDiff{Key: f.key, Label: f.label, Offset: f.offset, Length: f.length}
+		if rawA != nil {
+			fd.A = f.format(rawA)
+		}
+		if rawB != nil {
+			fd.B = f.format(rawB)
+		}
+		fd.Changed = !equalBytes(rawA, rawB) || (rawA == nil) != (rawB == nil)
+		diff.Fields = append(diff.Fields, fd)
+	}
+	return diff
+}
+
+// byteAt 回傳指定位移的位元組，超出長度時回傳 -1。
+func byteAt(data []byte, offset int) int {
+	if offset >= len(data) {
+		return -1
+	}
+	return int(data[offset])
+}
+
+// fieldBytes 取出欄位的原始位元組，資料不足時回傳 nil。
+func fieldBytes(data []byte, f edidField) []byte {
+	if f.offset+f.length > len(data) {
+		return nil
+	}
+	return data[f.offset : f.offset+f.length]
+}
+
+func formatHex(b []byte) string {
+	return fmt.Sprintf("% X", b)
+}
+
+func formatDecimal(b []byte) string {
+	return fmt.Sprintf("%d", b[0])
+}
+
+// formatDescriptor 優先顯示描述符的解析結果，無法解析時顯示原始位元組。
+func formatDescriptor(b []byte) string {
+	if text := parseDescriptor(b); text != "" {
+		return text
+	}
+	return formatHex(b)
+}
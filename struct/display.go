@@ -8,22 +8,53 @@ import (
 
 // Display 用來儲存解析後的 EDID 資訊
 type Display struct {
-	AdapterName    string
-	AdapterString  string
-	DeviceID       string
-	ManufacturerID string
-	ProductID      string
-	Serial         string
-	Week           int
-	Year           int
-	Version        string
-	Revision       string
-	Descriptor1    string
-	Descriptor2    string
-	Descriptor3    string
-	Descriptor4    string
+	AdapterName    string `json:"adapter_name"`
+	AdapterString  string `json:"adapter_string"`
+	DeviceID       string `json:"device_id"`
+	ManufacturerID string `json:"manufacturer_id"`
+	ProductID      string `json:"product_id"`
+	Serial         string `json:"serial"`
+	Week           int    `json:"week"`
+	Year           int    `json:"year"`
+	Version        string `json:"version"`
+	Revision       string `json:"revision"`
+	Descriptor1    string `json:"descriptor1"`
+	Descriptor2    string `json:"descriptor2"`
+	Descriptor3    string `json:"descriptor3"`
+	Descriptor4    string `json:"descriptor4"`
+	// DetailedTimings 為區塊 0 描述符中的詳細時序，依描述符順序排列。
+	DetailedTimings []DetailedTiming `json:"detailed_timings,omitempty"`
+	// VendorName 為製造商 ID 對應的公司名稱，PanelModel 為面板型號表中的料號，查無資料時為空字串。
+	VendorName       string `json:"vendor_name,omitempty"`
+	PanelModel       string `json:"panel_model,omitempty"`
+	PanelDescription string `json:"panel_description,omitempty"`
+	// Source 記錄 EDID 的來源，例如 SourceRegistry 或 SourceSink。
+	Source string `json:"source,omitempty"`
+	// 以下為數值型態的欄位，供腳本與程式直接比較，不需再解析格式化字串。
+	ManufacturerCode uint16 `json:"manufacturer_code"` // 0x08~0x09 的原始大端序編碼
+	ProductCode      uint16 `json:"product_code"`
+	SerialNumber     uint32 `json:"serial_number"`
+	EDIDVersion      int    `json:"edid_version"`
+	EDIDRevision     int    `json:"edid_revision"`
+	ExtensionCount   int    `json:"extension_count"` // 區塊 0 第 0x7E 位元組宣告的擴充區塊數
+	// EDID 為解析時的原始位元組（含擴充區塊）。
+	EDID EDIDBytes `json:"edid"`
+	// Warnings 為解析時發現但不影響使用的問題，例如檢查碼錯誤。
+	Warnings []string `json:"warnings,omitempty"`
 }
 
+// EDID 來源。
+const (
+	// SourceRegistry 表示 EDID 取自作業系統保存的副本。
+	SourceRegistry = "registry"
+	// SourceSysfs 表示 EDID 取自 Linux DRM 於 sysfs 提供的副本。
+	SourceSysfs = "sysfs"
+	// SourceSink 表示 EDID 直接透過 I2C 自面板 EEPROM 讀取。
+	SourceSink = "sink"
+	// SourceFile 表示 EDID 由檔案載入或手動貼上，並非來自已連接的顯示器。
+	SourceFile = "file"
+)
+
 // parseManufacturerID 解析製造商ID
 func parseManufacturerID(data []byte) string {
 	// 取出兩個位元組並轉成 16 位元整數，作為編碼來源。
@@ -75,24 +106,11 @@ func parseDescriptor(desc []byte) string {
 		default:
 			return fmt.Sprintf("Monitor Descriptor (Tag 0x%02X)", tag)
 		}
-	} else {
-		// 詳細定時描述符，必須依欄位位元組重建解析度與時脈。
-		hActive := int(desc[2]) + ((int(desc[4]) & 0xF0) << 4)
-		hBlank := int(desc[3]) + ((int(desc[4]) & 0x0F) << 8)
-		vActive := int(desc[5]) + ((int(desc[7]) & 0xF0) << 4)
-		vBlank := int(desc[6]) + ((int(desc[7]) & 0x0F) << 8)
-		hTotal := hActive + hBlank
-		vTotal := vActive + vBlank
-
-		refresh := 0.0
-		if hTotal != 0 && vTotal != 0 {
-			// pixelClock 單位為 10kHz，因此需除以總像素數後轉換為 Hz。
-			refresh = float64(pixelClock) / float64(hTotal*vTotal/10000)
-		}
-
-		return fmt.Sprintf("Detailed Timing: %dx%d @ %.2fHz (PixelClock %.2fMHz)",
-			hActive, vActive, refresh, float64(pixelClock)/100.0)
 	}
+
+	// 詳細定時描述符，完整解析後輸出精確的時序摘要。
+	timing, _ := DecodeDetailedTiming(desc)
+	return timing.String()
 }
 
 // ParseEDID 解析整份EDID並返回 Display 結構
@@ -124,26 +142,72 @@ func ParseEDID(edid []byte,
 	// EDID 在四個固定位置儲存描述符資訊。
 	offsets := []int{0x36, 0x48, 0x5A, 0x6C}
 	descs := make([]string, 4)
+	var timings []DetailedTiming
 	for i, off := range offsets {
 		// 每個描述符長度固定 18 位元組。
 		desc := edid[off : off+18]
 		descs[i] = parseDescriptor(desc)
+		if timing, ok := DecodeDetailedTiming(desc); ok {
+			timings = append(timings, timing)
+		}
 	}
 
+	panel, _ := LookupPanelModel(manuID, productID)
+
 	return &Display{
-		AdapterName:    adapterName,
-		AdapterString:  adapterString,
-		DeviceID:       deviceID,
-		ManufacturerID: manuID,
-		ProductID:      fmt.Sprintf("0x%04X", productID),
-		Serial:         fmt.Sprintf("0x%08X", serial),
-		Week:           week,
-		Year:           year,
-		Version:        version,
-		Revision:       revision,
-		Descriptor1:    descs[0],
-		Descriptor2:    descs[1],
-		Descriptor3:    descs[2],
-		Descriptor4:    descs[3],
+		AdapterName:      adapterName,
+		AdapterString:    adapterString,
+		DeviceID:         deviceID,
+		ManufacturerID:   manuID,
+		VendorName:       VendorName(manuID),
+		PanelModel:       panel.Model,
+		PanelDescription: panel.Description,
+		ProductID:        fmt.Sprintf("0x%04X", productID),
+		Serial:           fmt.Sprintf("0x%08X", serial),
+		Week:             week,
+		Year:             year,
+		Version:          version,
+		Revision:         revision,
+		Descriptor1:      descs[0],
+		Descriptor2:      descs[1],
+		Descriptor3:      descs[2],
+		Descriptor4:      descs[3],
+		DetailedTimings:  timings,
+		EDID:             append(EDIDBytes(nil), edid...),
+		Warnings:         validateEDID(edid),
+		ManufacturerCode: binary.BigEndian.Uint16(edid[0x08:0x0A]),
+		ProductCode:      productID,
+		SerialNumber:     serial,
+		EDIDVersion:      int(edid[0x12]),
+		EDIDRevision:     int(edid[0x13]),
+		ExtensionCount:   int(edid[0x7E]),
 	}, nil
 }
+
+// validateEDID 檢查區塊長度、擴充區塊數量、檢查碼與製造商 ID，回傳警告清單。
+func validateEDID(edid []byte) []string {
+	var warnings []string
+	if len(edid)%128 != 0 {
+		warnings = append(warnings, fmt.Sprintf("EDID length %d is not a multiple of 128", len(edid)))
+	}
+	blocks := len(edid) / 128
+	if declared := int(edid[0x7E]) + 1; declared != blocks {
+		warnings = append(warnings, fmt.Sprintf("EDID declares %d extension block(s) but %d present", declared-1, blocks-1))
+	}
+	for i := 0; i < blocks; i++ {
+		var sum byte
+		for _, b := range edid[i*128 : (i+1)*128] {
+			sum += b
+		}
+		if sum != 0 {
+			warnings = append(warnings, fmt.Sprintf("block %d checksum mismatch (sum 0x%02X)", i, sum))
+		}
+	}
+	// 製造商 ID 每個字母需介於 1~26（A~Z）。
+	val := binary.BigEndian.Uint16(edid[0x08:0x0A])
+	if val&0x8000 != 0 || (val>>10)&0x1F == 0 || (val>>5)&0x1F == 0 || val&0x1F == 0 ||
+		(val>>10)&0x1F > 26 || (val>>5)&0x1F > 26 || val&0x1F > 26 {
+		warnings = append(warnings, fmt.Sprintf("invalid manufacturer ID 0x%04X", val))
+	}
+	return warnings
+}
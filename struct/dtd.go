@@ -0,0 +1,290 @@
+package display
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DTD 第 17 位元組（旗標）的同步類型，位元 4~3。
+const (
+	SyncAnalogComposite        = "analog composite"
+	SyncBipolarAnalogComposite = "bipolar analog composite"
+	SyncDigitalComposite       = "digital composite"
+	SyncDigitalSeparate        = "digital separate"
+)
+
+// DetailedTiming 為 18 位元組詳細時序描述符（DTD）的欄位。
+type DetailedTiming struct {
+	PixelClock  int  `json:"pixel_clock"` // kHz，以 10 kHz 為單位儲存
+	HActive     int  `json:"h_active"`
+	HBlank      int  `json:"h_blank"`
+	HFrontPorch int  `json:"h_front_porch"`
+	HSyncWidth  int  `json:"h_sync_width"`
+	VActive     int  `json:"v_active"`
+	VBlank      int  `json:"v_blank"`
+	VFrontPorch int  `json:"v_front_porch"`
+	VSyncWidth  int  `json:"v_sync_width"`
+	HImageSize  int  `json:"h_image_size"` // mm
+	VImageSize  int  `json:"v_image_size"` // mm
+	HBorder     int  `json:"h_border"`
+	VBorder     int  `json:"v_border"`
+	Flags       byte `json:"flags"` // 第 17 位元組：交錯、立體與同步類型
+}
+
+// DecodeDetailedTiming 解析 18 位元組描述符；像素時脈為 0（顯示器描述符）時回傳 false。
+func DecodeDetailedTiming(desc []byte) (DetailedTiming, bool) {
+	if len(desc) != 18 || binary.LittleEndian.Uint16(desc[0:2]) == 0 {
+		return DetailedTiming{}, false
+	}
+	return DetailedTiming{
+		PixelClock:  int(binary.LittleEndian.Uint16(desc[0:2])) * 10,
+		HActive:     int(desc[2]) | int(desc[4]&0xF0)<<4,
+		HBlank:      int(desc[3]) | int(desc[4]&0x0F)<<8,
+		VActive:     int(desc[5]) | int(desc[7]&0xF0)<<4,
+		VBlank:      int(desc[6]) | int(desc[7]&0x0F)<<8,
+		HFrontPorch: int(desc[8]) | int(desc[11]&0xC0)<<2,
+		HSyncWidth:  int(desc[9]) | int(desc[11]&0x30)<<4,
+		VFrontPorch: int(desc[10]>>4) | int(desc[11]&0x0C)<<2,
+		VSyncWidth:  int(desc[10]&0x0F) | int(desc[11]&0x03)<<4,
+		HImageSize:  int(desc[12]) | int(desc[14]&0xF0)<<4,
+		VImageSize:  int(desc[13]) | int(desc[14]&0x0F)<<8,
+		HBorder:     int(desc[15]),
+		VBorder:     int(desc[16]),
+		Flags:       desc[17],
+	}, true
+}
+
+// Validate 檢查各欄位是否在 DTD 位元寬度內，且前廊與同步寬度不超過遮沒區間。
+func (t DetailedTiming) Validate() error {
+	limits := []struct {
+		name  string
+		value int
+		min   int
+		max   int
+	}{
+		{"pixel clock", t.PixelClock, 10, 655350},
+		{"h active", t.HActive, 1, 4095},
+		{"h blank", t.HBlank, 0, 4095},
+		{"h front porch", t.HFrontPorch, 0, 1023},
+		{"h sync width", t.HSyncWidth, 0, 1023},
+		{"v active", t.VActive, 1, 4095},
+		{"v blank", t.VBlank, 0, 4095},
+		{"v front porch", t.VFrontPorch, 0, 63},
+		{"v sync width", t.VSyncWidth, 0, 63},
+		{"h image size", t.HImageSize, 0, 4095},
+		{"v image size", t.VImageSize, 0, 4095},
+		{"h border", t.HBorder, 0, 255},
+		{"v border", t.VBorder, 0, 255},
+	}
+	for _, l := range limits {
+		if l.value < l.min || l.value > l.max {
+			return fmt.Errorf("%s %d out of range (%d-%d)", l.name, l.value, l.min, l.max)
+		}
+	}
+	if t.PixelClock%10 != 0 {
+		return fmt.Errorf("pixel clock %d kHz must be a multiple of 10 kHz", t.PixelClock)
+	}
+	if t.HFrontPorch+t.HSyncWidth > t.HBlank {
+		return fmt.Errorf("h front porch + sync width %d exceeds h blank %d", t.HFrontPorch+t.HSyncWidth, t.HBlank)
+	}
+	if t.VFrontPorch+t.VSyncWidth > t.VBlank {
+		return fmt.Errorf("v front porch + sync width %d exceeds v blank %d", t.VFrontPorch+t.VSyncWidth, t.VBlank)
+	}
+	return nil
+}
+
+// Encode 將時序編碼為 18 位元組描述符。
+func (t DetailedTiming) Encode() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	desc := make([]byte, 18)
+	binary.LittleEndian.PutUint16(desc[0:2], uint16(t.PixelClock/10))
+	desc[2] = byte(t.HActive)
+	desc[3] = byte(t.HBlank)
+	desc[4] = byte(t.HActive>>8)<<4 | byte(t.HBlank>>8)
+	desc[5] = byte(t.VActive)
+	desc[6] = byte(t.VBlank)
+	desc[7] = byte(t.VActive>>8)<<4 | byte(t.VBlank>>8)
+	desc[8] = byte(t.HFrontPorch)
+	desc[9] = byte(t.HSyncWidth)
+	desc[10] = byte(t.VFrontPorch&0x0F)<<4 | byte(t.VSyncWidth&0x0F)
+	desc[11] = byte(t.HFrontPorch>>8)<<6 | byte(t.HSyncWidth>>8)<<4 | byte(t.VFrontPorch>>4)<<2 | byte(t.VSyncWidth>>4)
+	desc[12] = byte(t.HImageSize)
+	desc[13] = byte(t.VImageSize)
+	desc[14] = byte(t.HImageSize>>8)<<4 | byte(t.VImageSize>>8)
+	desc[15] = byte(t.HBorder)
+	desc[16] = byte(t.VBorder)
+	desc[17] = t.Flags
+	return desc, nil
+}
+
+// HBackPorch 回傳水平後廊（遮沒扣除前廊與同步寬度）。
+func (t DetailedTiming) HBackPorch() int {
+	return t.HBlank - t.HFrontPorch - t.HSyncWidth
+}
+
+// VBackPorch 回傳垂直後廊（遮沒扣除前廊與同步寬度）。
+func (t DetailedTiming) VBackPorch() int {
+	return t.VBlank - t.VFrontPorch - t.VSyncWidth
+}
+
+// HTotal 回傳每行總像素數；邊框不計入，與 Linux DRM 的解讀相同。
+func (t DetailedTiming) HTotal() int {
+	return t.HActive + t.HBlank
+}
+
+// VTotal 回傳每場總行數；交錯模式下 VActive 與 VBlank 皆為單場的行數。
+func (t DetailedTiming) VTotal() int {
+	return t.VActive + t.VBlank
+}
+
+// Interlaced 回傳是否為交錯掃描（位元 7）。
+func (t DetailedTiming) Interlaced() bool {
+	return t.Flags&0x80 != 0
+}
+
+// HFreq 回傳水平頻率（kHz）。
+func (t DetailedTiming) HFreq() float64 {
+	if t.HTotal() == 0 {
+		return 0
+	}
+	return float64(t.PixelClock) / float64(t.HTotal())
+}
+
+// RefreshRate 以像素時脈與總像素數精確計算更新率（Hz）；交錯模式回傳場頻率，
+// 每幀為兩場加半行（2*VTotal+1 行）。
+func (t DetailedTiming) RefreshRate() float64 {
+	total := float64(t.HTotal() * t.VTotal())
+	if t.Interlaced() {
+		total = float64(t.HTotal()) * (float64(t.VTotal()) + 0.5)
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(t.PixelClock) * 1000 / total
+}
+
+// SyncType 回傳同步類型（位元 4~3），為 Sync 開頭的常數之一。
+func (t DetailedTiming) SyncType() string {
+	switch t.Flags >> 3 & 0x03 {
+	case 0:
+		return SyncAnalogComposite
+	case 1:
+		return SyncBipolarAnalogComposite
+	case 2:
+		return SyncDigitalComposite
+	default:
+		return SyncDigitalSeparate
+	}
+}
+
+// HSyncPolarity 回傳水平同步極性 "+" 或 "-"；類比同步沒有極性，回傳空字串。
+func (t DetailedTiming) HSyncPolarity() string {
+	if t.Flags&0x10 == 0 {
+		return ""
+	}
+	return polarity(t.Flags&0x02 != 0)
+}
+
+// VSyncPolarity 回傳垂直同步極性；只有數位分離同步有此欄位，其餘回傳空字串。
+func (t DetailedTiming) VSyncPolarity() string {
+	if t.SyncType() != SyncDigitalSeparate {
+		return ""
+	}
+	return polarity(t.Flags&0x04 != 0)
+}
+
+// Serrations 回傳複合同步是否帶有鋸齒脈衝（位元 2）；數位分離同步回傳 false。
+func (t DetailedTiming) Serrations() bool {
+	return t.SyncType() != SyncDigitalSeparate && t.Flags&0x04 != 0
+}
+
+// Stereo 回傳立體顯示模式（位元 6~5 與位元 0），無立體時回傳空字串。
+func (t DetailedTiming) Stereo() string {
+	switch t.Flags>>5&0x03<<1 | t.Flags&0x01 {
+	case 0b010:
+		return "field sequential, right on sync"
+	case 0b100:
+		return "field sequential, left on sync"
+	case 0b011:
+		return "2-way interleaved, right on even"
+	case 0b101:
+		return "2-way interleaved, left on even"
+	case 0b110:
+		return "4-way interleaved"
+	case 0b111:
+		return "side-by-side interleaved"
+	default:
+		return ""
+	}
+}
+
+func polarity(positive bool) string {
+	if positive {
+		return "+"
+	}
+	return "-"
+}
+
+// String 回傳完整的時序摘要，例如
+// "Detailed Timing: 1920x1080 @ 60.000Hz (PixelClock 148.50MHz), H 88/44/148 +, V 4/5/36 +, digital separate, 527x296mm"。
+func (t DetailedTiming) String() string {
+	// 交錯模式的 VActive 為單場行數，摘要以整幀高度呈現。
+	height, scan := t.VActive, ""
+	if t.Interlaced() {
+		height, scan = t.VActive*2, "i"
+	}
+	parts := []string{
+		fmt.Sprintf("Detailed Timing: %dx%d%s @ %.3fHz (PixelClock %.2fMHz)",
+			t.HActive, height, scan, t.RefreshRate(), float64(t.PixelClock)/1000),
+		strings.TrimSpace(fmt.Sprintf("H %d/%d/%d %s", t.HFrontPorch, t.HSyncWidth, t.HBackPorch(), t.HSyncPolarity())),
+		strings.TrimSpace(fmt.Sprintf("V %d/%d/%d %s", t.VFrontPorch, t.VSyncWidth, t.VBackPorch(), t.VSyncPolarity())),
+		t.SyncType(),
+		fmt.Sprintf("%dx%dmm", t.HImageSize, t.VImageSize),
+	}
+	if t.Serrations() {
+		parts = append(parts, "serrations")
+	}
+	if t.HBorder != 0 || t.VBorder != 0 {
+		parts = append(parts, fmt.Sprintf("border %d/%d", t.HBorder, t.VBorder))
+	}
+	if stereo := t.Stereo(); stereo != "" {
+		parts = append(parts, "stereo "+stereo)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MarshalJSON 除原始欄位外，另輸出後廊、總數、更新率與旗標解碼結果。
+func (t DetailedTiming) MarshalJSON() ([]byte, error) {
+	type raw DetailedTiming
+	return json.Marshal(struct {
+		raw
+		HBackPorch    int     `json:"h_back_porch"`
+		VBackPorch    int     `json:"v_back_porch"`
+		HTotal        int     `json:"h_total"`
+		VTotal        int     `json:"v_total"`
+		RefreshRate   float64 `json:"refresh_rate"`
+		Interlaced    bool    `json:"interlaced"`
+		SyncType      string  `json:"sync_type"`
+		HSyncPolarity string  `json:"h_sync_polarity,omitempty"`
+		VSyncPolarity string  `json:"v_sync_polarity,omitempty"`
+		Serrations    bool    `json:"serrations,omitempty"`
+		Stereo        string  `json:"stereo,omitempty"`
+	}{
+		raw:           raw(t),
+		HBackPorch:    t.HBackPorch(),
+		VBackPorch:    t.VBackPorch(),
+		HTotal:        t.HTotal(),
+		VTotal:        t.VTotal(),
+		RefreshRate:   t.RefreshRate(),
+		Interlaced:    t.Interlaced(),
+		SyncType:      t.SyncType(),
+		HSyncPolarity: t.HSyncPolarity(),
+		VSyncPolarity: t.VSyncPolarity(),
+		Serrations:    t.Serrations(),
+		Stereo:        t.Stereo(),
+	})
+}
@@ -0,0 +1,460 @@
+package display
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 描述符標籤。
+const (
+	descriptorTagMonitorName = 0xFC
+	descriptorTagRangeLimits = 0xFD
+	descriptorTagDummy       = 0x10
+)
+
+// ctaExtensionTag 為 CTA-861 擴充區塊的標籤。
+const ctaExtensionTag = 0x02
+
+// descriptorOffsets 為區塊 0 四個 18 位元組描述符的位移。
+var descriptorOffsets = []int{0x36, 0x48, 0x5A, 0x6C}
+
+// ErrNoFreeDescriptor 表示區塊 0 沒有可用來存放顯示器描述符的欄位。
+var ErrNoFreeDescriptor = errors.New("no free descriptor slot")
+
+// ErrNoCTAExtension 表示 EDID 中沒有指定的 CTA-861 擴充區塊。
+var ErrNoCTAExtension = errors.New("no CTA-861 extension block")
+
+// FixChecksums 重新計算每個完整區塊的檢查碼。
+func FixChecksums(edid []byte) {
+	for base := 0; base+128 <= len(edid); base += 128 {
+		var sum byte
+		for _, b := range edid[base : base+127] {
+			sum += b
+		}
+		edid[base+127] = -sum
+	}
+}
+
+// checkBaseBlock 確認資料至少包含區塊 0。
+func checkBaseBlock(edid []byte) error {
+	if len(edid) < 128 {
+		return fmt.Errorf("EDID data too short")
+	}
+	return nil
+}
+
+// SetManufacturerID 寫入三個大寫字母的製造商 ID（例如 "AUO"）。
+func SetManufacturerID(edid []byte, id string) error {
+	if err := checkBaseBlock(edid); err != nil {
+		return err
+	}
+	if len(id) != 3 {
+		return fmt.Errorf("manufacturer ID must be 3 letters, got %q", id)
+	}
+	var val uint16
+	for _, c := range []byte(strings.ToUpper(id)) {
+		if c < 'A' || c > 'Z' {
+			return fmt.Errorf("manufacturer ID %q must contain only letters A-Z", id)
+		}
+		val = val<<5 | uint16(c-'A'+1)
+	}
+	binary.BigEndian.PutUint16(edid[0x08:0x0A], val)
+	FixChecksums(edid)
+	return nil
+}
+
+// SetProductCode 寫入產品碼（小端序）。
+func SetProductCode(edid []byte, code uint16) error {
+	if err := checkBaseBlock(edid); err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(edid[0x0A:0x0C], code)
+	FixChecksums(edid)
+	return nil
+}
+
+// SetSerialNumber 寫入 32 位元序號（小端序）。
+func SetSerialNumber(edid []byte, serial uint32) error {
+	if err := checkBaseBlock(edid); err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint32(edid[0x0C:0x10], serial)
+	FixChecksums(edid)
+	return nil
+}
+
+// SetManufactureDate 寫入製造週次（0 表示未指定、1~54，或 255 表示 year 為型號年份）與年份。
+func SetManufactureDate(edid []byte, week, year int) error {
+	if err := checkBaseBlock(edid); err != nil {
+		return err
+	}
+	if (week < 0 || week > 54) && week != 0xFF {
+		return fmt.Errorf("week %d out of range (0-54 or 255)", week)
+	}
+	if year < 1990 || year > 1990+255 {
+		return fmt.Errorf("year %d out of range (1990-2245)", year)
+	}
+	edid[0x10] = byte(week)
+	edid[0x11] = byte(year - 1990)
+	FixChecksums(edid)
+	return nil
+}
+
+// displayDescriptorTag 回傳顯示器描述符的標籤；詳細時序描述符回傳 false。
+func displayDescriptorTag(desc []byte) (byte, bool) {
+	if binary.LittleEndian.Uint16(desc[0:2]) != 0 {
+		return 0, false
+	}
+	return desc[3], true
+}
+
+// findDescriptor 回傳具有指定標籤的描述符位移；找不到時回傳第一個空白（dummy）描述符，
+// 兩者皆無時回傳 ErrNoFreeDescriptor。
+func findDescriptor(edid []byte, tag byte) (int, bool, error) {
+	free := -1
+	for _, off := range descriptorOffsets {
+		got, ok := displayDescriptorTag(edid[off : off+18])
+		if !ok {
+			continue
+		}
+		if got == tag {
+			return off, true, nil
+		}
+		if got == descriptorTagDummy && free < 0 {
+			free = off
+		}
+	}
+	if free < 0 {
+		return 0, false, ErrNoFreeDescriptor
+	}
+	return free, false, nil
+}
+
+// SetMonitorName 寫入顯示器名稱描述符（最多 13 個可列印 ASCII 字元），
+// 沒有名稱描述符時使用第一個空白描述符。
+func SetMonitorName(edid []byte, name string) error {
+	if err := checkBaseBlock(edid); err != nil {
+		return err
+	}
+	if len(name) > 13 {
+		return fmt.Errorf("monitor name %q exceeds 13 characters", name)
+	}
+	for _, c := range []byte(name) {
+		if c < 0x20 || c > 0x7E {
+			return fmt.Errorf("monitor name %q contains non-printable ASCII", name)
+		}
+	}
+	off, _, err := findDescriptor(edid, descriptorTagMonitorName)
+	if err != nil {
+		return err
+	}
+	desc := edid[off : off+18]
+	copy(desc, []byte{0x00, 0x00, 0x00, descriptorTagMonitorName, 0x00})
+	writeDescriptorText(desc[5:], name)
+	FixChecksums(edid)
+	return nil
+}
+
+// writeDescriptorText 依 EDID 規範寫入文字：結尾補 0x0A，其餘補空白。
+func writeDescriptorText(dst []byte, text string) {
+	n := copy(dst, text)
+	for i := n; i < len(dst); i++ {
+		dst[i] = 0x20
+	}
+	if n < len(dst) {
+		dst[n] = 0x0A
+	}
+}
+
+// RangeLimits 為顯示器範圍限制描述符（標籤 0xFD）的內容。
+type RangeLimits struct {
+	MinVRate      int `json:"min_v_rate"`      // Hz
+	MaxVRate      int `json:"max_v_rate"`      // Hz
+	MinHRate      int `json:"min_h_rate"`      // kHz
+	MaxHRate      int `json:"max_h_rate"`      // kHz
+	MaxPixelClock int `json:"max_pixel_clock"` // MHz，以 10 MHz 為單位儲存
+}
+
+// ParseRangeLimits 讀取範圍限制描述符，不存在時回傳 false。
+// EDID 1.4 的 +255 Hz/kHz 位移旗標會一併計入。
+func ParseRangeLimits(edid []byte) (RangeLimits, bool) {
+	if len(edid) < 128 {
+		return RangeLimits{}, false
+	}
+	for _, off := range descriptorOffsets {
+		desc := edid[off : off+18]
+		if tag, ok := displayDescriptorTag(desc); !ok || tag != descriptorTagRangeLimits {
+			continue
+		}
+		r := RangeLimits{
+			MinVRate:      int(desc[5]),
+			MaxVRate:      int(desc[6]),
+			MinHRate:      int(desc[7]),
+			MaxHRate:      int(desc[8]),
+			MaxPixelClock: int(desc[9]) * 10,
+		}
+		flags := desc[4]
+		if flags&0x03 == 0x03 {
+			r.MinVRate += 255
+		}
+		if flags&0x03 >= 0x02 {
+			r.MaxVRate += 255
+		}
+		if flags&0x0C == 0x0C {
+			r.MinHRate += 255
+		}
+		if flags&0x0C >= 0x08 {
+			r.MaxHRate += 255
+		}
+		return r, true
+	}
+	return RangeLimits{}, false
+}
+
+// Validate 檢查範圍限制可否以描述符表示。
+func (r RangeLimits) Validate() error {
+	switch {
+	case r.MinVRate < 1 || r.MaxVRate > 510 || r.MinVRate > r.MaxVRate:
+		return fmt.Errorf("vertical rate %d-%d Hz is invalid", r.MinVRate, r.MaxVRate)
+	case r.MinHRate < 1 || r.MaxHRate > 510 || r.MinHRate > r.MaxHRate:
+		return fmt.Errorf("horizontal rate %d-%d kHz is invalid", r.MinHRate, r.MaxHRate)
+	case r.MaxPixelClock < 10 || r.MaxPixelClock > 2550:
+		return fmt.Errorf("max pixel clock %d MHz out of range (10-2550)", r.MaxPixelClock)
+	}
+	return nil
+}
+
+// SetRangeLimits 寫入範圍限制描述符；沒有時使用第一個空白描述符。像素時脈會無條件進位至 10 MHz。
+func SetRangeLimits(edid []byte, r RangeLimits) error {
+	if err := checkBaseBlock(edid); err != nil {
+		return err
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	off, exists, err := findDescriptor(edid, descriptorTagRangeLimits)
+	if err != nil {
+		return err
+	}
+	desc := edid[off : off+18]
+	if !exists {
+		// 新建的描述符宣告僅提供範圍限制，不含 GTF/CVT 次要時序參數。
+		copy(desc, []byte{0x00, 0x00, 0x00, descriptorTagRangeLimits, 0x00, 0, 0, 0, 0, 0, 0x01, 0x0A,
+			0x20, 0x20, 0x20, 0x20, 0x20, 0x20})
+	}
+
+	var flags byte
+	minV, maxV, minH, maxH := r.MinVRate, r.MaxVRate, r.MinHRate, r.MaxHRate
+	if maxV > 255 {
+		flags |= 0x02
+		maxV -= 255
+	}
+	if minV > 255 {
+		flags |= 0x01
+		minV -= 255
+	}
+	if maxH > 255 {
+		flags |= 0x08
+		maxH -= 255
+	}
+	if minH > 255 {
+		flags |= 0x04
+		minH -= 255
+	}
+	desc[4] = flags
+	desc[5], desc[6], desc[7], desc[8] = byte(minV), byte(maxV), byte(minH), byte(maxH)
+	desc[9] = byte((r.MaxPixelClock + 9) / 10)
+	FixChecksums(edid)
+	return nil
+}
+
+// DescriptorTiming 回傳區塊 0 第 slot 個描述符（0~3）的詳細時序，不是 DTD 時回傳 false。
+func DescriptorTiming(edid []byte, slot int) (DetailedTiming, bool) {
+	if len(edid) < 128 || slot < 0 || slot >= len(descriptorOffsets) {
+		return DetailedTiming{}, false
+	}
+	off := descriptorOffsets[slot]
+	return DecodeDetailedTiming(edid[off : off+18])
+}
+
+// SetDescriptorTiming 將詳細時序寫入區塊 0 第 slot 個描述符（0~3），會取代原本的內容。
+func SetDescriptorTiming(edid []byte, slot int, t DetailedTiming) error {
+	if err := checkBaseBlock(edid); err != nil {
+		return err
+	}
+	if slot < 0 || slot >= len(descriptorOffsets) {
+		return fmt.Errorf("descriptor slot %d out of range (0-3)", slot)
+	}
+	desc, err := t.Encode()
+	if err != nil {
+		return err
+	}
+	copy(edid[descriptorOffsets[slot]:], desc)
+	FixChecksums(edid)
+	return nil
+}
+
+// CTA 資料區塊標籤。
+const (
+	CTATagAudio    = 1
+	CTATagVideo    = 2
+	CTATagVendor   = 3
+	CTATagSpeaker  = 4
+	CTATagVESADTC  = 5
+	CTATagExtended = 7
+)
+
+// CTADataBlock 為 CTA-861 擴充區塊中的一個資料區塊；延伸標籤（7）的第一個位元組為延伸標籤碼。
+type CTADataBlock struct {
+	Tag  byte   `json:"tag"`
+	Data []byte `json:"data"`
+}
+
+// Name 回傳資料區塊種類的名稱。
+func (b CTADataBlock) Name() string {
+	switch b.Tag {
+	case CTATagAudio:
+		return "Audio"
+	case CTATagVideo:
+		return "Video"
+	case CTATagVendor:
+		return "Vendor-Specific"
+	case CTATagSpeaker:
+		return "Speaker Allocation"
+	case CTATagVESADTC:
+		return "VESA DTC"
+	case CTATagExtended:
+		if len(b.Data) > 0 {
+			return fmt.Sprintf("Extended (0x%02X)", b.Data[0])
+		}
+		return "Extended"
+	default:
+		return fmt.Sprintf("Reserved (%d)", b.Tag)
+	}
+}
+
+// CTABlocks 回傳所有 CTA-861 擴充區塊的區塊編號。
+func CTABlocks(edid []byte) []int {
+	var blocks []int
+	for block := 1; (block+1)*128 <= len(edid); block++ {
+		if edid[block*128] == ctaExtensionTag {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// ctaBlock 取出第 block 個區塊並確認為 CTA-861 擴充區塊。
+func ctaBlock(edid []byte, block int) ([]byte, error) {
+	if block < 1 || (block+1)*128 > len(edid) || edid[block*128] != ctaExtensionTag {
+		return nil, fmt.Errorf("block %d: %w", block, ErrNoCTAExtension)
+	}
+	return edid[block*128 : (block+1)*128], nil
+}
+
+// CTADataBlocks 解析第 block 個區塊的 CTA 資料區塊集合。
+func CTADataBlocks(edid []byte, block int) ([]CTADataBlock, error) {
+	ext, err := ctaBlock(edid, block)
+	if err != nil {
+		return nil, err
+	}
+	end := int(ext[2])
+	if end == 0 {
+		return nil, nil
+	}
+	if end < 4 || end > 127 {
+		return nil, fmt.Errorf("block %d: invalid DTD offset %d", block, end)
+	}
+	var blocks []CTADataBlock
+	for pos := 4; pos < end; {
+		tag, length := ext[pos]>>5, int(ext[pos]&0x1F)
+		if pos+1+length > end {
+			return blocks, fmt.Errorf("block %d: data block at 0x%02X overruns DTD offset", block, pos)
+		}
+		blocks = append(blocks, CTADataBlock{Tag: tag, Data: append([]byte(nil), ext[pos+1:pos+1+length]...)})
+		pos += 1 + length
+	}
+	return blocks, nil
+}
+
+// SetCTADataBlocks 以 blocks 取代第 block 個區塊的資料區塊集合，保留其後的 DTD
+// 並更新 DTD 位移與檢查碼；總長度超過 127 位元組時回傳錯誤。
+func SetCTADataBlocks(edid []byte, block int, blocks []CTADataBlock) error {
+	ext, err := ctaBlock(edid, block)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for i, b := range blocks {
+		if b.Tag > 7 {
+			return fmt.Errorf("data block %d: tag %d out of range (0-7)", i+1, b.Tag)
+		}
+		if len(b.Data) > 31 {
+			return fmt.Errorf("data block %d: length %d exceeds 31 bytes", i+1, len(b.Data))
+		}
+		payload = append(payload, b.Tag<<5|byte(len(b.Data)))
+		payload = append(payload, b.Data...)
+	}
+
+	// 保留原本的 DTD（像素時脈不為 0 的 18 位元組描述符）。
+	var dtds []byte
+	if start := int(ext[2]); start >= 4 {
+		for pos := start; pos+18 <= 127; pos += 18 {
+			if ext[pos] == 0 && ext[pos+1] == 0 {
+				break
+			}
+			dtds = append(dtds, ext[pos:pos+18]...)
+		}
+	}
+	if 4+len(payload)+len(dtds) > 127 {
+		return fmt.Errorf("data blocks (%d bytes) and DTDs (%d bytes) exceed the 123-byte CTA payload", len(payload), len(dtds))
+	}
+
+	body := append(append([]byte(nil), payload...), dtds...)
+	for i := 4; i < 127; i++ {
+		ext[i] = 0
+	}
+	copy(ext[4:], body)
+	ext[2] = byte(4 + len(payload))
+	if len(payload) == 0 && len(dtds) == 0 {
+		ext[2] = 0
+	}
+	FixChecksums(edid)
+	return nil
+}
+
+// AppendCTAExtension 新增一個空白的 CTA-861（修訂版 3）擴充區塊並更新擴充區塊數。
+func AppendCTAExtension(edid []byte) ([]byte, error) {
+	if err := checkBaseBlock(edid); err != nil {
+		return nil, err
+	}
+	if edid[0x7E] == 0xFF {
+		return nil, errors.New("extension count already at maximum")
+	}
+	blocks := len(edid) / 128
+	out := append([]byte(nil), edid[:blocks*128]...)
+	ext := make([]byte, 128)
+	ext[0], ext[1], ext[2] = ctaExtensionTag, 0x03, 0x04
+	out = append(out, ext...)
+	out[0x7E] = byte(blocks)
+	FixChecksums(out)
+	return out, nil
+}
+
+// MonitorName 回傳顯示器名稱描述符的內容，不存在時回傳空字串。
+func MonitorName(edid []byte) string {
+	if len(edid) < 128 {
+		return ""
+	}
+	for _, off := range descriptorOffsets {
+		desc := edid[off : off+18]
+		if tag, ok := displayDescriptorTag(desc); ok && tag == descriptorTagMonitorName {
+			text, _, _ := strings.Cut(string(desc[5:18]), "\n")
+			return strings.TrimRight(text, " ")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package display
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EDIDBytes 為原始 EDID，JSON 中以連續的大寫十六進位字串表示，方便資料庫歸檔與比對。
+type EDIDBytes []byte
+
+// MarshalJSON 輸出 "00FFFFFFFFFFFF00..."。
+func (b EDIDBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToUpper(hex.EncodeToString(b)))
+}
+
+// UnmarshalJSON 接受十六進位字串，可含空白。
+func (b *EDIDBytes) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	raw, err := hex.DecodeString(strings.Join(strings.Fields(text), ""))
+	if err != nil {
+		return fmt.Errorf("invalid EDID hex: %w", err)
+	}
+	*b = raw
+	return nil
+}
+
+// Export 為顯示器清單的 JSON 匯出格式。
+type Export struct {
+	GeneratedAt time.Time  `json:"generated_at"`
+	Count       int        `json:"count"`
+	Displays    []*Display `json:"displays"`
+	Error       string     `json:"error,omitempty"` // 列舉時的最後錯誤
+}
+
+// MarshalDisplays 將顯示器清單序列化為縮排的 JSON；enumErr 為列舉時的錯誤，可為 nil。
+func MarshalDisplays(displays []*Display, enumErr error) ([]byte, error) {
+	export := Export{GeneratedAt: time.Now(), Count: len(displays), Displays: displays}
+	if export.Displays == nil {
+		export.Displays = []*Display{}
+	}
+	if enumErr != nil {
+		export.Error = enumErr.Error()
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,150 @@
+package display
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed pnp_ids.txt
+var pnpIDs string
+
+// PanelModel 為面板型號表的一筆資料，以製造商 ID 與產品碼對應面板料號。
+type PanelModel struct {
+	ManufacturerID string `json:"manufacturer_id"`
+	ProductCode    uint16 `json:"product_code"`
+	Model          string `json:"model"`
+	Description    string `json:"description,omitempty"`
+}
+
+// PanelTable 為使用者維護的面板型號檔格式；Vendors 可補充或覆寫內建的製造商名稱。
+//
+//	{
+//	  "vendors": {"XYZ": "Example Display"},
+//	  "panels": [{"manufacturer_id": "AUO", "product_code": "0x123D", "model": "B156HAN02.1"}]
+//	}
+type PanelTable struct {
+	Vendors map[string]string `json:"vendors,omitempty"`
+	Panels  []PanelModel      `json:"panels"`
+}
+
+// UnmarshalJSON 讓 product_code 可寫成數字或 "0x123D" 之類的字串。
+func (m *PanelModel) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ManufacturerID string          `json:"manufacturer_id"`
+		ProductCode    json.RawMessage `json:"product_code"`
+		Model          string          `json:"model"`
+		Description    string          `json:"description"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	text := string(raw.ProductCode)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	code, err := strconv.ParseUint(strings.TrimSpace(text), 0, 16)
+	if err != nil {
+		return fmt.Errorf("panel %s product code %s: %w", raw.Model, raw.ProductCode, err)
+	}
+	*m = PanelModel{
+		ManufacturerID: strings.ToUpper(strings.TrimSpace(raw.ManufacturerID)),
+		ProductCode:    uint16(code),
+		Model:          raw.Model,
+		Description:    raw.Description,
+	}
+	return nil
+}
+
+type panelKey struct {
+	manufacturerID string
+	productCode    uint16
+}
+
+var (
+	vendorMu      sync.RWMutex
+	vendorNames   = parsePNPIDs(pnpIDs)
+	customVendors = map[string]string{}
+	panelModels   = map[panelKey]PanelModel{}
+)
+
+// parsePNPIDs 解析內嵌的「ID<Tab>名稱」清單，略過空行與 # 開頭的註解。
+func parsePNPIDs(text string) map[string]string {
+	names := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		names[strings.TrimSpace(id)] = strings.TrimSpace(name)
+	}
+	return names
+}
+
+// VendorName 回傳製造商 ID 對應的公司名稱，使用者面板型號檔中的名稱優先；查無資料時回傳空字串。
+func VendorName(manufacturerID string) string {
+	id := strings.ToUpper(strings.TrimSpace(manufacturerID))
+	vendorMu.RLock()
+	defer vendorMu.RUnlock()
+	if name, ok := customVendors[id]; ok {
+		return name
+	}
+	return vendorNames[id]
+}
+
+// LookupPanelModel 依製造商 ID 與產品碼查詢面板型號。
+func LookupPanelModel(manufacturerID string, productCode uint16) (PanelModel, bool) {
+	vendorMu.RLock()
+	defer vendorMu.RUnlock()
+	model, ok := panelModels[panelKey{strings.ToUpper(manufacturerID), productCode}]
+	return model, ok
+}
+
+// RegisterPanelModel 新增或覆寫一筆面板型號。
+func RegisterPanelModel(model PanelModel) {
+	model.ManufacturerID = strings.ToUpper(strings.TrimSpace(model.ManufacturerID))
+	vendorMu.Lock()
+	defer vendorMu.Unlock()
+	panelModels[panelKey{model.ManufacturerID, model.ProductCode}] = model
+}
+
+// LoadPanelModels 讀取使用者的面板型號檔，取代先前載入的製造商名稱與面板型號。
+// 檔案不存在時回傳的錯誤可用 errors.Is(err, fs.ErrNotExist) 判斷。
+func LoadPanelModels(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var table PanelTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	vendors := make(map[string]string, len(table.Vendors))
+	for id, name := range table.Vendors {
+		id = strings.ToUpper(strings.TrimSpace(id))
+		if len(id) != 3 {
+			return fmt.Errorf("%s: vendor ID %q must be three letters", path, id)
+		}
+		vendors[id] = name
+	}
+	models := make(map[panelKey]PanelModel, len(table.Panels))
+	for _, m := range table.Panels {
+		models[panelKey{m.ManufacturerID, m.ProductCode}] = m
+	}
+
+	vendorMu.Lock()
+	defer vendorMu.Unlock()
+	customVendors, panelModels = vendors, models
+	return nil
+}
@@ -0,0 +1,62 @@
+package display
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVendorName(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "AUO", want: "AU Optronics"},
+		{id: "BOE", want: "BOE Technology Group"},
+		{id: "CMN", want: "Chimei Innolux Corporation"},
+		{id: "SDC", want: "Samsung Display"},
+		{id: "LGD", want: "LG Display"},
+		{id: "CSO", want: "TCL China Star Optoelectronics Technology (CSOT)"},
+		{id: "MNE", want: "TCL China Star Optoelectronics Technology (CSOT)"},
+		{id: " auo ", want: "AU Optronics"},
+		{id: "ZZZ", want: ""},
+	}
+	for _, tt := range tests {
+		if got := VendorName(tt.id); got != tt.want {
+			t.Errorf("VendorName(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestLoadPanelModels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "panels.json")
+	table := `{
+  "vendors": {"xyz": "Example Display", "AUO": "AU Optronics Corp."},
+  "panels": [{"manufacturer_id": "auo", "product_code": "0x123D", "model": "B156HAN02.1"}]
+}`
+	if err := os.WriteFile(path, []byte(table), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadPanelModels(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		vendorMu.Lock()
+		customVendors, panelModels = map[string]string{}, map[panelKey]PanelModel{}
+		vendorMu.Unlock()
+	})
+
+	if got := VendorName("XYZ"); got != "Example Display" {
+		t.Errorf("VendorName(XYZ) = %q, want custom vendor", got)
+	}
+	if got := VendorName("AUO"); got != "AU Optronics Corp." {
+		t.Errorf("VendorName(AUO) = %q, want override from panel table", got)
+	}
+	model, ok := LookupPanelModel("AUO", 0x123D)
+	if !ok || model.Model != "B156HAN02.1" {
+		t.Errorf("LookupPanelModel(AUO, 0x123D) = %+v, %v", model, ok)
+	}
+	if _, ok := LookupPanelModel("AUO", 0x123E); ok {
+		t.Error("LookupPanelModel matched an unknown product code")
+	}
+}
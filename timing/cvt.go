@@ -0,0 +1,169 @@
+package timing
+
+import "math"
+
+// CVT 與 GTF 共用常數（VESA CVT 1.2 / GTF 1.1 預設值）。
+const (
+	cellGran     = 8     // 水平字元格粒度（像素）
+	minVPorch    = 3     // CVT 最小垂直前廊（行）
+	minVBPorch   = 6     // 最小垂直後廊（行）
+	minVSyncBP   = 550.0 // 垂直同步加後廊的最短時間（µs）
+	dutyC        = 30.0  // C' = (C-J)*K/256 + J
+	dutyM        = 300.0 // M' = K/256 * M
+	hSyncPercent = 0.08  // 水平同步佔總像素比例
+	rbMinVBlank  = 460.0 // Reduced Blanking 最短垂直遮沒（µs）
+	rbVFPorch    = 3     // RB v1 垂直前廊（行）
+	gtfMinPorch  = 1     // GTF 最小垂直前廊（行）
+	gtfVSync     = 3     // GTF 垂直同步（行）
+)
+
+// cvtVSync 依長寬比回傳 CVT 垂直同步行數，非標準比例為 10。
+func cvtVSync(h, v int) int {
+	switch {
+	case v*4/3 == h:
+		return 4
+	case v*16/9 == h:
+		return 5
+	case v*16/10 == h:
+		return 6
+	case v*5/4 == h, v*15/9 == h:
+		return 7
+	default:
+		return 10
+	}
+}
+
+// roundedActive 回傳依字元格向下取整的水平有效像素，供遮沒計算使用。
+func roundedActive(h int) float64 {
+	return float64(h / cellGran * cellGran)
+}
+
+// CVT 以標準遮沒公式產生時序；像素時脈以 0.25 MHz 為步進向下取整。
+// 有效像素維持輸入值，僅遮沒計算依規格以 8 像素粒度取整。
+func CVT(hActive, vActive int, refresh float64) Timing {
+	vSync := cvtVSync(hActive, vActive)
+	hPeriod := (1e6/refresh - minVSyncBP) / float64(vActive+minVPorch)
+
+	vSyncBP := int(math.Floor(minVSyncBP/hPeriod)) + 1
+	vSyncBP = max(vSyncBP, vSync+minVBPorch)
+
+	duty := max(dutyC-dutyM*hPeriod/1000, 20)
+	active := roundedActive(hActive)
+	hBlank := int(math.Floor(active*duty/(100-duty)/(2*cellGran))) * 2 * cellGran
+	hTotal := hActive + hBlank
+	hSync := int(math.Floor(hSyncPercent*float64(hTotal)/cellGran)) * cellGran
+
+	clockMHz := 0.25 * math.Floor(float64(hTotal)/hPeriod/0.25)
+	return Timing{
+		Method:        MethodCVT,
+		PixelClock:    int(math.Round(clockMHz * 1000)),
+		HActive:       hActive,
+		HFrontPorch:   hBlank/2 - hSync,
+		HSync:         hSync,
+		HBackPorch:    hBlank / 2,
+		VActive:       vActive,
+		VFrontPorch:   minVPorch,
+		VSync:         vSync,
+		VBackPorch:    vSyncBP - vSync,
+		VSyncPositive: true,
+	}
+}
+
+// rbVBlankLines 回傳 Reduced Blanking 的估計行週期（µs）與垂直遮沒行數。
+func rbVBlankLines(vActive int, refresh float64, minLines int) (float64, int) {
+	hPeriod := (1e6/refresh - rbMinVBlank) / float64(vActive)
+	lines := int(math.Floor(rbMinVBlank/hPeriod)) + 1
+	return hPeriod, max(lines, minLines)
+}
+
+// CVTRB 以 Reduced Blanking v1 產生時序：水平遮沒固定 160 像素（48/32/80）。
+func CVTRB(hActive, vActive int, refresh float64) Timing {
+	vSync := cvtVSync(hActive, vActive)
+	_, vBlank := rbVBlankLines(vActive, refresh, rbVFPorch+vSync+minVBPorch)
+	const hFP, hSync, hBP = 48, 32, 80
+
+	hTotal := hActive + hFP + hSync + hBP
+	vTotal := vActive + vBlank
+	clockMHz := 0.25 * math.Floor(refresh*float64(vTotal*hTotal)/1e6/0.25)
+	return Timing{
+		Method:        MethodCVTRB,
+		PixelClock:    int(math.Round(clockMHz * 1000)),
+		HActive:       hActive,
+		HFrontPorch:   hFP,
+		HSync:         hSync,
+		HBackPorch:    hBP,
+		VActive:       vActive,
+		VFrontPorch:   rbVFPorch,
+		VSync:         vSync,
+		VBackPorch:    vBlank - rbVFPorch - vSync,
+		HSyncPositive: true,
+	}
+}
+
+// CVTRB2 以 Reduced Blanking v2 產生時序：水平遮沒 80 像素（8/32/40），
+// 垂直同步 8 行、後廊 6 行，前廊吸收剩餘行數；像素時脈以 1 kHz 向下取整。
+func CVTRB2(hActive, vActive int, refresh float64) Timing {
+	t := cvtRB23(hActive, vActive, refresh, 0, math.Floor)
+	t.Method = MethodRBv2
+	return t
+}
+
+// CVTRB3 以 Reduced Blanking v3 產生時序；extraHBlank 為額外水平遮沒
+// （0 至 120，8 的倍數，加在後廊），像素時脈以 1 kHz 向上取整。
+func CVTRB3(hActive, vActive int, refresh float64, extraHBlank int) Timing {
+	extraHBlank = min(max(extraHBlank, 0), 120) / 8 * 8
+	t := cvtRB23(hActive, vActive, refresh, extraHBlank, math.Ceil)
+	t.Method = MethodRBv3
+	return t
+}
+
+func cvtRB23(hActive, vActive int, refresh float64, extraHBlank int, round func(float64) float64) Timing {
+	const hFP, hSync, hBP, vSync = 8, 32, 40, 8
+	_, vBlank := rbVBlankLines(vActive, refresh, 1+vSync+minVBPorch)
+
+	hTotal := hActive + hFP + hSync + hBP + extraHBlank
+	vTotal := vActive + vBlank
+	clockKHz := round(refresh * float64(vTotal*hTotal) / 1000)
+	return Timing{
+		PixelClock:    int(clockKHz),
+		HActive:       hActive,
+		HFrontPorch:   hFP,
+		HSync:         hSync,
+		HBackPorch:    hBP + extraHBlank,
+		VActive:       vActive,
+		VFrontPorch:   vBlank - vSync - minVBPorch,
+		VSync:         vSync,
+		VBackPorch:    minVBPorch,
+		HSyncPositive: true,
+	}
+}
+
+// GTF 以 GTF 預設參數（M=600、C=40、K=128、J=20）產生時序。
+func GTF(hActive, vActive int, refresh float64) Timing {
+	hPeriodEst := (1e6/refresh - minVSyncBP) / float64(vActive+gtfMinPorch)
+	vSyncBP := int(math.Round(minVSyncBP / hPeriodEst))
+	vTotal := vActive + vSyncBP + gtfMinPorch
+
+	vFieldEst := 1e6 / hPeriodEst / float64(vTotal)
+	hPeriod := hPeriodEst / (refresh / vFieldEst)
+
+	duty := dutyC - dutyM*hPeriod/1000
+	active := roundedActive(hActive)
+	hBlank := int(math.Round(active*duty/(100-duty)/(2*cellGran))) * 2 * cellGran
+	hTotal := hActive + hBlank
+	hSync := int(math.Round(hSyncPercent*float64(hTotal)/cellGran)) * cellGran
+
+	return Timing{
+		Method:        MethodGTF,
+		PixelClock:    int(math.Round(float64(hTotal) / hPeriod * 1000)),
+		HActive:       hActive,
+		HFrontPorch:   hBlank/2 - hSync,
+		HSync:         hSync,
+		HBackPorch:    hBlank / 2,
+		VActive:       vActive,
+		VFrontPorch:   gtfMinPorch,
+		VSync:         gtfVSync,
+		VBackPorch:    vSyncBP - gtfVSync,
+		VSyncPositive: true,
+	}
+}
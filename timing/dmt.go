@@ -0,0 +1,57 @@
+package timing
+
+import "math"
+
+// dmtMode 為 VESA DMT 表中的一筆時序；Refresh 為標稱更新率。
+type dmtMode struct {
+	Refresh float64
+	Timing  Timing
+}
+
+// dmtModes 收錄常用的 VESA DMT 1.13 時序。
+var dmtModes = []dmtMode{
+	{60, Timing{PixelClock: 25175, HActive: 640, HFrontPorch: 16, HSync: 96, HBackPorch: 48, VActive: 480, VFrontPorch: 10, VSync: 2, VBackPorch: 33}},
+	{75, Timing{PixelClock: 31500, HActive: 640, HFrontPorch: 16, HSync: 64, HBackPorch: 120, VActive: 480, VFrontPorch: 1, VSync: 3, VBackPorch: 16}},
+	{60, Timing{PixelClock: 40000, HActive: 800, HFrontPorch: 40, HSync: 128, HBackPorch: 88, VActive: 600, VFrontPorch: 1, VSync: 4, VBackPorch: 23, HSyncPositive: true, VSyncPositive: true}},
+	{75, Timing{PixelClock: 49500, HActive: 800, HFrontPorch: 16, HSync: 80, HBackPorch: 160, VActive: 600, VFrontPorch: 1, VSync: 3, VBackPorch: 21, HSyncPositive: true, VSyncPositive: true}},
+	{60, Timing{PixelClock: 65000, HActive: 1024, HFrontPorch: 24, HSync: 136, HBackPorch: 160, VActive: 768, VFrontPorch: 3, VSync: 6, VBackPorch: 29}},
+	{75, Timing{PixelClock: 78750, HActive: 1024, HFrontPorch: 16, HSync: 96, HBackPorch: 176, VActive: 768, VFrontPorch: 1, VSync: 3, VBackPorch: 28, HSyncPositive: true, VSyncPositive: true}},
+	{60, Timing{PixelClock: 74250, HActive: 1280, HFrontPorch: 110, HSync: 40, HBackPorch: 220, VActive: 720, VFrontPorch: 5, VSync: 5, VBackPorch: 20, HSyncPositive: true, VSyncPositive: true}},
+	{60, Timing{PixelClock: 71000, HActive: 1280, HFrontPorch: 48, HSync: 32, HBackPorch: 80, VActive: 800, VFrontPorch: 3, VSync: 6, VBackPorch: 14, HSyncPositive: true}},
+	{60, Timing{PixelClock: 108000, HActive: 1280, HFrontPorch: 48, HSync: 112, HBackPorch: 248, VActive: 1024, VFrontPorch: 1, VSync: 3, VBackPorch: 38, HSyncPositive: true, VSyncPositive: true}},
+	{75, Timing{PixelClock: 135000, HActive: 1280, HFrontPorch: 16, HSync: 144, HBackPorch: 248, VActive: 1024, VFrontPorch: 1, VSync: 3, VBackPorch: 38, HSyncPositive: true, VSyncPositive: true}},
+	{60, Timing{PixelClock: 85500, HActive: 1366, HFrontPorch: 70, HSync: 143, HBackPorch: 213, VActive: 768, VFrontPorch: 3, VSync: 3, VBackPorch: 24, HSyncPositive: true, VSyncPositive: true}},
+	{60, Timing{PixelClock: 106500, HActive: 1440, HFrontPorch: 80, HSync: 152, HBackPorch: 232, VActive: 900, VFrontPorch: 3, VSync: 6, VBackPorch: 25, VSyncPositive: true}},
+	{60, Timing{PixelClock: 108000, HActive: 1600, HFrontPorch: 24, HSync: 80, HBackPorch: 96, VActive: 900, VFrontPorch: 1, VSync: 3, VBackPorch: 96, HSyncPositive: true, VSyncPositive: true}},
+	{60, Timing{PixelClock: 146250, HActive: 1680, HFrontPorch: 104, HSync: 176, HBackPorch: 280, VActive: 1050, VFrontPorch: 3, VSync: 6, VBackPorch: 30, VSyncPositive: true}},
+	{60, Timing{PixelClock: 148500, HActive: 1920, HFrontPorch: 88, HSync: 44, HBackPorch: 148, VActive: 1080, VFrontPorch: 4, VSync: 5, VBackPorch: 36, HSyncPositive: true, VSyncPositive: true}},
+	{60, Timing{PixelClock: 154000, HActive: 1920, HFrontPorch: 48, HSync: 32, HBackPorch: 80, VActive: 1200, VFrontPorch: 3, VSync: 6, VBackPorch: 26, HSyncPositive: true}},
+	{60, Timing{PixelClock: 268500, HActive: 2560, HFrontPorch: 48, HSync: 32, HBackPorch: 80, VActive: 1600, VFrontPorch: 3, VSync: 6, VBackPorch: 37, HSyncPositive: true}},
+	{60, Timing{PixelClock: 533250, HActive: 3840, HFrontPorch: 48, HSync: 32, HBackPorch: 80, VActive: 2160, VFrontPorch: 3, VSync: 5, VBackPorch: 54, HSyncPositive: true}},
+}
+
+// DMT 查詢 DMT 表中解析度相同且更新率最接近（相差 1 Hz 內）的時序。
+func DMT(hActive, vActive int, refresh float64) (Timing, bool) {
+	best, bestDelta := Timing{}, 1.0
+	found := false
+	for _, m := range dmtModes {
+		if m.Timing.HActive != hActive || m.Timing.VActive != vActive {
+			continue
+		}
+		if delta := math.Abs(m.Refresh - refresh); delta <= bestDelta {
+			best, bestDelta, found = m.Timing, delta, true
+		}
+	}
+	best.Method = MethodDMT
+	return best, found
+}
+
+// DMTModes 回傳 DMT 表中所有時序的複本。
+func DMTModes() []Timing {
+	modes := make([]Timing, len(dmtModes))
+	for i, m := range dmtModes {
+		modes[i] = m.Timing
+		modes[i].Method = MethodDMT
+	}
+	return modes
+}
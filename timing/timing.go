@@ -0,0 +1,195 @@
+// Package timing 依 VESA CVT（含 Reduced Blanking v1/v2/v3）、GTF 公式與 DMT 表
+// 由解析度與更新率產生顯示時序，並與 EDID 的 18 位元組詳細時序描述符（DTD）互相轉換。
+package timing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+// Method 為時序產生方式。
+type Method string
+
+const (
+	MethodCVT   Method = "cvt"     // CVT 標準遮沒
+	MethodCVTRB Method = "cvt-rb"  // CVT Reduced Blanking v1
+	MethodRBv2  Method = "cvt-rb2" // CVT Reduced Blanking v2
+	MethodRBv3  Method = "cvt-rb3" // CVT Reduced Blanking v3
+	MethodGTF   Method = "gtf"     // GTF 預設參數
+	MethodDMT   Method = "dmt"     // DMT 表查詢
+)
+
+// Methods 依序列出所有產生方式。
+var Methods = []Method{MethodCVT, MethodCVTRB, MethodRBv2, MethodRBv3, MethodGTF, MethodDMT}
+
+// DTD 旗標位元（第 17 位元組）。
+const (
+	flagDigitalSep    = 0x18 // 數位分離同步
+	flagVSyncPositive = 0x04
+	flagHSyncPositive = 0x02
+)
+
+// Timing 為逐行掃描的顯示時序；PixelClock 單位為 kHz。
+type Timing struct {
+	Method        Method `json:"method,omitempty"`
+	PixelClock    int    `json:"pixel_clock"`
+	HActive       int    `json:"h_active"`
+	HFrontPorch   int    `json:"h_front_porch"`
+	HSync         int    `json:"h_sync"`
+	HBackPorch    int    `json:"h_back_porch"`
+	VActive       int    `json:"v_active"`
+	VFrontPorch   int    `json:"v_front_porch"`
+	VSync         int    `json:"v_sync"`
+	VBackPorch    int    `json:"v_back_porch"`
+	HSyncPositive bool   `json:"h_sync_positive"`
+	VSyncPositive bool   `json:"v_sync_positive"`
+	Interlaced    bool   `json:"interlaced,omitempty"`
+}
+
+// HBlank 回傳水平遮沒像素數。
+func (t Timing) HBlank() int { return t.HFrontPorch + t.HSync + t.HBackPorch }
+
+// VBlank 回傳垂直遮沒行數。
+func (t Timing) VBlank() int { return t.VFrontPorch + t.VSync + t.VBackPorch }
+
+// HTotal 回傳每行總像素數。
+func (t Timing) HTotal() int { return t.HActive + t.HBlank() }
+
+// VTotal 回傳每幀總行數。
+func (t Timing) VTotal() int { return t.VActive + t.VBlank() }
+
+// HFreq 回傳水平頻率（kHz）。
+func (t Timing) HFreq() float64 {
+	if t.HTotal() == 0 {
+		return 0
+	}
+	return float64(t.PixelClock) / float64(t.HTotal())
+}
+
+// RefreshRate 回傳以像素時脈與總像素數精確計算的更新率（Hz）。
+func (t Timing) RefreshRate() float64 {
+	total := t.HTotal() * t.VTotal()
+	if total == 0 {
+		return 0
+	}
+	return float64(t.PixelClock) * 1000 / float64(total)
+}
+
+// String 回傳例如 "1920x1080@60.000Hz 148.500MHz (cvt-rb)" 的摘要。
+func (t Timing) String() string {
+	s := fmt.Sprintf("%dx%d@%.3fHz %.3fMHz", t.HActive, t.VActive, t.RefreshRate(), float64(t.PixelClock)/1000)
+	if t.Method != "" {
+		s += fmt.Sprintf(" (%s)", t.Method)
+	}
+	return s
+}
+
+// ParseMethod 解析產生方式名稱，不分大小寫。
+func ParseMethod(name string) (Method, error) {
+	m := Method(strings.ToLower(strings.TrimSpace(name)))
+	for _, known := range Methods {
+		if m == known {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("timing: unknown method %q", name)
+}
+
+// Generate 以指定方式由解析度與更新率產生時序。
+func Generate(method Method, hActive, vActive int, refresh float64) (Timing, error) {
+	if hActive <= 0 || vActive <= 0 || refresh <= 0 {
+		return Timing{}, fmt.Errorf("timing: invalid mode %dx%d@%g", hActive, vActive, refresh)
+	}
+	switch method {
+	case MethodCVT:
+		return CVT(hActive, vActive, refresh), nil
+	case MethodCVTRB:
+		return CVTRB(hActive, vActive, refresh), nil
+	case MethodRBv2:
+		return CVTRB2(hActive, vActive, refresh), nil
+	case MethodRBv3:
+		return CVTRB3(hActive, vActive, refresh, 0), nil
+	case MethodGTF:
+		return GTF(hActive, vActive, refresh), nil
+	case MethodDMT:
+		t, ok := DMT(hActive, vActive, refresh)
+		if !ok {
+			return Timing{}, fmt.Errorf("timing: %dx%d@%g is not a DMT mode", hActive, vActive, refresh)
+		}
+		return t, nil
+	default:
+		return Timing{}, fmt.Errorf("timing: unknown method %q", method)
+	}
+}
+
+// ToDetailedTiming 轉換為 DTD 欄位；DTD 的像素時脈以 10 kHz 為單位，會四捨五入。
+// hImage 與 vImage 為影像尺寸（mm）。
+func (t Timing) ToDetailedTiming(hImage, vImage int) (display.DetailedTiming, error) {
+	if t.Interlaced {
+		return display.DetailedTiming{}, fmt.Errorf("timing: interlaced timings are not supported")
+	}
+	flags := byte(flagDigitalSep)
+	if t.VSyncPositive {
+		flags |= flagVSyncPositive
+	}
+	if t.HSyncPositive {
+		flags |= flagHSyncPositive
+	}
+	dtd := display.DetailedTiming{
+		PixelClock:  int(math.Round(float64(t.PixelClock)/10)) * 10,
+		HActive:     t.HActive,
+		HBlank:      t.HBlank(),
+		HFrontPorch: t.HFrontPorch,
+		HSyncWidth:  t.HSync,
+		VActive:     t.VActive,
+		VBlank:      t.VBlank(),
+		VFrontPorch: t.VFrontPorch,
+		VSyncWidth:  t.VSync,
+		HImageSize:  hImage,
+		VImageSize:  vImage,
+		Flags:       flags,
+	}
+	if err := dtd.Validate(); err != nil {
+		return display.DetailedTiming{}, fmt.Errorf("timing: %w", err)
+	}
+	return dtd, nil
+}
+
+// EncodeDTD 將時序編碼為 18 位元組 DTD。
+func (t Timing) EncodeDTD(hImage, vImage int) ([]byte, error) {
+	dtd, err := t.ToDetailedTiming(hImage, vImage)
+	if err != nil {
+		return nil, err
+	}
+	return dtd.Encode()
+}
+
+// FromDetailedTiming 由 DTD 欄位還原時序；沒有極性資訊的同步類型視為負極性。
+func FromDetailedTiming(d display.DetailedTiming) Timing {
+	return Timing{
+		PixelClock:    d.PixelClock,
+		HActive:       d.HActive,
+		HFrontPorch:   d.HFrontPorch,
+		HSync:         d.HSyncWidth,
+		HBackPorch:    d.HBackPorch(),
+		VActive:       d.VActive,
+		VFrontPorch:   d.VFrontPorch,
+		VSync:         d.VSyncWidth,
+		VBackPorch:    d.VBackPorch(),
+		HSyncPositive: d.HSyncPolarity() == "+",
+		VSyncPositive: d.VSyncPolarity() == "+",
+		Interlaced:    d.Interlaced(),
+	}
+}
+
+// DecodeDTD 解析 18 位元組 DTD；顯示器描述符回傳 false。
+func DecodeDTD(desc []byte) (Timing, bool) {
+	d, ok := display.DecodeDetailedTiming(desc)
+	if !ok {
+		return Timing{}, false
+	}
+	return FromDetailedTiming(d), true
+}
@@ -0,0 +1,320 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/acc"
+	"GMTAUXOneKeyBuild/config"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// accView 保存 ACC / Gamma 校正頁面的元件與目前編輯中的 LUT。
+type accView struct {
+	root    *tview.Flex
+	table   *tview.Table
+	curve   *tview.TextView
+	form    *tview.Form
+	profile acc.Profile
+	lut     *acc.LUT
+	path    string
+}
+
+// showACCView 開啟 ACC / Gamma 校正頁面。
+func (app *App) showACCView() {
+	profiles := acc.Profiles()
+	if len(profiles) == 0 {
+		app.showModal("沒有可用的 TCON ACC 設定檔")
+		return
+	}
+
+	view := &accView{
+		profile: profiles[0],
+		path:    "acc_lut.csv",
+	}
+	view.lut = acc.NewLinearLUT(view.profile.Entries, view.profile.Bits)
+
+	// 表格列出每個索引的 R/G/B 數值，選取儲存格後可直接修改。
+	view.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, true).
+		SetFixed(1, 1)
+	view.table.SetBorder(true).
+		SetTitle(" ACC LUT ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	view.table.SetSelectedFunc(func(row, column int) {
+		app.editACCEntry(view, row-1, column-1)
+	})
+
+	// 曲線區以文字方式繪製三個通道的輸出曲線。
+	view.curve = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+	view.curve.SetBorder(true).
+		SetTitle(" Curve ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+
+	view.form = tview.NewForm().
+		AddDropDown("TCON", names, 0, func(option string, _ int) {
+			if p, ok := acc.LookupProfile(option); ok {
+				view.profile = p
+			}
+		}).
+		AddInputField("檔案", view.path, 40, nil, func(text string) {
+			view.path = strings.TrimSpace(text)
+		}).
+		AddButton("匯入", func() { app.importACC(view) }).
+		AddButton("匯出", func() { app.exportACC(view) }).
+		AddButton("燒錄", func() { app.confirmProgramACC(view) }).
+		AddButton("線性", func() {
+			view.lut = acc.NewLinearLUT(view.profile.Entries, view.profile.Bits)
+			view.render()
+		}).
+		AddButton("關閉", app.closeView)
+	view.form.SetBorder(true).
+		SetTitle(" ACC / Gamma 校正 ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	// Tab 在表單與表格之間切換焦點。
+	view.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyTAB || event.Key() == tcell.KeyBacktab {
+			app.app.SetFocus(view.form)
+			return nil
+		}
+		return event
+	})
+	view.form.SetCancelFunc(app.closeView)
+
+	right := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(view.curve, 0, 3, false).
+		AddItem(view.form, 11, 0, true)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(view.table, 0, 1, false).
+		AddItem(right, 0, 2, true)
+
+	view.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	// 讓表單的最後一個按鈕之後能跳到表格。
+	view.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyBacktab {
+			if idx, _ := view.form.GetFocusedItemIndex(); idx == 0 {
+				app.app.SetFocus(view.table)
+				return nil
+			}
+		}
+		return event
+	})
+
+	view.render()
+	app.showView(view.root, view.form)
+	app.setStatus(fmt.Sprintf("[yellow]ACC 設定檔: %s[-]", view.profile.Name))
+}
+
+// render 重新繪製表格與曲線。
+func (v *accView) render() {
+	v.table.Clear()
+	headers := []string{"#", "R", "G", "B"}
+	for col, header := range headers {
+		v.table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tview.Styles.SecondaryTextColor).
+			SetSelectable(false).
+			SetAlign(tview.AlignCenter))
+	}
+
+	colors := []tcell.Color{tcell.ColorRed, tcell.ColorGreen, tcell.ColorDodgerBlue}
+	for i := 0; i < v.lut.Entries(); i++ {
+		v.table.SetCell(i+1, 0, tview.NewTableCell(strconv.Itoa(i)).
+			SetTextColor(tview.Styles.SecondaryTextColor).
+			SetSelectable(false))
+		for c, channel := range []acc.Channel{acc.ChannelRed, acc.ChannelGreen, acc.ChannelBlue} {
+			value := v.lut.Channel(channel)[i]
+			v.table.SetCell(i+1, c+1, tview.NewTableCell(strconv.Itoa(int(value))).
+				SetTextColor(colors[c]).
+				SetAlign(tview.AlignRight))
+		}
+	}
+
+	_, _, width, height := v.curve.GetInnerRect()
+	v.curve.SetText(renderACCCurve(v.lut, width, height))
+}
+
+// renderACCCurve 以字元格繪製 R/G/B 曲線，重疊處以白色表示。
+func renderACCCurve(lut *acc.LUT, width, height int) string {
+	if width < 16 {
+		width = 64
+	}
+	if height < 4 {
+		height = 16
+	}
+	entries := lut.Entries()
+	if entries == 0 {
+		return ""
+	}
+
+	// 每個格子以位元遮罩記錄有哪些通道經過。
+	grid := make([][]byte, height)
+	for row := range grid {
+		grid[row] = make([]byte, width)
+	}
+	max := float64(lut.MaxValue())
+	for c, channel := range []acc.Channel{acc.ChannelRed, acc.ChannelGreen, acc.ChannelBlue} {
+		values := lut.Channel(channel)
+		for col := 0; col < width; col++ {
+			index := col * (entries - 1) / max1(width-1)
+			row := height - 1 - int(float64(values[index])/max*float64(height-1)+0.5)
+			if row < 0 {
+				row = 0
+			}
+			grid[row][col] |= 1 << c
+		}
+	}
+
+	marks := map[byte]string{
+		1: "[red]•[-]", 2: "[green]•[-]", 4: "[dodgerblue]•[-]",
+	}
+	var b strings.Builder
+	for _, line := range grid {
+		for _, mask := range line {
+			switch {
+			case mask == 0:
+				b.WriteByte(' ')
+			case marks[mask] != "":
+				b.WriteString(marks[mask])
+			default:
+				b.WriteString("[white]•[-]")
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func max1(v int) int {
+	if v < 1 {
+		return 1
+	}
+	return v
+}
+
+// editACCEntry 以輸入框修改指定索引與通道的數值。
+func (app *App) editACCEntry(view *accView, index, column int) {
+	if index < 0 || index >= view.lut.Entries() || column < 0 || column > 2 {
+		return
+	}
+	channel := acc.Channel(column)
+	values := view.lut.Channel(channel)
+
+	input := tview.NewInputField().
+		SetLabel(fmt.Sprintf("%s[%d] = ", strings.ToUpper(channel.String()[:1]), index)).
+		SetText(strconv.Itoa(int(values[index]))).
+		SetFieldWidth(8).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	input.SetBorder(true).SetTitle(" 修改數值 ")
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			v, err := strconv.Atoi(input.GetText())
+			if err != nil || v < 0 || v > int(view.lut.MaxValue()) {
+				app.setStatus(fmt.Sprintf("[red]數值需介於 0~%d[-]", view.lut.MaxValue()))
+				return
+			}
+			values[index] = uint16(v)
+			view.render()
+		}
+		app.app.SetRoot(view.root, true).SetFocus(view.table)
+	})
+
+	frame := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(input, 3, 0, true).
+			AddItem(nil, 0, 1, false), 30, 0, true).
+		AddItem(nil, 0, 1, false)
+	app.app.SetRoot(frame, true).SetFocus(input)
+}
+
+// importACC 由 CSV 或 JSON 檔案載入 LUT。
+func (app *App) importACC(view *accView) {
+	lut, err := acc.LoadFile(view.path, view.profile.Bits)
+	if err != nil {
+		app.showModal(fmt.Sprintf("匯入 ACC 失敗：%v", err))
+		return
+	}
+	view.lut = lut
+	view.render()
+	app.setStatus(fmt.Sprintf("[green]已匯入 %s（%d 筆）[-]", view.path, lut.Entries()))
+}
+
+// exportACC 將目前的 LUT 輸出為 CSV 或 JSON 檔案。
+func (app *App) exportACC(view *accView) {
+	if err := view.lut.SaveFile(view.path); err != nil {
+		app.showModal(fmt.Sprintf("匯出 ACC 失敗：%v", err))
+		return
+	}
+	app.setStatus(fmt.Sprintf("[green]已匯出 %s[-]", view.path))
+}
+
+// confirmProgramACC 在燒錄前要求使用者確認。
+func (app *App) confirmProgramACC(view *accView) {
+	if err := view.lut.Validate(); err != nil {
+		app.showModal(fmt.Sprintf("LUT 內容不正確：%v", err))
+		return
+	}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("確定要將 ACC 資料燒錄至 %s？\n燒錄期間請勿關閉電源。", view.profile.Name)).
+		AddButtons([]string{"燒錄", "取消"}).
+		SetDoneFunc(func(index int, _ string) {
+			app.restoreRoot()
+			if index == 0 {
+				app.programACC(view)
+			}
+		})
+	app.app.SetRoot(modal, true).SetFocus(modal)
+}
+
+// programACC 於背景燒錄 LUT 並回報驗證結果。
+func (app *App) programACC(view *accView) {
+	profile := view.profile
+	profile.PollTimeout = config.Millis(app.config.Timeouts.ACCPollMS)
+	lut := view.lut
+	app.setStatus("[yellow]燒錄 ACC 資料中...[-]")
+	go func() {
+		driver, err := app.ensureGPUDriver()
+		if err != nil || driver == nil {
+			app.queueShowModal(fmt.Sprintf("無法燒錄 ACC：%s", app.describeGPUError(err)))
+			return
+		}
+		programmer := acc.NewProgrammer(driver, profile)
+		programmer.Progress = func(message string) {
+			app.queueSetStatus(fmt.Sprintf("[yellow]%s[-]", message))
+		}
+		if err := programmer.Program(lut); err != nil {
+			app.queueSetStatus(fmt.Sprintf("[red]ACC 燒錄失敗: %v[-]", err))
+			app.queueShowModal(fmt.Sprintf("ACC 燒錄失敗：\n%v", err))
+			return
+		}
+		app.queueSetStatus("[green]ACC 燒錄與驗證完成[-]")
+		app.queueShowModal("ACC 燒錄與驗證完成！")
+	}()
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"log"
+	"net"
+
+	"GMTAUXOneKeyBuild/api"
+	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/luascripts"
+	"GMTAUXOneKeyBuild/remote"
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+// apiBackend 讓 API 與介面共用驅動快取、dry-run、遠端設定與 Lua 執行環境。
+type apiBackend struct {
+	app *App
+}
+
+// Displays 回傳介面最近一次列出的顯示器快照；API 在背景 goroutine 執行，不直接讀取介面狀態。
+func (b apiBackend) Displays() []*display.Display {
+	return b.app.snapshotDisplays()
+}
+
+func (b apiBackend) Driver(target *display.Display) (gpu.Driver, error) {
+	return b.app.auxDriverFor(target)
+}
+
+func (b apiBackend) ScriptRuntime(target *display.Display, driver gpu.Driver, detectErr error) luascripts.RuntimeOptions {
+	return b.app.scriptRuntimeFor(b.app.snapshotDisplays(), target, driver, detectErr)
+}
+
+// StartAPI 於背景啟動本機 HTTP API 並要求 Bearer 權杖；token 為空時與 -serve 相同
+// 隨機產生並顯示於彈窗，API 不會在未驗證的情況下開放。
+// 監聽失敗會立即回傳錯誤，之後的錯誤顯示於狀態列。
+func (app *App) StartAPI(addr, token string) error {
+	generated := token == ""
+	if generated {
+		var err error
+		if token, err = remote.GenerateToken(); err != nil {
+			return err
+		}
+	}
+	server := api.NewServer(apiBackend{app: app}, app.scriptsDir, app.recipesDir)
+	server.ReportsDir = app.reportsDir
+	server.Token = token
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if generated {
+		log.Printf("generated API token: %s", token)
+		// 佇列中的更新在事件迴圈啟動後才執行，不會被 Run 的初始狀態覆蓋。
+		app.queueShowModal("未指定 -token，HTTP API 已啟用隨機權杖：\n\n" + token)
+	}
+	go func() {
+		if err := server.Serve(ln); err != nil {
+			app.queueSetStatus("[red]API 伺服器停止: " + err.Error() + "[-]")
+		}
+	}()
+	return nil
+}
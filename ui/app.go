@@ -3,11 +3,17 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"slices"
 	"strings"
 	"sync"
 
+	"GMTAUXOneKeyBuild/config"
 	"GMTAUXOneKeyBuild/edidhelper"
 	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/hotplug"
 	"GMTAUXOneKeyBuild/luascripts"
 	display "GMTAUXOneKeyBuild/struct"
 
@@ -26,12 +32,32 @@ type App struct {
 	table                 *tview.Table       // 顯示詳細屬性的表格
 	statusBar             *tview.TextView    // 底部狀態列
 	layout                tview.Primitive    // 頁面佈局的根節點
+	activeView            tview.Primitive    // 目前取代主佈局的功能頁面
+	activeFocus           tview.Primitive    // 功能頁面預設的焦點元件
+	config                config.Config      // 目前套用的設定
+	configPath            string             // 設定畫面儲存的目標檔案
+	configErr             error              // 啟動時載入設定檔的錯誤，於 Run 顯示
+	logFile               *os.File           // 狀態列訊息記錄檔
+	logger                *log.Logger
 	scriptsDir            string
+	recipesDir            string
+	reportsDir            string
+	panelsPath            string // 使用者維護的面板型號檔
+	dryRun                bool   // 啟用時寫入只被記錄，不會送往硬體
+	snapshotPath          string // dry-run 讀取使用的快照檔
+	remoteAddr            string // 遠端測試機位址，設定後硬體存取轉送至遠端
+	remoteToken           string
+	hotplugEnabled        bool           // 監看顯示器插拔並自動重新偵測
+	hotplugScript         string         // 偵測到新面板時自動執行的腳本名稱
+	hotplugSource         hotplug.Source // 測試時注入的事件來源，nil 時使用作業系統通知
+	hotplugWatcher        *hotplug.Watcher
 	scripts               []luascripts.Script
 	onSwitchToDisplayList func(*App)
 	gpuDrivers            map[string]gpu.Driver
 	gpuDetectErrs         map[string]error
 	gpuDetectMu           sync.Mutex
+	displaysMu            sync.RWMutex
+	displaySnapshot       []*display.Display // populateDisplayList 更新的清單副本，供 API 等背景 goroutine 讀取
 }
 
 // NewApp 建立一個新的 App 實例，並完成所有介面的初始化設定。
@@ -39,12 +65,8 @@ func NewApp() *App {
 	// 啟用滑鼠操作的 tview 應用程式，提供更友善的互動方式。
 	application := tview.NewApplication().EnableMouse(true)
 
-	// 建立主選單，提供重新偵測、切換焦點與離開等功能。
+	// 建立主選單，項目依設定檔的語言與快捷鍵由 populateMainMenu 填入。
 	mainMenu := tview.NewList().
-		AddItem("重新偵測螢幕", "刷新顯示器列表", 'r', nil).
-		AddItem("重新載入 Lua 腳本", "重新掃描 scripts 目錄", 'l', nil).
-		AddItem("切換至螢幕列表", "將焦點移到螢幕選單", 'd', nil).
-		AddItem("離開", "結束應用程式", 'q', nil).
 		SetHighlightFullLine(true)
 	mainMenu.SetBorder(true).
 		SetTitle(" Main Menu ").
@@ -120,11 +142,18 @@ func NewApp() *App {
 		table:         table,
 		statusBar:     status,
 		layout:        layout,
-		scriptsDir:    "scripts",
+		configPath:    config.DefaultPath,
 		gpuDrivers:    make(map[string]gpu.Driver),
 		gpuDetectErrs: make(map[string]error),
 	}
 
+	// 載入設定檔；格式錯誤時沿用預設值，錯誤於 Run 時在狀態列提示。
+	cfg, err := config.Load(app.configPath)
+	app.configErr = err
+	if err := app.applyConfig(cfg); err != nil && app.configErr == nil {
+		app.configErr = err
+	}
+
 	app.onSwitchToDisplayList = func(a *App) {
 		a.FocusDisplayList()
 	}
@@ -145,6 +174,9 @@ func NewApp() *App {
 
 // Run 啟動應用程式，並在執行前刷新顯示器資訊與狀態。
 func (app *App) Run() error {
+	// 面板型號檔需在解析 EDID 前載入，才能帶出面板料號。
+	panelErr := app.loadPanelModels()
+
 	// 嘗試重新整理顯示器，並依結果在狀態列顯示不同訊息。
 	if err := app.refreshDisplays(); err != nil {
 		if len(app.displays) == 0 {
@@ -161,15 +193,41 @@ func (app *App) Run() error {
 	if err := app.refreshScripts(); err != nil {
 		app.setStatus(fmt.Sprintf("[red]Lua 腳本載入失敗: %v[-]", err))
 	}
+	if panelErr != nil {
+		app.setStatus(fmt.Sprintf("[red]面板型號檔載入失敗: %v[-]", panelErr))
+	}
+	if app.configErr != nil {
+		app.setStatus(fmt.Sprintf("[red]設定檔有誤: %v[-]", app.configErr))
+	}
+	if err := app.startHotplug(); err != nil {
+		app.setStatus(fmt.Sprintf("[yellow]無法監看顯示器插拔，請手動重新偵測: %v[-]", err))
+	}
+	defer app.stopHotplug()
+	defer app.closeLogFile()
 
 	// 建立畫面根節點並將焦點放在主選單後開始事件迴圈。
 	return app.app.SetRoot(app.layout, true).SetFocus(app.mainMenu).Run()
 }
 
+// loadPanelModels 讀取面板型號檔；檔案不存在時視為沒有自訂型號。
+func (app *App) loadPanelModels() error {
+	err := display.LoadPanelModels(app.panelsPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
 // refreshDisplays 重新取得顯示器清單並更新顯示內容。
 func (app *App) refreshDisplays() error {
 	// 呼叫 edidhelper 取得系統中的所有顯示器資訊。
 	displays, err := edidhelper.GetScreens()
+	// 保留由檔案載入的虛擬項目，重新偵測時不清除正在檢查的 EDID。
+	for _, d := range app.displays {
+		if d.Source == display.SourceFile {
+			displays = append(displays, d)
+		}
+	}
 	app.displays = displays
 	app.populateDisplayList()
 
@@ -188,6 +246,10 @@ func (app *App) refreshDisplays() error {
 
 // populateDisplayList 將顯示器資訊填入左側清單。
 func (app *App) populateDisplayList() {
+	app.displaysMu.Lock()
+	app.displaySnapshot = slices.Clone(app.displays)
+	app.displaysMu.Unlock()
+
 	app.displayList.Clear()
 	for i, d := range app.displays {
 		// 以數字鍵作為快捷鍵，方便使用者快速切換。
@@ -245,18 +307,37 @@ func (app *App) updateTable(d *display.Display) {
 
 // displayToRows 將顯示器資訊轉換成表格可使用的列資料。
 func displayToRows(d *display.Display) [][]string {
+	// 製造商名稱與面板型號查無資料時提示可自行補充。
+	vendor, panel := d.VendorName, d.PanelModel
+	if vendor == "" {
+		vendor = "（未收錄）"
+	}
+	switch {
+	case panel == "":
+		panel = "（未收錄，可加入面板型號檔）"
+	case d.PanelDescription != "":
+		panel = fmt.Sprintf("%s（%s）", panel, d.PanelDescription)
+	}
 	rows := [][]string{
 		{"顯示卡名稱", d.AdapterName},
 		{"顯示卡描述", d.AdapterString},
 		{"裝置識別碼", d.DeviceID},
 		{"製造商ID", d.ManufacturerID},
+		{"製造商", vendor},
 		{"產品ID", d.ProductID},
+		{"面板型號", panel},
 		{"序號", d.Serial},
 		{"製造週次", fmt.Sprintf("%d", d.Week)},
 		{"製造年份", fmt.Sprintf("%d", d.Year)},
 		{"EDID 版本", d.Version},
 		{"EDID 修訂版", d.Revision},
 	}
+	if d.Source != "" {
+		rows = append(rows, []string{"EDID 來源", d.Source})
+	}
+	for _, warning := range d.Warnings {
+		rows = append(rows, []string{"EDID 警告", warning})
+	}
 
 	// 額外的描述欄位僅在有內容時才加入表格。
 	descriptors := []struct {
@@ -279,9 +360,14 @@ func displayToRows(d *display.Display) [][]string {
 }
 
 // handleMainMenu 處理主選單項目的點擊或快捷鍵事件。
-func (app *App) handleMainMenu(index int, mainText, _ string, _ rune) {
-	switch mainText {
-	case "重新偵測螢幕":
+func (app *App) handleMainMenu(index int, _, _ string, _ rune) {
+	if index < 0 || index >= len(mainMenuItems) {
+		return
+	}
+	switch mainMenuItems[index].id {
+	case "refresh":
+		// 重新載入面板型號檔，讓新增的型號在重新偵測後即可顯示。
+		panelErr := app.loadPanelModels()
 		// 重新整理顯示器並依照結果顯示對應提示訊息。
 		if err := app.refreshDisplays(); err != nil {
 			message := fmt.Sprintf("螢幕重新偵測時發生錯誤: %v", err)
@@ -295,7 +381,10 @@ func (app *App) handleMainMenu(index int, mainText, _ string, _ rune) {
 			app.showModal("螢幕重新偵測完成！")
 			app.setStatus(fmt.Sprintf("[green]載入 %d 個顯示器[-]", len(app.displays)))
 		}
-	case "重新載入 Lua 腳本":
+		if panelErr != nil {
+			app.showModal(fmt.Sprintf("面板型號檔載入失敗: %v", panelErr))
+		}
+	case "reload_scripts":
 		if err := app.refreshScripts(); err != nil {
 			app.showModal(fmt.Sprintf("Lua 腳本載入失敗: %v", err))
 		} else if len(app.scripts) == 0 {
@@ -303,12 +392,40 @@ func (app *App) handleMainMenu(index int, mainText, _ string, _ rune) {
 		} else {
 			app.showModal("Lua 腳本清單已更新！")
 		}
-	case "切換至螢幕列表":
+	case "recipe":
+		app.showRecipeView()
+	case "acc":
+		app.showACCView()
+	case "dpcd_decode":
+		app.showDPCDView()
+	case "dpcd_hex":
+		app.showDPCDHexView()
+	case "sink_edid":
+		app.readSinkEDID()
+	case "edid_file":
+		app.showEDIDFileView()
+	case "edid_editor":
+		app.showEDIDEditor()
+	case "edid_diff":
+		app.showEDIDDiffView()
+	case "i2c_scan":
+		app.showI2CScanView()
+	case "ddc":
+		app.showDDCView()
+	case "export":
+		app.showExportView()
+	case "dry_run":
+		app.toggleDryRun()
+	case "driver":
+		app.showDriverView()
+	case "settings":
+		app.showSettingsView()
+	case "display_list":
 		// 將行為委由外部指定的處理函式執行。
 		if app.onSwitchToDisplayList != nil {
 			app.onSwitchToDisplayList(app)
 		}
-	case "離開":
+	case "quit":
 		// 停止事件迴圈，結束應用程式。
 		app.app.Stop()
 	}
@@ -333,10 +450,18 @@ func (app *App) onDisplaySelected(index int, mainText, _ string, _ rune) {
 func (app *App) handleGlobalShortcuts(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Key() {
 	case tcell.KeyEsc:
-		// 按下 Esc 時回到主選單。
+		// 按下 Esc 時關閉功能頁面並回到主選單。
+		if app.activeView != nil {
+			app.closeView()
+			return nil
+		}
 		app.app.SetFocus(app.mainMenu)
 		return nil
 	case tcell.KeyTAB:
+		if app.activeView != nil {
+			// 功能頁面自行處理 Tab 切換。
+			return event
+		}
 		// Tab 在主選單、顯示器清單與 Lua 腳本清單間循環切換。
 		switch app.app.GetFocus() {
 		case app.mainMenu:
@@ -348,6 +473,9 @@ func (app *App) handleGlobalShortcuts(event *tcell.EventKey) *tcell.EventKey {
 		}
 		return nil
 	case tcell.KeyBacktab:
+		if app.activeView != nil {
+			return event
+		}
 		// Shift+Tab 則反向切換焦點。
 		switch app.app.GetFocus() {
 		case app.scriptList:
@@ -365,6 +493,10 @@ func (app *App) handleGlobalShortcuts(event *tcell.EventKey) *tcell.EventKey {
 // handleMouseCapture 攔截滑鼠操作，支援中鍵快速回到主選單。
 func (app *App) handleMouseCapture(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
 	if event.Buttons()&tcell.Button2 != 0 {
+		if app.activeView != nil {
+			app.closeView()
+			return nil, action
+		}
 		app.app.SetFocus(app.mainMenu)
 		return nil, action
 	}
@@ -377,16 +509,42 @@ func (app *App) showModal(message string) {
 		SetText(message).
 		AddButtons([]string{"OK"}).
 		SetDoneFunc(func(_ int, _ string) {
-			// 關閉視窗後重新設定主畫面並將焦點放回主選單。
-			app.app.SetRoot(app.layout, true).SetFocus(app.mainMenu)
+			// 關閉視窗後恢復原本的畫面與焦點。
+			app.restoreRoot()
 		})
 
 	app.app.SetRoot(modal, true).SetFocus(modal)
 }
 
-// setStatus 更新狀態列的文字，統一由此處集中管理。
+// showView 以功能頁面取代主要佈局，focus 為頁面預設的焦點元件。
+func (app *App) showView(view, focus tview.Primitive) {
+	app.activeView = view
+	app.activeFocus = focus
+	app.restoreRoot()
+}
+
+// closeView 關閉目前的功能頁面並回到主選單。
+func (app *App) closeView() {
+	app.activeView = nil
+	app.activeFocus = nil
+	app.restoreRoot()
+}
+
+// restoreRoot 依是否有功能頁面，重新設定畫面根節點與焦點。
+func (app *App) restoreRoot() {
+	if app.activeView != nil {
+		app.app.SetRoot(app.activeView, true).SetFocus(app.activeFocus)
+		return
+	}
+	app.app.SetRoot(app.layout, true).SetFocus(app.mainMenu)
+}
+
+// setStatus 更新狀態列的文字，統一由此處集中管理；設定記錄檔時同時寫入。
 func (app *App) setStatus(message string) {
 	app.statusBar.SetText(message)
+	if app.logger != nil {
+		app.logger.Println(stripColorTags(message))
+	}
 }
 
 // FocusDisplayList 將焦點移至顯示器清單，提供外部呼叫時重複使用。
@@ -445,33 +603,34 @@ func (app *App) onScriptSelected(index int, mainText, _ string, _ rune) {
 
 // executeLuaScript 在獨立 goroutine 中執行 Lua 腳本，避免阻塞 UI。
 func (app *App) executeLuaScript(script luascripts.Script) {
-	driver, detectErr := app.ensureGPUDriver()
-
-	functions := map[string]lua.LGFunction{
-		"set_status": func(L *lua.LState) int {
-			message := L.CheckString(1)
-			app.queueSetStatus(message)
-			return 0
-		},
-		"show_modal": func(L *lua.LState) int {
-			message := L.CheckString(1)
-			app.queueShowModal(message)
-			return 0
-		},
+	// dry-run 時改用記錄寫入的驅動，讀取由快照或模擬空間提供，不偵測或初始化實體驅動；
+	// 需要實機資料時由「切換 Dry-run」先擷取快照。
+	var (
+		driver    gpu.Driver
+		detectErr error
+		dryRun    *gpu.DryRunDriver
+	)
+	if app.dryRun {
+		var err error
+		if dryRun, err = app.newDryRunDriver(); err != nil {
+			app.queueSetStatus(fmt.Sprintf("[red]Dry-run 快照載入失敗: %v[-]", err))
+			return
+		}
+		driver = dryRun
+	} else {
+		driver, detectErr = app.ensureGPUDriver()
 	}
 
-	for name, fn := range app.luaGPUFunctions(driver, detectErr) {
-		functions[name] = fn
-	}
-
-	opts := luascripts.RuntimeOptions{
-		Functions: functions,
-		Globals: map[string]interface{}{
-			"context": app.luaContext(driver, detectErr),
-		},
-	}
+	opts := app.scriptRuntime(driver, detectErr)
+	retries := driverRetries(driver)
 
 	results, err := luascripts.ExecuteScript(script.Path, opts)
+	if dryRun != nil {
+		// 先開啟寫入清單，之後的結果視窗關閉時會回到此頁面。
+		app.app.QueueUpdateDraw(func() {
+			app.showDryRunWrites(script.Name, dryRun)
+		})
+	}
 	if err != nil {
 		app.queueSetStatus(fmt.Sprintf("[red]Lua 腳本失敗: %v[-]", err))
 		app.queueShowModal(fmt.Sprintf("Lua 腳本「%s」執行失敗:\n%v", script.Name, err))
@@ -486,14 +645,53 @@ func (app *App) executeLuaScript(script luascripts.Script) {
 		}
 	}
 
-	app.queueSetStatus(fmt.Sprintf("[green]Lua 腳本「%s」執行完成[-]", script.Name))
+	app.queueSetStatus(fmt.Sprintf("[green]Lua 腳本「%s」執行完成[-]", script.Name) + retryNote(driver, retries))
+}
+
+// scriptRuntime 組成腳本執行環境：狀態列與彈窗函式、GPU 函式、dpcd/ddc 模組與 context。
+func (app *App) scriptRuntime(driver gpu.Driver, detectErr error) luascripts.RuntimeOptions {
+	return app.scriptRuntimeFor(app.displays, app.currentDisplay(), driver, detectErr)
 }
 
-func (app *App) luaGPUFunctions(driver gpu.Driver, detectErr error) map[string]lua.LGFunction {
+// scriptRuntimeFor 與 scriptRuntime 相同，但以指定的顯示器清單與目標組成 context，
+// 供不在 UI 執行緒上的 API 使用。
+func (app *App) scriptRuntimeFor(displays []*display.Display, target *display.Display, driver gpu.Driver, detectErr error) luascripts.RuntimeOptions {
 	describeError := func() string {
-		return app.describeGPUError(detectErr)
+		return app.describeGPUErrorFor(target, detectErr)
+	}
+	functions := map[string]lua.LGFunction{
+		"set_status": func(L *lua.LState) int {
+			message := L.CheckString(1)
+			app.queueSetStatus(message)
+			return 0
+		},
+		"show_modal": func(L *lua.LState) int {
+			message := L.CheckString(1)
+			app.queueShowModal(message)
+			return 0
+		},
+		"edid_diff": luaEDIDDiff(),
+	}
+
+	for name, fn := range app.luaGPUFunctions(driver, describeError) {
+		functions[name] = fn
+	}
+
+	return luascripts.RuntimeOptions{
+		Functions: functions,
+		Globals: map[string]interface{}{
+			"context": app.luaContext(displays, target, driver, detectErr),
+		},
+		Modules: map[string]map[string]lua.LGFunction{
+			"dpcd":   luaDPCDModule(),
+			"json":   luaJSONModule(),
+			"timing": luaTimingModule(),
+			"ddc":    luaDDCModule(app.newDDCClient(driver), describeError),
+		},
 	}
+}
 
+func (app *App) luaGPUFunctions(driver gpu.Driver, describeError func() string) map[string]lua.LGFunction {
 	return map[string]lua.LGFunction{
 		"read_dpcd": func(L *lua.LState) int {
 			if driver == nil {
@@ -511,9 +709,7 @@ func (app *App) luaGPUFunctions(driver gpu.Driver, detectErr error) map[string]l
 			// 使用驅動介面讀取 DPCD，並將資料轉成 Lua table。
 			data, err := driver.ReadDPCD(address, uint32(length))
 			if err != nil {
-				L.Push(lua.LNil)
-				L.Push(lua.LString(err.Error()))
-				return 2
+				return pushDriverError(L, lua.LNil, err)
 			}
 			tbl := L.NewTable()
 			for i, b := range data {
@@ -538,9 +734,7 @@ func (app *App) luaGPUFunctions(driver gpu.Driver, detectErr error) map[string]l
 				return 2
 			}
 			if err := driver.WriteDPCD(address, data); err != nil {
-				L.Push(lua.LBool(false))
-				L.Push(lua.LString(err.Error()))
-				return 2
+				return pushDriverError(L, lua.LBool(false), err)
 			}
 			L.Push(lua.LBool(true))
 			return 1
@@ -560,9 +754,7 @@ func (app *App) luaGPUFunctions(driver gpu.Driver, detectErr error) map[string]l
 			}
 			data, err := driver.ReadI2C(address, uint32(length))
 			if err != nil {
-				L.Push(lua.LNil)
-				L.Push(lua.LString(err.Error()))
-				return 2
+				return pushDriverError(L, lua.LNil, err)
 			}
 			tbl := L.NewTable()
 			for i, b := range data {
@@ -587,19 +779,58 @@ func (app *App) luaGPUFunctions(driver gpu.Driver, detectErr error) map[string]l
 				return 2
 			}
 			if err := driver.WriteI2C(address, data); err != nil {
-				L.Push(lua.LBool(false))
-				L.Push(lua.LString(err.Error()))
-				return 2
+				return pushDriverError(L, lua.LBool(false), err)
 			}
 			L.Push(lua.LBool(true))
 			return 1
 		},
+		"scan_i2c":  luaScanI2C(driver, describeError),
+		"read_edid": luaReadEDID(driver, describeError),
+		"retry_stats": func(L *lua.LState) int {
+			// 未經重試包裝的驅動（例如 dry-run）沒有統計，回傳 nil。
+			retry, ok := driver.(*gpu.RetryDriver)
+			if !ok {
+				L.Push(lua.LNil)
+				return 1
+			}
+			stats := retry.Stats()
+			tbl := L.NewTable()
+			tbl.RawSetString("calls", lua.LNumber(stats.Calls))
+			tbl.RawSetString("retries", lua.LNumber(stats.Retries))
+			tbl.RawSetString("recovered", lua.LNumber(stats.Recovered))
+			tbl.RawSetString("failures", lua.LNumber(stats.Failures))
+			errs := L.NewTable()
+			for kind, n := range stats.Errors {
+				errs.RawSetString(kind, lua.LNumber(n))
+			}
+			tbl.RawSetString("errors", errs)
+			L.Push(tbl)
+			return 1
+		},
 	}
 }
 
+// pushDriverError 推入驅動失敗時的回傳值：first、錯誤訊息與錯誤分類（例如 "defer"），
+// 讓腳本不必比對訊息文字即可判斷錯誤種類；無法分類時分類為 nil。
+func pushDriverError(L *lua.LState, first lua.LValue, err error) int {
+	L.Push(first)
+	L.Push(lua.LString(err.Error()))
+	if kind := gpu.ErrorKind(err); kind != "" {
+		L.Push(lua.LString(kind))
+	} else {
+		L.Push(lua.LNil)
+	}
+	return 3
+}
+
 func (app *App) describeGPUError(err error) string {
-	// 若能取得目前顯示器的供應商，以此拼接提示訊息。
-	vendor := app.selectedDisplayVendor()
+	return app.describeGPUErrorFor(app.currentDisplay(), err)
+}
+
+// describeGPUErrorFor 與 describeGPUError 相同，但以指定的顯示器判斷廠牌。
+func (app *App) describeGPUErrorFor(d *display.Display, err error) string {
+	// 若能取得顯示器的供應商，以此拼接提示訊息。
+	vendor := app.vendorKeyForDisplay(d)
 	unavailable := "no compatible GPU driver available for selected display"
 	if vendor != "" {
 		unavailable = fmt.Sprintf("no %s GPU driver available for selected display", vendor)
@@ -615,7 +846,31 @@ func (app *App) describeGPUError(err error) string {
 
 func (app *App) ensureGPUDriver() (gpu.Driver, error) {
 	// 先取得目前聚焦的顯示器，再推論應使用的 GPU 驅動。
-	display := app.currentDisplay()
+	return app.driverForDisplay(app.currentDisplay())
+}
+
+// driverForDisplay 推論操作指定顯示器使用的 GPU 驅動；display 為 nil 時依預設廠牌偵測。
+// 不讀取介面元件，可在背景 goroutine 中呼叫。
+func (app *App) driverForDisplay(display *display.Display) (gpu.Driver, error) {
+	if display != nil {
+		// 使用者為此顯示器手動指定的驅動優先於所有自動判斷。
+		if choice := app.config.DisplayDriver(displayDriverKey(display)); choice != "" {
+			return app.openDriverChoice(choice)
+		}
+	}
+	if app.remoteAddr != "" {
+		// 指定遠端測試機時，所有硬體存取都轉送至遠端。
+		return app.remoteDriver()
+	}
+	if display != nil && display.DeviceID != "" {
+		// 可直接對應到顯示器連接埠的驅動（例如 Linux drm_dp_aux）優先使用。
+		driver, err := app.cachedGPUDriver("device:"+display.DeviceID, func() (gpu.Driver, error) {
+			return gpu.DetectForDevice(display.DeviceID)
+		})
+		if !errors.Is(err, gpu.ErrNoDriver) {
+			return driver, err
+		}
+	}
 	vendor := app.vendorKeyForDisplay(display)
 	return app.ensureGPUDriverForVendor(vendor)
 }
@@ -627,6 +882,26 @@ func (app *App) ensureGPUDriverForVendor(vendor string) (gpu.Driver, error) {
 		key = "default"
 	}
 
+	return app.cachedGPUDriver(key, func() (gpu.Driver, error) {
+		// 設定檔指定的驅動優先，否則以廠牌名稱偵測。
+		name := app.config.Driver(vendor)
+		if name == "" {
+			name = vendor
+		}
+		if name != "" {
+			// 先嘗試以指定驅動偵測，失敗再退回一般偵測流程。
+			driver, err := gpu.DetectByName(name)
+			if errors.Is(err, gpu.ErrNoDriver) {
+				driver, err = gpu.Detect()
+			}
+			return driver, err
+		}
+		return gpu.Detect()
+	})
+}
+
+// cachedGPUDriver 以 key 快取偵測結果，避免重複初始化驅動。
+func (app *App) cachedGPUDriver(key string, detect func() (gpu.Driver, error)) (gpu.Driver, error) {
 	app.gpuDetectMu.Lock()
 	defer app.gpuDetectMu.Unlock()
 
@@ -635,31 +910,36 @@ func (app *App) ensureGPUDriverForVendor(vendor string) (gpu.Driver, error) {
 		return driver, app.gpuDetectErrs[key]
 	}
 
-	var (
-		driver gpu.Driver
-		err    error
-	)
-
-	if vendor != "" {
-		// 先嘗試以指定廠牌偵測，失敗再退回一般偵測流程。
-		driver, err = gpu.DetectByName(vendor)
-		if errors.Is(err, gpu.ErrNoDriver) {
-			driver, err = gpu.Detect()
-		}
-	} else {
-		driver, err = gpu.Detect()
-	}
+	driver, err := detect()
 
 	// 將結果與錯誤都記錄起來，以利後續查詢。
 	if err != nil {
 		app.gpuDetectErrs[key] = err
 	} else {
+		// 以設定的重試策略包裝，吸收纜線不良造成的 DEFER 與逾時。
+		driver = gpu.NewRetryDriver(driver, app.config.Retry.Policy())
 		app.gpuDrivers[key] = driver
 		app.gpuDetectErrs[key] = nil
 	}
 	return driver, err
 }
 
+// driverRetries 回傳驅動累計的重試次數；未經重試包裝的驅動（例如 dry-run）為 0。
+func driverRetries(driver gpu.Driver) uint64 {
+	if retry, ok := driver.(*gpu.RetryDriver); ok {
+		return retry.Stats().Retries
+	}
+	return 0
+}
+
+// retryNote 回傳附加在完成訊息後的重試次數說明，沒有重試時為空字串。
+func retryNote(driver gpu.Driver, before uint64) string {
+	if n := driverRetries(driver) - before; n > 0 {
+		return fmt.Sprintf(" [yellow]（AUX 重試 %d 次）[-]", n)
+	}
+	return ""
+}
+
 func formatLuaResults(values []lua.LValue) string {
 	if len(values) == 0 {
 		return ""
@@ -775,19 +1055,24 @@ func tableToByteSlice(tbl *lua.LTable) ([]byte, error) {
 	return data, nil
 }
 
-// luaContext 建立提供給 Lua 腳本使用的資料內容。
-func (app *App) luaContext(driver gpu.Driver, detectErr error) map[string]interface{} {
-	currentIndex := app.displayList.GetCurrentItem()
+// luaContext 建立提供給 Lua 腳本使用的資料內容；target 為目前操作的顯示器。
+func (app *App) luaContext(list []*display.Display, target *display.Display, driver gpu.Driver, detectErr error) map[string]interface{} {
+	currentIndex := -1
+	if target != nil {
+		currentIndex = slices.Index(list, target)
+	}
 	// 建立一個可供 Lua 閱讀的顯示器資訊切片。
-	displays := make([]interface{}, len(app.displays))
+	displays := make([]interface{}, len(list))
 	var selectedDisplay map[string]interface{}
-	for i, d := range app.displays {
+	for i, d := range list {
 		// 逐一將顯示器欄位轉換成鍵值對，方便腳本使用。
 		entry := map[string]interface{}{
 			"adapter_name":    d.AdapterName,
 			"adapter_string":  d.AdapterString,
 			"device_id":       d.DeviceID,
 			"manufacturer_id": d.ManufacturerID,
+			"vendor_name":     d.VendorName,
+			"panel_model":     d.PanelModel,
 			"product_id":      d.ProductID,
 			"serial":          d.Serial,
 			"week":            d.Week,
@@ -798,6 +1083,17 @@ func (app *App) luaContext(driver gpu.Driver, detectErr error) map[string]interf
 			"descriptor2":     d.Descriptor2,
 			"descriptor3":     d.Descriptor3,
 			"descriptor4":     d.Descriptor4,
+			"source":          d.Source,
+			"warnings":        d.Warnings,
+			// 數值欄位與原始 EDID 位元組表（索引 1 對應位元組 0x00）。
+			"manufacturer_code": int(d.ManufacturerCode),
+			"product_code":      int(d.ProductCode),
+			"serial_number":     int64(d.SerialNumber),
+			"edid_version":      d.EDIDVersion,
+			"edid_revision":     d.EDIDRevision,
+			"extension_count":   d.ExtensionCount,
+			"edid":              []byte(d.EDID),
+			"detailed_timings":  detailedTimingsContext(d.DetailedTimings),
 		}
 		displays[i] = entry
 		if i == currentIndex {
@@ -807,12 +1103,9 @@ func (app *App) luaContext(driver gpu.Driver, detectErr error) map[string]interf
 	}
 
 	selectedIndex := currentIndex + 1
-	if len(app.displays) == 0 {
-		selectedIndex = 0
-	}
 	// context 包含顯示器清單與目前索引等摘要資訊。
 	context := map[string]interface{}{
-		"display_count":          len(app.displays),
+		"display_count":          len(list),
 		"displays":               displays,
 		"selected_display_index": selectedIndex,
 	}
@@ -821,14 +1114,21 @@ func (app *App) luaContext(driver gpu.Driver, detectErr error) map[string]interf
 		context["selected_display"] = selectedDisplay
 	}
 
+	_, dryRun := driver.(*gpu.DryRunDriver)
 	gpuInfo := map[string]interface{}{
 		"available": driver != nil,
+		"dry_run":   dryRun,
 	}
 	if driver != nil {
 		// 若成功取得驅動，提供其名稱給腳本識別。
 		gpuInfo["driver_name"] = driver.Name()
 	}
-	if vendor := app.selectedDisplayVendor(); vendor != "" {
+	if target != nil {
+		if choice := app.config.DisplayDriver(displayDriverKey(target)); choice != "" {
+			gpuInfo["override"] = choice
+		}
+	}
+	if vendor := app.vendorKeyForDisplay(target); vendor != "" {
 		gpuInfo["vendor"] = vendor
 		context["selected_display_vendor"] = vendor
 	}
@@ -841,6 +1141,47 @@ func (app *App) luaContext(driver gpu.Driver, detectErr error) map[string]interf
 	return context
 }
 
+// detailedTimingsContext 將詳細時序轉成 Lua 可讀的表，包含後廊、總數、精確更新率與旗標解碼結果。
+func detailedTimingsContext(timings []display.DetailedTiming) []interface{} {
+	list := make([]interface{}, len(timings))
+	for i, t := range timings {
+		list[i] = map[string]interface{}{
+			"pixel_clock":     t.PixelClock,
+			"h_active":        t.HActive,
+			"h_blank":         t.HBlank,
+			"h_front_porch":   t.HFrontPorch,
+			"h_sync_width":    t.HSyncWidth,
+			"h_back_porch":    t.HBackPorch(),
+			"h_total":         t.HTotal(),
+			"v_active":        t.VActive,
+			"v_blank":         t.VBlank,
+			"v_front_porch":   t.VFrontPorch,
+			"v_sync_width":    t.VSyncWidth,
+			"v_back_porch":    t.VBackPorch(),
+			"v_total":         t.VTotal(),
+			"h_image_size":    t.HImageSize,
+			"v_image_size":    t.VImageSize,
+			"h_border":        t.HBorder,
+			"v_border":        t.VBorder,
+			"flags":           int(t.Flags),
+			"refresh_rate":    t.RefreshRate(),
+			"interlaced":      t.Interlaced(),
+			"sync_type":       t.SyncType(),
+			"h_sync_polarity": t.HSyncPolarity(),
+			"v_sync_polarity": t.VSyncPolarity(),
+			"stereo":          t.Stereo(),
+		}
+	}
+	return list
+}
+
+// snapshotDisplays 回傳最近一次 populateDisplayList 時的顯示器清單，可在任何 goroutine 呼叫。
+func (app *App) snapshotDisplays() []*display.Display {
+	app.displaysMu.RLock()
+	defer app.displaysMu.RUnlock()
+	return app.displaySnapshot
+}
+
 func (app *App) currentDisplay() *display.Display {
 	index := app.displayList.GetCurrentItem()
 	if index < 0 || index >= len(app.displays) {
@@ -865,10 +1206,6 @@ func (app *App) vendorKeyForDisplay(d *display.Display) string {
 	}
 }
 
-func (app *App) selectedDisplayVendor() string {
-	return app.vendorKeyForDisplay(app.currentDisplay())
-}
-
 func (app *App) queueSetStatus(message string) {
 	// 將更新動作排入事件迴圈，避免與 UI 執行緒競爭。
 	app.app.QueueUpdateDraw(func() {
@@ -0,0 +1,293 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/config"
+	"GMTAUXOneKeyBuild/ddcci"
+	"GMTAUXOneKeyBuild/gpu"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// ddcCommonVCP 為「讀取常用」一次讀取的 VCP 代碼。
+var ddcCommonVCP = []byte{
+	ddcci.VCPBrightness,
+	ddcci.VCPContrast,
+	ddcci.VCPColorPreset,
+	ddcci.VCPVideoGainRed,
+	ddcci.VCPVideoGainGreen,
+	ddcci.VCPVideoGainBlue,
+	ddcci.VCPInputSource,
+	ddcci.VCPPowerMode,
+	ddcci.VCPVersion,
+}
+
+// ddcView 保存 DDC/CI 頁面的元件與輸入值。
+type ddcView struct {
+	root    *tview.Flex
+	form    *tview.Form
+	output  *tview.TextView
+	client  *ddcci.Client
+	vcp     string
+	value   string
+	running bool
+}
+
+// showDDCView 開啟外接螢幕的 DDC/CI（MCCS）調整頁面。
+func (app *App) showDDCView() {
+	driver, err := app.auxDriver()
+	if err != nil || driver == nil {
+		app.showModal(fmt.Sprintf("無法開啟 DDC/CI：%s", app.describeGPUError(err)))
+		return
+	}
+
+	view := &ddcView{client: app.newDDCClient(driver), vcp: "0x10", value: ""}
+
+	view.form = tview.NewForm().
+		AddInputField("VCP", view.vcp, 6, nil, func(text string) { view.vcp = strings.TrimSpace(text) }).
+		AddInputField("數值", view.value, 8, nil, func(text string) { view.value = strings.TrimSpace(text) }).
+		AddButton("讀取", func() { app.runDDC(view, app.ddcGet) }).
+		AddButton("寫入", func() { app.runDDC(view, app.ddcSet) }).
+		AddButton("讀取常用", func() { app.runDDC(view, app.ddcReadCommon) }).
+		AddButton("能力", func() { app.runDDC(view, app.ddcCapabilities) }).
+		AddButton("關閉", app.closeView)
+	view.form.SetBorder(true).
+		SetTitle(" DDC/CI ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	view.form.SetCancelFunc(app.closeView)
+
+	view.output = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.output.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s ", driver.Name())).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	fmt.Fprintln(view.output, "透過 I2C 從屬位址 0x37 與外接螢幕進行 DDC/CI 通訊。")
+	fmt.Fprintln(view.output, "常用 VCP：0x10 亮度、0x12 對比、0x14 色彩預設、0x16/0x18/0x1A RGB 增益。")
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(view.form, 40, 0, true).
+		AddItem(view.output, 0, 1, false)
+
+	view.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(view.root, view.form)
+}
+
+// runDDC 於背景執行 DDC/CI 操作，DDC/CI 每個訊息需間隔 50 ms，避免阻塞 UI。
+func (app *App) runDDC(view *ddcView, action func(*ddcView) (string, error)) {
+	if view.running {
+		return
+	}
+	view.running = true
+	app.setStatus("[yellow]DDC/CI 通訊中...[-]")
+	go func() {
+		text, err := action(view)
+		app.app.QueueUpdateDraw(func() {
+			view.running = false
+			if text != "" {
+				fmt.Fprintln(view.output, tview.Escape(text))
+			}
+			if err != nil {
+				fmt.Fprintf(view.output, "[red]%s[-]\n", tview.Escape(err.Error()))
+				app.setStatus(fmt.Sprintf("[red]DDC/CI 失敗: %v[-]", err))
+			} else {
+				app.setStatus("[green]DDC/CI 完成[-]")
+			}
+			view.output.ScrollToEnd()
+		})
+	}()
+}
+
+func parseVCPCode(text string) (byte, error) {
+	v, err := strconv.ParseUint(text, 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("VCP 代碼 %q 無效，需為 0x00~0xFF", text)
+	}
+	return byte(v), nil
+}
+
+// formatVCPValue 以名稱與目前值/最大值描述 VCP。
+func formatVCPValue(v ddcci.VCPValue) string {
+	text := fmt.Sprintf("0x%02X %-28s %5d / %d", v.Code, ddcci.VCPName(v.Code), v.Current, v.Max)
+	if v.Code == ddcci.VCPColorPreset {
+		if preset, ok := ddcci.ColorPresets[v.Current]; ok {
+			text += "  (" + preset + ")"
+		}
+	}
+	return text
+}
+
+func (app *App) ddcGet(view *ddcView) (string, error) {
+	code, err := parseVCPCode(view.vcp)
+	if err != nil {
+		return "", err
+	}
+	v, err := view.client.GetVCP(code)
+	if err != nil {
+		return "", err
+	}
+	return formatVCPValue(v), nil
+}
+
+// ddcSet 寫入後再讀回確認。
+func (app *App) ddcSet(view *ddcView) (string, error) {
+	code, err := parseVCPCode(view.vcp)
+	if err != nil {
+		return "", err
+	}
+	value, err := strconv.ParseUint(view.value, 0, 16)
+	if err != nil {
+		return "", fmt.Errorf("數值 %q 無效，需為 0~65535", view.value)
+	}
+	if err := view.client.SetVCP(code, uint16(value)); err != nil {
+		return "", err
+	}
+	v, err := view.client.GetVCP(code)
+	if err != nil {
+		return fmt.Sprintf("0x%02X %s <= %d（讀回失敗）", code, ddcci.VCPName(code), value), err
+	}
+	return fmt.Sprintf("0x%02X %s <= %d，讀回 %d", code, ddcci.VCPName(code), value, v.Current), nil
+}
+
+func (app *App) ddcReadCommon(view *ddcView) (string, error) {
+	var lines []string
+	for _, code := range ddcCommonVCP {
+		v, err := view.client.GetVCP(code)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("0x%02X %-28s %v", code, ddcci.VCPName(code), err))
+			continue
+		}
+		lines = append(lines, formatVCPValue(v))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (app *App) ddcCapabilities(view *ddcView) (string, error) {
+	raw, err := view.client.Capabilities()
+	if err != nil {
+		return raw, err
+	}
+	caps, err := ddcci.ParseCapabilities(raw)
+	if err != nil {
+		return raw, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\nmodel: %s  type: %s  mccs_ver: %s\n", raw, caps.Model(), caps.Fields["type"], caps.MCCSVersion())
+	for _, code := range caps.VCPCodes() {
+		fmt.Fprintf(&b, "  0x%02X %s", code, ddcci.VCPName(code))
+		if values := caps.VCP[code]; len(values) > 0 {
+			parts := make([]string, len(values))
+			for i, v := range values {
+				parts[i] = fmt.Sprintf("%02X", v)
+			}
+			fmt.Fprintf(&b, " (%s)", strings.Join(parts, " "))
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// newDDCClient 建立套用設定檔延遲的 DDC/CI 客戶端；driver 為 nil 時回傳 nil。
+func (app *App) newDDCClient(driver gpu.Driver) *ddcci.Client {
+	if driver == nil {
+		return nil
+	}
+	client := ddcci.New(driver)
+	client.ReplyDelay = config.Millis(app.config.AUX.DDCReplyDelayMS)
+	client.CommandDelay = config.Millis(app.config.AUX.DDCCommandDelayMS)
+	return client
+}
+
+// luaDDCModule 提供 Lua 的 ddc.get、ddc.set 與 ddc.capabilities；client 為 nil 時回傳 describeError 的訊息。
+func luaDDCModule(client *ddcci.Client, describeError func() string) map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		"get": func(L *lua.LState) int {
+			if client == nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(describeError()))
+				return 2
+			}
+			v, err := client.GetVCP(byte(L.CheckInt(1)))
+			if err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			// 回傳目前值與最大值。
+			L.Push(lua.LNumber(v.Current))
+			L.Push(lua.LNumber(v.Max))
+			return 2
+		},
+		"set": func(L *lua.LState) int {
+			if client == nil {
+				L.Push(lua.LBool(false))
+				L.Push(lua.LString(describeError()))
+				return 2
+			}
+			code := byte(L.CheckInt(1))
+			value := L.CheckInt(2)
+			if value < 0 || value > 0xFFFF {
+				L.ArgError(2, "value must be between 0 and 65535")
+				return 0
+			}
+			if err := client.SetVCP(code, uint16(value)); err != nil {
+				L.Push(lua.LBool(false))
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			L.Push(lua.LBool(true))
+			return 1
+		},
+		"capabilities": func(L *lua.LState) int {
+			if client == nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(describeError()))
+				return 2
+			}
+			raw, err := client.Capabilities()
+			if err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			caps, err := ddcci.ParseCapabilities(raw)
+			if err != nil {
+				L.Push(lua.LString(raw))
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			tbl := L.NewTable()
+			tbl.RawSetString("raw", lua.LString(raw))
+			for key, value := range caps.Fields {
+				if key != "vcp" && key != "cmds" {
+					tbl.RawSetString(key, lua.LString(value))
+				}
+			}
+			vcp := L.NewTable()
+			for code, values := range caps.VCP {
+				list := L.NewTable()
+				for i, v := range values {
+					list.RawSetInt(i+1, lua.LNumber(v))
+				}
+				vcp.RawSetInt(int(code), list)
+			}
+			tbl.RawSetString("vcp", vcp)
+			L.Push(tbl)
+			return 1
+		},
+	}
+}
@@ -0,0 +1,325 @@
+package ui
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/dpcd"
+	"GMTAUXOneKeyBuild/gpu"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	dpcdHexPageSize  = 0x100
+	dpcdHexChunkSize = 16 // 單次 AUX 讀取最多 16 位元組
+	dpcdHexLimit     = 0x100000
+)
+
+// dpcdHexView 保存 DPCD 十六進位檢視器的狀態。
+type dpcdHexView struct {
+	root    *tview.Flex
+	jump    *tview.InputField
+	table   *tview.Table
+	info    *tview.TextView
+	driver  gpu.Driver
+	base    uint32
+	data    []byte
+	valid   []bool
+	changed []bool
+	loading bool
+}
+
+// showDPCDHexView 開啟選定顯示器的 DPCD 十六進位檢視與編輯頁面。
+func (app *App) showDPCDHexView() {
+	driver, err := app.auxDriver()
+	if err != nil || driver == nil {
+		app.showModal(fmt.Sprintf("無法開啟 DPCD 檢視器：%s", app.describeGPUError(err)))
+		return
+	}
+
+	view := &dpcdHexView{driver: driver}
+
+	view.jump = tview.NewInputField().
+		SetLabel("跳至位址: ").
+		SetFieldWidth(10).
+		SetText("0x00000")
+	view.jump.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			addr, err := strconv.ParseUint(strings.TrimSpace(view.jump.GetText()), 0, 32)
+			if err != nil || addr >= dpcdHexLimit {
+				app.setStatus("[red]位址需為 0x00000~0xFFFFF[-]")
+				return
+			}
+			app.loadDPCDPage(view, uint32(addr)&^(dpcdHexPageSize-1), uint32(addr))
+		}
+		app.app.SetFocus(view.table)
+	})
+
+	view.table = tview.NewTable().
+		SetSelectable(true, true).
+		SetFixed(1, 1)
+	view.table.SetBorder(true).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	view.table.SetSelectionChangedFunc(func(row, column int) {
+		app.annotateDPCDByte(view, row, column)
+	})
+	view.table.SetSelectedFunc(func(row, column int) {
+		app.editDPCDByte(view, row, column)
+	})
+	view.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyPgDn:
+			app.loadDPCDPage(view, (view.base+dpcdHexPageSize)%dpcdHexLimit, 0)
+			return nil
+		case tcell.KeyPgUp:
+			app.loadDPCDPage(view, (view.base+dpcdHexLimit-dpcdHexPageSize)%dpcdHexLimit, 0)
+			return nil
+		case tcell.KeyTAB, tcell.KeyBacktab:
+			app.app.SetFocus(view.jump)
+			return nil
+		}
+		switch event.Rune() {
+		case 'g':
+			app.app.SetFocus(view.jump)
+			return nil
+		case 'r':
+			app.loadDPCDPage(view, view.base, 0)
+			return nil
+		}
+		return event
+	})
+
+	view.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+	view.info.SetBorder(true).
+		SetTitle(" Field ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow]PgUp/PgDn[-] 換頁  [yellow]g[-] 跳至位址  [yellow]r[-] 重新讀取  [yellow]Enter[-] 修改  [yellow]Esc[-] 關閉  [red]紅色[-] 為上次讀取後變動的位元組")
+
+	left := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(view.jump, 1, 0, false).
+		AddItem(view.table, 0, 1, true).
+		AddItem(help, 1, 0, false)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(left, 58, 0, true).
+		AddItem(view.info, 0, 1, false)
+
+	view.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(view.root, view.table)
+	app.loadDPCDPage(view, 0, 0)
+}
+
+// loadDPCDPage 於背景讀取一整頁；同一頁重新讀取時標示變動的位元組，cursor 為讀取後選取的位址。
+func (app *App) loadDPCDPage(view *dpcdHexView, base, cursor uint32) {
+	if view.loading {
+		return
+	}
+	view.loading = true
+	app.setStatus(fmt.Sprintf("[yellow]讀取 DPCD 0x%05X...[-]", base))
+
+	go func() {
+		data := make([]byte, dpcdHexPageSize)
+		valid := make([]bool, dpcdHexPageSize)
+		var failed int
+		for offset := uint32(0); offset < dpcdHexPageSize; offset += dpcdHexChunkSize {
+			chunk, err := view.driver.ReadDPCD(base+offset, dpcdHexChunkSize)
+			if err != nil {
+				failed++
+				continue
+			}
+			copy(data[offset:], chunk)
+			for i := range chunk {
+				valid[int(offset)+i] = true
+			}
+		}
+
+		app.app.QueueUpdateDraw(func() {
+			view.loading = false
+			changed := make([]bool, dpcdHexPageSize)
+			if base == view.base && view.data != nil {
+				for i := range data {
+					changed[i] = valid[i] && view.valid[i] && data[i] != view.data[i]
+				}
+			}
+			view.base, view.data, view.valid, view.changed = base, data, valid, changed
+			app.renderDPCDPage(view)
+			if cursor >= base && cursor < base+dpcdHexPageSize {
+				offset := int(cursor - base)
+				view.table.Select(offset/16+1, offset%16+1)
+			}
+			row, column := view.table.GetSelection()
+			app.annotateDPCDByte(view, row, column)
+			if failed > 0 {
+				app.setStatus(fmt.Sprintf("[yellow]DPCD 0x%05X：%d 段讀取失敗（以 -- 表示）[-]", base, failed))
+				return
+			}
+			app.setStatus(fmt.Sprintf("[green]DPCD 0x%05X-0x%05X 讀取完成[-]", base, base+dpcdHexPageSize-1))
+		})
+	}()
+}
+
+// renderDPCDPage 將目前頁面填入表格；已知暫存器以青色、變動的位元組以紅色顯示。
+func (app *App) renderDPCDPage(view *dpcdHexView) {
+	view.table.Clear()
+	view.table.SetTitle(fmt.Sprintf(" DPCD 0x%05X-0x%05X (%s) ", view.base, view.base+dpcdHexPageSize-1, view.driver.Name()))
+	view.table.SetCell(0, 0, tview.NewTableCell("Addr").SetSelectable(false).SetTextColor(tcell.ColorYellow))
+	for col := 0; col < 16; col++ {
+		view.table.SetCell(0, col+1, tview.NewTableCell(fmt.Sprintf("%02X", col)).
+			SetSelectable(false).
+			SetTextColor(tcell.ColorYellow))
+	}
+	for row := 0; row < dpcdHexPageSize/16; row++ {
+		view.table.SetCell(row+1, 0, tview.NewTableCell(fmt.Sprintf("%05X", view.base+uint32(row*16))).
+			SetSelectable(false).
+			SetTextColor(tcell.ColorYellow))
+		for col := 0; col < 16; col++ {
+			i := row*16 + col
+			cell := tview.NewTableCell("--").SetTextColor(tcell.ColorGray)
+			if view.valid[i] {
+				cell.SetText(fmt.Sprintf("%02X", view.data[i])).SetTextColor(tcell.ColorWhite)
+				if _, ok := dpcd.Find(view.base + uint32(i)); ok {
+					cell.SetTextColor(tcell.ColorAqua)
+				}
+				if view.changed[i] {
+					cell.SetTextColor(tcell.ColorRed)
+				}
+			}
+			view.table.SetCell(row+1, col+1, cell)
+		}
+	}
+}
+
+// dpcdHexOffset 將表格座標轉成頁內位移，標題列與位址欄回傳 -1。
+func dpcdHexOffset(row, column int) int {
+	if row < 1 || column < 1 || row > dpcdHexPageSize/16 || column > 16 {
+		return -1
+	}
+	return (row-1)*16 + column - 1
+}
+
+// annotateDPCDByte 顯示選取位元組所屬暫存器的欄位說明。
+func (app *App) annotateDPCDByte(view *dpcdHexView, row, column int) {
+	offset := dpcdHexOffset(row, column)
+	if offset < 0 || view.data == nil {
+		return
+	}
+	addr := view.base + uint32(offset)
+	view.info.Clear()
+	if !view.valid[offset] {
+		fmt.Fprintf(view.info, "0x%05X\n\n[red]讀取失敗[-]", addr)
+		return
+	}
+	fmt.Fprintf(view.info, "[yellow]0x%05X[-] = 0x%02X (%d, 0b%08b)\n\n", addr, view.data[offset], view.data[offset], view.data[offset])
+
+	reg, ok := dpcd.Find(addr)
+	if !ok {
+		fmt.Fprint(view.info, "未定義的暫存器")
+		return
+	}
+	start := int(reg.Address - view.base)
+	end := start + reg.Size()
+	if reg.Address < view.base || end > len(view.data) {
+		// 暫存器跨頁時只顯示名稱。
+		fmt.Fprintf(view.info, "%s (%s)", reg.Name, reg.Group)
+		return
+	}
+	for _, d := range dpcd.Decode(reg.Address, view.data[start:end]) {
+		fmt.Fprintf(view.info, "[aqua]%s[-]\n%s\n", tview.Escape(d.Name), tview.Escape(d.Group))
+		if d.Summary != "" {
+			fmt.Fprintf(view.info, "\n%s\n", tview.Escape(d.Summary))
+		}
+		for _, f := range d.Fields {
+			fmt.Fprintf(view.info, "\n%s = %d", tview.Escape(f.Name), f.Value)
+			if f.Meaning != "" {
+				fmt.Fprintf(view.info, "\n  %s", tview.Escape(f.Meaning))
+			}
+		}
+	}
+}
+
+// editDPCDByte 以輸入框修改選取位址，可一次輸入多個位元組，確認後寫入。
+func (app *App) editDPCDByte(view *dpcdHexView, row, column int) {
+	offset := dpcdHexOffset(row, column)
+	if offset < 0 || view.data == nil {
+		return
+	}
+	addr := view.base + uint32(offset)
+
+	input := tview.NewInputField().
+		SetLabel(fmt.Sprintf("0x%05X = ", addr)).
+		SetFieldWidth(24)
+	if view.valid[offset] {
+		input.SetText(fmt.Sprintf("%02X", view.data[offset]))
+	}
+	input.SetBorder(true).SetTitle(" 修改 DPCD（十六進位，可輸入多個位元組）")
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key != tcell.KeyEnter {
+			app.restoreRoot()
+			return
+		}
+		cleaned := strings.NewReplacer(" ", "", ",", "").Replace(input.GetText())
+		data, err := hex.DecodeString(cleaned)
+		if err != nil || len(data) == 0 || len(data) > dpcdHexChunkSize {
+			app.setStatus(fmt.Sprintf("[red]請輸入 1~%d 個十六進位位元組[-]", dpcdHexChunkSize))
+			app.restoreRoot()
+			return
+		}
+		app.confirmDPCDWrite(view, addr, data)
+	})
+
+	frame := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(input, 3, 0, true).
+			AddItem(nil, 0, 1, false), 50, 0, true).
+		AddItem(nil, 0, 1, false)
+	app.app.SetRoot(frame, true).SetFocus(input)
+}
+
+// confirmDPCDWrite 在寫入前要求使用者確認，寫入後重新讀取目前頁面。
+func (app *App) confirmDPCDWrite(view *dpcdHexView, addr uint32, data []byte) {
+	name := "未定義的暫存器"
+	if reg, ok := dpcd.Find(addr); ok {
+		name = reg.Name
+	}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("確定寫入 DPCD 0x%05X (%s)？\n% X", addr, name, data)).
+		AddButtons([]string{"寫入", "取消"}).
+		SetDoneFunc(func(index int, _ string) {
+			app.restoreRoot()
+			if index != 0 {
+				return
+			}
+			go func() {
+				if err := view.driver.WriteDPCD(addr, data); err != nil {
+					app.queueShowModal(fmt.Sprintf("DPCD 0x%05X 寫入失敗：\n%v", addr, err))
+					return
+				}
+				app.app.QueueUpdateDraw(func() {
+					app.loadDPCDPage(view, view.base, addr)
+				})
+			}()
+		})
+	app.app.SetRoot(modal, true).SetFocus(modal)
+}
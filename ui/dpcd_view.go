@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/dpcd"
+	"GMTAUXOneKeyBuild/gpu"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// dpcdView 保存 DPCD 解碼頁面的元件。
+type dpcdView struct {
+	root    *tview.Flex
+	list    *tview.List
+	form    *tview.Form
+	output  *tview.TextView
+	groups  []dpcd.Group
+	address string
+	length  string
+}
+
+// showDPCDView 開啟 DPCD 暫存器解碼頁面，可依類別或自訂範圍讀取並解碼。
+func (app *App) showDPCDView() {
+	view := &dpcdView{groups: dpcd.Groups(), address: "0x00000", length: "16"}
+
+	view.list = tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true)
+	view.list.SetBorder(true).
+		SetTitle(" Register Groups ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	for _, g := range view.groups {
+		view.list.AddItem(g.Name, "", 0, nil)
+	}
+	view.list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		if index >= 0 && index < len(view.groups) {
+			app.readDPCDRanges(view, view.groups[index].Name, view.groups[index].Ranges)
+		}
+	})
+
+	view.form = tview.NewForm().
+		AddInputField("位址", view.address, 10, nil, func(text string) { view.address = strings.TrimSpace(text) }).
+		AddInputField("長度", view.length, 6, nil, func(text string) { view.length = strings.TrimSpace(text) }).
+		AddButton("讀取", func() { app.readDPCDCustom(view) }).
+		AddButton("關閉", app.closeView)
+	view.form.SetBorder(true).
+		SetTitle(" 自訂範圍 ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	view.form.SetCancelFunc(app.closeView)
+
+	view.output = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.output.SetBorder(true).
+		SetTitle(" Decoded ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	fmt.Fprintln(view.output, "選擇左側類別並按 Enter 讀取，或於下方輸入自訂範圍。")
+
+	// Tab 依序在類別清單、自訂範圍與解碼結果之間切換。
+	focusOrder := []tview.Primitive{view.list, view.form, view.output}
+	cycle := func(from tview.Primitive, step int) {
+		for i, p := range focusOrder {
+			if p == from {
+				app.app.SetFocus(focusOrder[(i+step+len(focusOrder))%len(focusOrder)])
+				return
+			}
+		}
+	}
+	view.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTAB:
+			cycle(view.list, 1)
+			return nil
+		case tcell.KeyBacktab:
+			cycle(view.list, -1)
+			return nil
+		}
+		return event
+	})
+	view.output.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTAB:
+			cycle(view.output, 1)
+			return nil
+		case tcell.KeyBacktab:
+			cycle(view.output, -1)
+			return nil
+		}
+		return event
+	})
+
+	left := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(view.list, 0, 1, true).
+		AddItem(view.form, 9, 0, false)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(left, 0, 1, true).
+		AddItem(view.output, 0, 3, false)
+
+	view.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(view.root, view.list)
+}
+
+// readDPCDCustom 解析自訂範圍並讀取。
+func (app *App) readDPCDCustom(view *dpcdView) {
+	addr, err := strconv.ParseUint(view.address, 0, 32)
+	if err != nil || addr > 0xFFFFF {
+		app.showModal(fmt.Sprintf("位址 %q 無效，需為 0x00000~0xFFFFF", view.address))
+		return
+	}
+	length, err := strconv.ParseUint(view.length, 0, 32)
+	if err != nil || length == 0 || length > 256 {
+		app.showModal(fmt.Sprintf("長度 %q 無效，需為 1~256", view.length))
+		return
+	}
+	app.readDPCDRanges(view, fmt.Sprintf("0x%05X+%d", addr, length), []dpcd.Range{{Start: uint32(addr), Length: uint32(length)}})
+}
+
+// readDPCDRanges 於背景讀取指定區段並顯示解碼結果。
+func (app *App) readDPCDRanges(view *dpcdView, title string, ranges []dpcd.Range) {
+	view.output.Clear()
+	fmt.Fprintf(view.output, "[yellow]%s[-] 讀取中...\n", tview.Escape(title))
+	app.app.SetFocus(view.output)
+
+	go func() {
+		driver, err := app.auxDriver()
+		if err != nil || driver == nil {
+			message := app.describeGPUError(err)
+			app.app.QueueUpdateDraw(func() {
+				view.output.Clear()
+				fmt.Fprintf(view.output, "[red]GPU 驅動不可用: %s[-]\n", tview.Escape(message))
+			})
+			return
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "[yellow]%s[-] via %s\n", tview.Escape(title), tview.Escape(driver.Name()))
+		for _, r := range ranges {
+			data, err := readDPCDChunked(driver, r.Start, r.Length)
+			if err != nil {
+				fmt.Fprintf(&b, "\n[red]0x%05X+%d 讀取失敗: %s[-]\n", r.Start, r.Length, tview.Escape(err.Error()))
+				continue
+			}
+			fmt.Fprintf(&b, "\n%s\n", tview.Escape(dpcd.Format(r.Start, data)))
+		}
+		app.app.QueueUpdateDraw(func() {
+			view.output.SetText(b.String())
+			view.output.ScrollToBeginning()
+		})
+	}()
+}
+
+// readDPCDChunked 以每次最多 16 位元組讀取 DPCD，符合原生 AUX 交易與 NVAPI 的長度限制。
+func readDPCDChunked(driver gpu.Driver, start, length uint32) ([]byte, error) {
+	data := make([]byte, 0, length)
+	for offset := uint32(0); offset < length; offset += dpcdHexChunkSize {
+		n := min(length-offset, dpcdHexChunkSize)
+		chunk, err := driver.ReadDPCD(start+offset, n)
+		if err != nil {
+			return nil, fmt.Errorf("read 0x%05X: %w", start+offset, err)
+		}
+		data = append(data, chunk...)
+	}
+	return data, nil
+}
+
+// luaDPCDModule 提供 Lua 的 dpcd.decode 與 dpcd.format。
+func luaDPCDModule() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		"decode": func(L *lua.LState) int {
+			address := uint32(L.CheckInt(1))
+			data, err := tableToByteSlice(L.CheckTable(2))
+			if err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			result := L.NewTable()
+			for i, d := range dpcd.Decode(address, data) {
+				entry := L.NewTable()
+				entry.RawSetString("address", lua.LNumber(d.Address))
+				entry.RawSetString("name", lua.LString(d.Name))
+				entry.RawSetString("group", lua.LString(d.Group))
+				raw := L.NewTable()
+				for j, b := range d.Raw {
+					raw.RawSetInt(j+1, lua.LNumber(b))
+				}
+				entry.RawSetString("raw", raw)
+				if d.Summary != "" {
+					entry.RawSetString("summary", lua.LString(d.Summary))
+				}
+				fields := L.NewTable()
+				for j, f := range d.Fields {
+					field := L.NewTable()
+					field.RawSetString("name", lua.LString(f.Name))
+					field.RawSetString("value", lua.LNumber(f.Value))
+					field.RawSetString("meaning", lua.LString(f.Meaning))
+					fields.RawSetInt(j+1, field)
+				}
+				entry.RawSetString("fields", fields)
+				result.RawSetInt(i+1, entry)
+			}
+			L.Push(result)
+			return 1
+		},
+		"format": func(L *lua.LState) int {
+			address := uint32(L.CheckInt(1))
+			data, err := tableToByteSlice(L.CheckTable(2))
+			if err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			L.Push(lua.LString(dpcd.Format(address, data)))
+			return 1
+		},
+	}
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"GMTAUXOneKeyBuild/config"
+	"GMTAUXOneKeyBuild/gpu"
+	display "GMTAUXOneKeyBuild/struct"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 手動指定驅動時，除已註冊的驅動名稱外可使用的選項。
+const (
+	driverSim    = "sim"    // 模擬驅動，快照檔存在時以其為初始內容
+	driverRemote = "remote" // 遠端測試機，值可寫成 remote:<host[:port]>
+)
+
+// displayDriverKey 回傳記錄手動驅動選擇所用的顯示器鍵值；沒有裝置識別碼時改用顯示卡名稱。
+func displayDriverKey(d *display.Display) string {
+	if d.DeviceID != "" {
+		return d.DeviceID
+	}
+	return d.AdapterName
+}
+
+// driverChoiceOptions 回傳可手動選擇的驅動：auto、本平台已註冊的驅動、sim 與 remote。
+func driverChoiceOptions() []string {
+	options := []string{config.DriverAuto}
+	options = append(options, gpu.Providers()...)
+	return append(options, driverSim, driverRemote)
+}
+
+// splitDriverChoice 將設定值拆成選項與遠端位址，例如 remote:bench:7420。
+func splitDriverChoice(choice string) (string, string) {
+	if name, addr, ok := strings.Cut(choice, ":"); ok && name == driverRemote {
+		return driverRemote, addr
+	}
+	return choice, ""
+}
+
+// openDriverChoice 依手動指定的驅動建立或取得快取的驅動；找不到時不會退回自動偵測。
+func (app *App) openDriverChoice(choice string) (gpu.Driver, error) {
+	name, addr := splitDriverChoice(choice)
+	switch name {
+	case driverSim:
+		return app.cachedGPUDriver("sim", func() (gpu.Driver, error) {
+			return app.newSimDriver()
+		})
+	case driverRemote:
+		if addr == "" {
+			addr = app.remoteAddr
+		}
+		if addr == "" {
+			return nil, errors.New("remote driver selected but no address given (use -remote or remote:<host[:port]>)")
+		}
+		return app.dialRemote(addr)
+	default:
+		return app.cachedGPUDriver("provider:"+name, func() (gpu.Driver, error) {
+			driver, err := gpu.DetectByName(name)
+			if err != nil {
+				return nil, fmt.Errorf("driver %s: %w", name, err)
+			}
+			return driver, nil
+		})
+	}
+}
+
+// showDriverView 開啟目前顯示器的驅動選擇頁面，選擇會記錄在設定檔中。
+func (app *App) showDriverView() {
+	d := app.currentDisplay()
+	if d == nil {
+		app.showModal("請先在螢幕列表選擇顯示器")
+		return
+	}
+	key := displayDriverKey(d)
+	options := driverChoiceOptions()
+	choice, addr := splitDriverChoice(app.config.DisplayDriver(key))
+	if choice == "" {
+		choice = config.DriverAuto
+	}
+	if addr == "" {
+		addr = app.remoteAddr
+	}
+	index := slices.Index(options, choice)
+	if index < 0 {
+		// 設定檔中的驅動未在本平台註冊時仍列出，方便改回 auto。
+		options = append(options, choice)
+		index = len(options) - 1
+	}
+
+	info := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	info.SetBorder(true).
+		SetTitle(" Driver ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	fmt.Fprintf(info, "顯示器：%s\n顯示卡描述：%s\n識別鍵：%s\n\n", tview.Escape(d.AdapterName), tview.Escape(d.AdapterString), tview.Escape(key))
+	fmt.Fprintln(info, app.describeAutoDriver(d))
+	fmt.Fprintf(info, "\n本平台已註冊的驅動：%s\n", strings.Join(gpu.Providers(), "、"))
+	fmt.Fprintln(info, "sim 使用模擬驅動（快照檔存在時載入）；remote 轉送至遠端測試機。")
+
+	form := tview.NewForm()
+	form.AddDropDown("驅動", options, index, func(option string, _ int) { choice = option }).
+		AddInputField("遠端位址", addr, 24, nil, func(text string) { addr = strings.TrimSpace(text) }).
+		AddButton("套用", func() {
+			value := choice
+			if choice == driverRemote && addr != "" && addr != app.remoteAddr {
+				value = driverRemote + ":" + addr
+			}
+			app.setDisplayDriver(key, value)
+		}).
+		AddButton("測試", func() {
+			value := choice
+			if choice == driverRemote && addr != "" {
+				value = driverRemote + ":" + addr
+			}
+			app.testDriverChoice(info, value)
+		}).
+		AddButton("關閉", app.closeView)
+	form.SetBorder(true).
+		SetTitle(" Select Driver ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	form.SetCancelFunc(app.closeView)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(form, 40, 0, true).
+		AddItem(info, 0, 1, false)
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(root, form)
+}
+
+// describeAutoDriver 說明選擇 auto 時會使用的驅動來源。
+func (app *App) describeAutoDriver(d *display.Display) string {
+	if app.remoteAddr != "" {
+		return fmt.Sprintf("auto：轉送至遠端測試機 %s", tview.Escape(app.remoteAddr))
+	}
+	vendor := app.vendorKeyForDisplay(d)
+	if name := app.config.Driver(vendor); name != "" {
+		return fmt.Sprintf("auto：依設定檔的廠牌偏好使用 %s", name)
+	}
+	if vendor != "" {
+		return fmt.Sprintf("auto：依顯示卡描述判斷為 %s，依序嘗試該廠牌的驅動", vendor)
+	}
+	return "auto：依序嘗試所有已註冊的驅動"
+}
+
+// setDisplayDriver 記錄顯示器的驅動選擇並寫入設定檔；value 為 auto 時清除記錄。
+func (app *App) setDisplayDriver(key, value string) {
+	cfg := app.config
+	cfg.DisplayDrivers = maps.Clone(cfg.DisplayDrivers)
+	if cfg.DisplayDrivers == nil {
+		cfg.DisplayDrivers = map[string]string{}
+	}
+	if value == config.DriverAuto {
+		delete(cfg.DisplayDrivers, key)
+	} else {
+		cfg.DisplayDrivers[key] = value
+	}
+	if err := config.Save(app.configPath, cfg); err != nil {
+		app.showModal(fmt.Sprintf("驅動設定儲存失敗：%v", err))
+		return
+	}
+	app.config = cfg
+	app.closeView()
+	app.setStatus(fmt.Sprintf("[green]%s 的驅動已設為 %s[-]", key, value))
+}
+
+// testDriverChoice 於背景開啟驅動並讀取 DPCD 版本，結果附加在說明區。
+func (app *App) testDriverChoice(info *tview.TextView, choice string) {
+	if choice == config.DriverAuto {
+		app.setStatus("[yellow]auto 會依目前設定自動判斷，請選擇特定驅動後再測試[-]")
+		return
+	}
+	app.setStatus(fmt.Sprintf("[yellow]測試驅動 %s 中...[-]", choice))
+	go func() {
+		var result string
+		driver, err := app.openDriverChoice(choice)
+		if err != nil {
+			result = fmt.Sprintf("[red]%s：%s[-]", choice, tview.Escape(err.Error()))
+		} else if rev, err := driver.ReadDPCD(0x0000, 1); err != nil {
+			result = fmt.Sprintf("[yellow]%s：已建立 %s，但讀取 DPCD 失敗: %s[-]", choice, tview.Escape(driver.Name()), tview.Escape(err.Error()))
+		} else {
+			result = fmt.Sprintf("[green]%s：%s，DPCD 版本 %d.%d[-]", choice, tview.Escape(driver.Name()), rev[0]>>4, rev[0]&0x0F)
+		}
+		app.app.QueueUpdateDraw(func() {
+			fmt.Fprintf(info, "\n%s\n", result)
+			app.setStatus(result)
+		})
+	}()
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"GMTAUXOneKeyBuild/gpu"
+	display "GMTAUXOneKeyBuild/struct"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SetDryRun 設定 dry-run 模式與讀取用的快照檔，供命令列參數使用。
+func (app *App) SetDryRun(enabled bool, snapshotPath string) {
+	app.dryRun = enabled
+	if snapshotPath != "" {
+		app.snapshotPath = snapshotPath
+	}
+	app.updateDryRunIndicator()
+}
+
+// updateDryRunIndicator 在主選單標題標示目前是否為 dry-run 模式。
+func (app *App) updateDryRunIndicator() {
+	if app.dryRun {
+		app.mainMenu.SetTitle(" Main Menu [DRY-RUN] ")
+		return
+	}
+	app.mainMenu.SetTitle(" Main Menu ")
+}
+
+// newDryRunDriver 建立一次執行使用的 dry-run 驅動；快照檔存在時先載入作為讀取來源。
+func (app *App) newDryRunDriver() (*gpu.DryRunDriver, error) {
+	sim, err := app.newSimDriver()
+	if err != nil {
+		return nil, err
+	}
+	return gpu.NewDryRunDriver(sim), nil
+}
+
+// newSimDriver 建立模擬驅動；快照檔存在時先載入作為初始內容。
+func (app *App) newSimDriver() (*gpu.SimDriver, error) {
+	sim := gpu.NewSimDriver()
+	if app.snapshotPath != "" {
+		if err := sim.LoadSnapshot(app.snapshotPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return sim, nil
+}
+
+// auxDriver 回傳唯讀操作使用的驅動；dry-run 時改由快照或模擬空間提供資料。
+func (app *App) auxDriver() (gpu.Driver, error) {
+	return app.auxDriverFor(app.currentDisplay())
+}
+
+// auxDriverFor 與 auxDriver 相同，但操作指定的顯示器而非清單中選取的項目。
+func (app *App) auxDriverFor(d *display.Display) (gpu.Driver, error) {
+	if app.dryRun {
+		return app.newDryRunDriver()
+	}
+	return app.driverForDisplay(d)
+}
+
+// toggleDryRun 切換 dry-run 模式；啟用時可選擇先擷取目前面板的快照作為讀取來源。
+func (app *App) toggleDryRun() {
+	if app.dryRun {
+		app.SetDryRun(false, "")
+		app.setStatus("[green]已關閉 Dry-run 模式，後續操作將寫入硬體[-]")
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("啟用 Dry-run 模式\n寫入只會被記錄，讀取由快照 %s 或模擬空間提供", app.snapshotPath)).
+		AddButtons([]string{"啟用", "擷取目前面板後啟用", "取消"}).
+		SetDoneFunc(func(index int, _ string) {
+			app.restoreRoot()
+			switch index {
+			case 0:
+				app.SetDryRun(true, "")
+				app.setStatus("[yellow]Dry-run 模式已啟用，不會寫入硬體[-]")
+			case 1:
+				app.setStatus("[yellow]擷取面板快照中...[-]")
+				go app.captureSnapshotAndEnableDryRun()
+			}
+		})
+	app.app.SetRoot(modal, true).SetFocus(modal)
+}
+
+// captureSnapshotAndEnableDryRun 以唯讀方式擷取常用 DPCD 與 EDID 區段後啟用 dry-run。
+func (app *App) captureSnapshotAndEnableDryRun() {
+	driver, err := app.ensureGPUDriver()
+	if err != nil || driver == nil {
+		app.queueShowModal(fmt.Sprintf("快照擷取失敗:\n%s", app.describeGPUError(err)))
+		app.queueSetStatus("[red]快照擷取失敗[-]")
+		return
+	}
+
+	snap, captureErr := gpu.CaptureSnapshot(driver, gpu.DefaultSnapshotRanges)
+	if err := snap.Save(app.snapshotPath); err != nil {
+		app.queueShowModal(fmt.Sprintf("快照儲存失敗:\n%v", err))
+		app.queueSetStatus("[red]快照儲存失敗[-]")
+		return
+	}
+
+	app.app.QueueUpdateDraw(func() {
+		app.SetDryRun(true, "")
+		if captureErr != nil {
+			// 部分區段讀取失敗時仍啟用，未擷取的位址會讀到 0x00。
+			app.setStatus(fmt.Sprintf("[yellow]Dry-run 已啟用，部分區段擷取失敗: %v[-]", captureErr))
+			return
+		}
+		app.setStatus(fmt.Sprintf("[yellow]Dry-run 已啟用，快照已儲存至 %s[-]", app.snapshotPath))
+	})
+}
+
+// showDryRunWrites 以可捲動頁面列出 dry-run 期間攔截的所有寫入。
+func (app *App) showDryRunWrites(title string, driver *gpu.DryRunDriver) {
+	writes := driver.Writes()
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	text.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Dry-run: %s ", title)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	fmt.Fprintf(text, "[yellow]共攔截 %d 筆寫入，未寫入任何硬體（Esc 關閉）[-]\n\n", len(writes))
+	fmt.Fprintln(text, tview.Escape(driver.FormatWrites()))
+
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+	app.showView(root, text)
+}
@@ -0,0 +1,343 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"GMTAUXOneKeyBuild/edidhelper"
+	display "GMTAUXOneKeyBuild/struct"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// 比較來源中顯示器以外的選項。
+const (
+	diffSourceSink = "面板直讀 (I2C)"
+	diffSourceFile = "檔案"
+)
+
+// edidDiffView 保存 EDID 比較頁面的元件與目前選擇的來源。
+type edidDiffView struct {
+	form        *tview.Form
+	fields      *tview.Table
+	hexA        *tview.TextView
+	hexB        *tview.TextView
+	options     []string
+	sourceA     int
+	sourceB     int
+	pathA       string
+	pathB       string
+	changedOnly bool
+	diff        *display.EDIDDiff
+}
+
+// showEDIDDiffView 開啟 EDID 比較頁面，可比較顯示器清單中的任一項目（登錄檔、面板直讀
+// 或檔案載入）、即時由面板讀取的 EDID 與檔案，並以左右並排的十六進位標示差異。
+func (app *App) showEDIDDiffView() {
+	view := &edidDiffView{}
+	for i, d := range app.displays {
+		view.options = append(view.options, diffDisplayOption(i, d))
+	}
+	view.options = append(view.options, diffSourceSink, diffSourceFile)
+
+	// 預設比較目前選取的顯示器與面板直讀，符合燒錄後驗證的流程。
+	view.sourceA = max(app.displayList.GetCurrentItem(), 0)
+	if len(app.displays) == 0 {
+		view.sourceA = len(view.options) - 1
+	}
+	view.sourceB = len(app.displays)
+
+	view.form = tview.NewForm().
+		AddDropDown("來源 A", view.options, view.sourceA, func(_ string, index int) { view.sourceA = index }).
+		AddInputField("檔案 A", "", 32, nil, func(text string) { view.pathA = strings.TrimSpace(text) }).
+		AddDropDown("來源 B", view.options, view.sourceB, func(_ string, index int) { view.sourceB = index }).
+		AddInputField("檔案 B", "", 32, nil, func(text string) { view.pathB = strings.TrimSpace(text) }).
+		AddCheckbox("只顯示差異", false, func(checked bool) {
+			view.changedOnly = checked
+			view.renderFields()
+		}).
+		AddButton("比較", func() { app.compareEDID(view) }).
+		AddButton("關閉", app.closeView)
+	view.form.SetBorder(true).
+		SetTitle(" Sources ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	view.form.SetCancelFunc(app.closeView)
+
+	view.fields = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	view.fields.SetBorder(true).
+		SetTitle(" Fields ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	newHexPane := func(title string) *tview.TextView {
+		pane := tview.NewTextView().
+			SetDynamicColors(true).
+			SetScrollable(true)
+		pane.SetBorder(true).
+			SetTitle(title).
+			SetTitleAlign(tview.AlignCenter).
+			SetBorderColor(tcell.ColorWhite).
+			SetTitleColor(tcell.ColorYellow)
+		return pane
+	}
+	view.hexA = newHexPane(" A ")
+	view.hexB = newHexPane(" B ")
+	fmt.Fprintln(view.hexA, "選擇兩個來源後按「比較」；來源為「檔案」時請填入對應的檔案路徑。")
+
+	// Tab 依序在欄位表與兩個十六進位窗格之間切換，Shift+Tab 回到來源設定。
+	focusOrder := []tview.Primitive{view.form, view.fields, view.hexA, view.hexB}
+	cycle := func(from tview.Primitive) func(*tcell.EventKey) *tcell.EventKey {
+		return func(event *tcell.EventKey) *tcell.EventKey {
+			for i, p := range focusOrder {
+				if p != from {
+					continue
+				}
+				switch event.Key() {
+				case tcell.KeyTAB:
+					app.app.SetFocus(focusOrder[(i+1)%len(focusOrder)])
+					return nil
+				case tcell.KeyBacktab:
+					app.app.SetFocus(focusOrder[(i+len(focusOrder)-1)%len(focusOrder)])
+					return nil
+				}
+			}
+			return event
+		}
+	}
+	view.fields.SetInputCapture(cycle(view.fields))
+	view.hexA.SetInputCapture(cycle(view.hexA))
+	view.hexB.SetInputCapture(cycle(view.hexB))
+
+	top := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(view.form, 50, 0, true).
+		AddItem(view.fields, 0, 1, false)
+	hex := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(view.hexA, 0, 1, false).
+		AddItem(view.hexB, 0, 1, false)
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(top, 15, 0, true).
+		AddItem(hex, 0, 1, false).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(root, view.form)
+}
+
+// diffDisplayOption 回傳顯示器在來源選單中的文字。
+func diffDisplayOption(index int, d *display.Display) string {
+	label := fmt.Sprintf("%d. %s", index+1, d.AdapterName)
+	if d.Source != "" {
+		label += fmt.Sprintf(" (%s)", d.Source)
+	}
+	return label
+}
+
+// compareEDID 於背景取得兩個來源的 EDID 並顯示比較結果；面板直讀需存取硬體。
+func (app *App) compareEDID(view *edidDiffView) {
+	sourceA, pathA := view.sourceA, view.pathA
+	sourceB, pathB := view.sourceB, view.pathB
+	displays := append([]*display.Display(nil), app.displays...)
+	app.setStatus("[yellow]取得 EDID 中...[-]")
+
+	go func() {
+		a, labelA, err := app.diffSourceEDID(displays, view.options, sourceA, pathA)
+		if err != nil {
+			app.queueSetStatus("[red]來源 A 讀取失敗[-]")
+			app.queueShowModal(fmt.Sprintf("來源 A：%v", err))
+			return
+		}
+		b, labelB, err := app.diffSourceEDID(displays, view.options, sourceB, pathB)
+		if err != nil {
+			app.queueSetStatus("[red]來源 B 讀取失敗[-]")
+			app.queueShowModal(fmt.Sprintf("來源 B：%v", err))
+			return
+		}
+
+		diff := display.DiffEDID(a, b)
+		app.app.QueueUpdateDraw(func() {
+			view.diff = diff
+			view.renderFields()
+			view.hexA.SetTitle(fmt.Sprintf(" A: %s ", tview.Escape(labelA)))
+			view.hexB.SetTitle(fmt.Sprintf(" B: %s ", tview.Escape(labelB)))
+			view.hexA.SetText(formatDiffHex(a, diff)).ScrollToBeginning()
+			view.hexB.SetText(formatDiffHex(b, diff)).ScrollToBeginning()
+			if diff.Equal() {
+				app.setStatus("[green]兩份 EDID 完全相同[-]")
+			} else {
+				app.setStatus(fmt.Sprintf("[yellow]%d 個欄位、%d 個位元組不同[-]", len(diff.ChangedFields()), len(diff.Bytes)))
+			}
+			app.app.SetFocus(view.fields)
+		})
+	}()
+}
+
+// diffSourceEDID 依來源選項取得 EDID 與顯示用的名稱；displays 為開始比較時的顯示器清單。
+func (app *App) diffSourceEDID(displays []*display.Display, options []string, index int, path string) ([]byte, string, error) {
+	if index < 0 || index >= len(options) {
+		return nil, "", fmt.Errorf("未選擇來源")
+	}
+	switch options[index] {
+	case diffSourceSink:
+		driver, err := app.auxDriver()
+		if err != nil || driver == nil {
+			return nil, "", fmt.Errorf("GPU 驅動不可用：%s", app.describeGPUError(err))
+		}
+		edid, err := edidhelper.ReadEDIDFromSink(driver)
+		if len(edid) == 0 {
+			return nil, "", err
+		}
+		return edid, "sink", nil
+	case diffSourceFile:
+		if path == "" {
+			return nil, "", fmt.Errorf("請輸入檔案路徑")
+		}
+		edid, err := edidhelper.LoadEDIDFile(path)
+		if err != nil {
+			return nil, "", err
+		}
+		return edid, filepath.Base(path), nil
+	default:
+		if index >= len(displays) || len(displays[index].EDID) == 0 {
+			return nil, "", fmt.Errorf("顯示器沒有 EDID 資料")
+		}
+		return displays[index].EDID, options[index], nil
+	}
+}
+
+// renderFields 將欄位比較結果填入表格，差異以紅色標示。
+func (view *edidDiffView) renderFields() {
+	view.fields.Clear()
+	for col, header := range []string{"欄位", "位移", "A", "B"} {
+		view.fields.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tview.Styles.SecondaryTextColor).
+			SetSelectable(false))
+	}
+	if view.diff == nil {
+		return
+	}
+
+	row := 1
+	for _, f := range view.diff.Fields {
+		if view.changedOnly && !f.Changed {
+			continue
+		}
+		color := tview.Styles.PrimaryTextColor
+		if f.Changed {
+			color = tcell.ColorRed
+		}
+		cells := []string{f.Label, fmt.Sprintf("0x%03X", f.Offset), diffCellText(f.A), diffCellText(f.B)}
+		for col, text := range cells {
+			view.fields.SetCell(row, col, tview.NewTableCell(tview.Escape(text)).
+				SetTextColor(color).
+				SetMaxWidth(48))
+		}
+		row++
+	}
+	view.fields.ScrollToBeginning()
+}
+
+// diffCellText 以 "-" 表示該來源沒有此欄位。
+func diffCellText(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// formatDiffHex 以每行 16 位元組輸出十六進位內容，與另一來源不同的位元組以紅色標示。
+func formatDiffHex(data []byte, diff *display.EDIDDiff) string {
+	if len(data) == 0 {
+		return "[gray]（無資料）[-]"
+	}
+	changed := make(map[int]bool, len(diff.Bytes))
+	for _, d := range diff.Bytes {
+		changed[d.Offset] = true
+	}
+
+	var b strings.Builder
+	length := max(diff.LengthA, diff.LengthB)
+	for offset := 0; offset < length; offset += 16 {
+		fmt.Fprintf(&b, "[gray]%03X[-] ", offset)
+		for i := offset; i < offset+16 && i < length; i++ {
+			switch {
+			case i >= len(data):
+				b.WriteString(" --")
+			case changed[i]:
+				fmt.Fprintf(&b, " [red]%02X[-]", data[i])
+			default:
+				fmt.Fprintf(&b, " %02X", data[i])
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// luaEDIDDiff 提供 Lua 的 edid_diff(a, b)，a、b 可為位元組陣列表或 EDID 檔案路徑；
+// 回傳含 equal、length_a、length_b、fields（僅列出不同的欄位）與 bytes 的表。
+func luaEDIDDiff() lua.LGFunction {
+	return func(L *lua.LState) int {
+		var sides [2][]byte
+		for i := range sides {
+			switch value := L.CheckAny(i + 1).(type) {
+			case *lua.LTable:
+				data, err := tableToByteSlice(value)
+				if err != nil {
+					L.ArgError(i+1, err.Error())
+					return 0
+				}
+				sides[i] = data
+			case lua.LString:
+				data, err := edidhelper.LoadEDIDFile(string(value))
+				if err != nil {
+					L.Push(lua.LNil)
+					L.Push(lua.LString(err.Error()))
+					return 2
+				}
+				sides[i] = data
+			default:
+				L.ArgError(i+1, "expected byte table or file path")
+				return 0
+			}
+		}
+
+		diff := display.DiffEDID(sides[0], sides[1])
+		result := L.NewTable()
+		result.RawSetString("equal", lua.LBool(diff.Equal()))
+		result.RawSetString("length_a", lua.LNumber(diff.LengthA))
+		result.RawSetString("length_b", lua.LNumber(diff.LengthB))
+		fields := L.NewTable()
+		for i, f := range diff.ChangedFields() {
+			field := L.NewTable()
+			field.RawSetString("key", lua.LString(f.Key))
+			field.RawSetString("label", lua.LString(f.Label))
+			field.RawSetString("offset", lua.LNumber(f.Offset))
+			field.RawSetString("length", lua.LNumber(f.Length))
+			field.RawSetString("a", lua.LString(f.A))
+			field.RawSetString("b", lua.LString(f.B))
+			fields.RawSetInt(i+1, field)
+		}
+		result.RawSetString("fields", fields)
+		bytes := L.NewTable()
+		for i, d := range diff.Bytes {
+			entry := L.NewTable()
+			entry.RawSetString("offset", lua.LNumber(d.Offset))
+			entry.RawSetString("a", lua.LNumber(d.A))
+			entry.RawSetString("b", lua.LNumber(d.B))
+			bytes.RawSetInt(i+1, entry)
+		}
+		result.RawSetString("bytes", bytes)
+		L.Push(result)
+		return 1
+	}
+}
@@ -0,0 +1,636 @@
+package ui
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/edidhelper"
+	"GMTAUXOneKeyBuild/luascripts"
+	display "GMTAUXOneKeyBuild/struct"
+	"GMTAUXOneKeyBuild/timing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// EDID 編輯器的區段。
+var edidEditorSections = []string{
+	"基本資訊",
+	"顯示器名稱",
+	"範圍限制",
+	"詳細時序 1",
+	"詳細時序 2",
+	"詳細時序 3",
+	"詳細時序 4",
+	"CTA 資料區塊",
+}
+
+// edidEditor 保存 EDID 編輯頁面的元件與編輯中的副本。
+type edidEditor struct {
+	edid     []byte // 編輯中的 EDID，每次成功套用後檢查碼皆已重新計算
+	source   *display.Display
+	sections *tview.List
+	holder   *tview.Flex
+	form     *tview.Form
+	message  *tview.TextView
+	output   *tview.Form
+	preview  *tview.TextView
+	path     string
+}
+
+// showEDIDEditor 以目前選取顯示器的 EDID 開啟編輯頁面。每個欄位修改時立即驗證，
+// 通過後套用至編輯中的副本並重新計算檢查碼，結果可存檔、燒錄至面板或加入顯示器清單。
+func (app *App) showEDIDEditor() {
+	current := app.currentDisplay()
+	if current == nil || len(current.EDID) < 128 {
+		app.showModal("請先選取具有 EDID 的顯示器，或由「EDID 檔案載入/另存」載入檔案")
+		return
+	}
+
+	ed := &edidEditor{
+		edid:   append([]byte(nil), current.EDID...),
+		source: current,
+		path:   fmt.Sprintf("%s%04X_edited.bin", current.ManufacturerID, current.ProductCode),
+	}
+
+	ed.sections = tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true)
+	ed.sections.SetBorder(true).
+		SetTitle(" Sections ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	for _, name := range edidEditorSections {
+		ed.sections.AddItem(name, "", 0, nil)
+	}
+	ed.sections.SetChangedFunc(func(index int, _, _ string, _ rune) {
+		app.showEDIDEditorSection(ed, index)
+	})
+	ed.sections.SetSelectedFunc(func(int, string, string, rune) {
+		app.app.SetFocus(ed.form)
+	})
+
+	ed.holder = tview.NewFlex().SetDirection(tview.FlexRow)
+	ed.holder.SetBorder(true).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	ed.message = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+
+	ed.output = tview.NewForm().
+		AddInputField("檔案", ed.path, 32, nil, func(text string) { ed.path = strings.TrimSpace(text) }).
+		AddButton("存檔", func() { app.saveEditedEDID(ed) }).
+		AddButton("燒錄至面板", func() { app.confirmProgramEDID(ed) }).
+		AddButton("加入清單", func() {
+			app.addFileDisplay(append([]byte(nil), ed.edid...), "編輯後 "+ed.source.AdapterName, "")
+		}).
+		AddButton("關閉", app.closeView)
+	ed.output.SetBorder(true).
+		SetTitle(" Output ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	ed.preview = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	ed.preview.SetBorder(true).
+		SetTitle(" Preview ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	// Tab 依序在區段清單、輸出設定與預覽之間切換；區段清單按 Enter 進入欄位表單。
+	ed.sections.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTAB:
+			app.app.SetFocus(ed.output)
+			return nil
+		case tcell.KeyBacktab:
+			app.app.SetFocus(ed.preview)
+			return nil
+		}
+		return event
+	})
+	ed.preview.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTAB:
+			app.app.SetFocus(ed.sections)
+			return nil
+		case tcell.KeyBacktab:
+			app.app.SetFocus(ed.output)
+			return nil
+		}
+		return event
+	})
+
+	middle := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(ed.holder, 0, 1, false).
+		AddItem(ed.message, 2, 0, false).
+		AddItem(ed.output, 7, 0, false)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(ed.sections, 20, 0, true).
+		AddItem(middle, 0, 2, false).
+		AddItem(ed.preview, 0, 2, false)
+
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showEDIDEditorSection(ed, 0)
+	ed.renderPreview()
+	app.showView(root, ed.sections)
+}
+
+// apply 在副本上套用修改；驗證失敗時保留原內容並顯示錯誤。
+func (ed *edidEditor) apply(edit func(edid []byte) error) {
+	work := append([]byte(nil), ed.edid...)
+	if err := edit(work); err != nil {
+		ed.message.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+	ed.edid = work
+	ed.message.SetText("[green]已套用，檢查碼已重新計算[-]")
+	ed.renderPreview()
+}
+
+// showEDIDEditorSection 依區段建立欄位表單。
+func (app *App) showEDIDEditorSection(ed *edidEditor, index int) {
+	if index < 0 || index >= len(edidEditorSections) {
+		return
+	}
+	ed.message.Clear()
+
+	form := tview.NewForm()
+	switch name := edidEditorSections[index]; {
+	case name == "基本資訊":
+		ed.basicForm(form)
+	case name == "顯示器名稱":
+		ed.monitorNameForm(form)
+	case name == "範圍限制":
+		ed.rangeLimitsForm(form)
+	case strings.HasPrefix(name, "詳細時序"):
+		ed.timingForm(form, index-3, func() { app.showEDIDEditorSection(ed, index) })
+	case name == "CTA 資料區塊":
+		ed.ctaForm(form, func() { app.showEDIDEditorSection(ed, index) })
+	}
+	form.AddButton("返回", func() { app.app.SetFocus(ed.sections) })
+
+	ed.form = form
+	ed.holder.Clear().AddItem(form, 0, 1, false)
+	ed.holder.SetTitle(fmt.Sprintf(" %s ", edidEditorSections[index]))
+}
+
+// basicForm 編輯製造商 ID、產品碼、序號與製造日期。
+func (ed *edidEditor) basicForm(form *tview.Form) {
+	info, _ := display.ParseEDID(ed.edid, "", "", "")
+	week, year := strconv.Itoa(info.Week), strconv.Itoa(info.Year)
+
+	form.AddInputField("製造商ID", info.ManufacturerID, 5, nil, func(text string) {
+		ed.apply(func(edid []byte) error { return display.SetManufacturerID(edid, strings.TrimSpace(text)) })
+	})
+	form.AddInputField("產品碼", info.ProductID, 8, nil, func(text string) {
+		ed.apply(func(edid []byte) error {
+			code, err := strconv.ParseUint(strings.TrimSpace(text), 0, 16)
+			if err != nil {
+				return fmt.Errorf("product code %q: %w", text, err)
+			}
+			return display.SetProductCode(edid, uint16(code))
+		})
+	})
+	form.AddInputField("序號", info.Serial, 12, nil, func(text string) {
+		ed.apply(func(edid []byte) error {
+			serial, err := strconv.ParseUint(strings.TrimSpace(text), 0, 32)
+			if err != nil {
+				return fmt.Errorf("serial %q: %w", text, err)
+			}
+			return display.SetSerialNumber(edid, uint32(serial))
+		})
+	})
+	setDate := func() {
+		ed.apply(func(edid []byte) error {
+			w, err := strconv.Atoi(strings.TrimSpace(week))
+			if err != nil {
+				return fmt.Errorf("week %q is not a number", week)
+			}
+			y, err := strconv.Atoi(strings.TrimSpace(year))
+			if err != nil {
+				return fmt.Errorf("year %q is not a number", year)
+			}
+			return display.SetManufactureDate(edid, w, y)
+		})
+	}
+	form.AddInputField("製造週次", week, 5, nil, func(text string) {
+		week = text
+		setDate()
+	})
+	form.AddInputField("製造年份", year, 6, nil, func(text string) {
+		year = text
+		setDate()
+	})
+}
+
+// monitorNameForm 編輯顯示器名稱描述符。
+func (ed *edidEditor) monitorNameForm(form *tview.Form) {
+	form.AddInputField("名稱", display.MonitorName(ed.edid), 15, nil, func(text string) {
+		ed.apply(func(edid []byte) error { return display.SetMonitorName(edid, text) })
+	})
+}
+
+// rangeLimitsForm 編輯範圍限制描述符；不存在時以常見的 60 Hz 面板數值作為起始值。
+func (ed *edidEditor) rangeLimitsForm(form *tview.Form) {
+	limits, ok := display.ParseRangeLimits(ed.edid)
+	if !ok {
+		limits = display.RangeLimits{MinVRate: 48, MaxVRate: 60, MinHRate: 30, MaxHRate: 90, MaxPixelClock: 200}
+		ed.message.SetText("[yellow]目前沒有範圍限制描述符，修改後會使用空白描述符[-]")
+	}
+	fields := []struct {
+		label string
+		value *int
+	}{
+		{"最低垂直頻率 (Hz)", &limits.MinVRate},
+		{"最高垂直頻率 (Hz)", &limits.MaxVRate},
+		{"最低水平頻率 (kHz)", &limits.MinHRate},
+		{"最高水平頻率 (kHz)", &limits.MaxHRate},
+		{"最高像素時脈 (MHz)", &limits.MaxPixelClock},
+	}
+	for _, f := range fields {
+		value := f.value
+		form.AddInputField(f.label, strconv.Itoa(*value), 6, nil, func(text string) {
+			ed.apply(func(edid []byte) error {
+				n, err := strconv.Atoi(strings.TrimSpace(text))
+				if err != nil {
+					return fmt.Errorf("%q is not a number", text)
+				}
+				*value = n
+				return display.SetRangeLimits(edid, limits)
+			})
+		})
+	}
+}
+
+// timingForm 編輯區塊 0 第 slot 個描述符的詳細時序；可依 CVT/GTF/DMT 計算時序後套用，
+// 套用後以 refresh 重新建立表單。
+func (ed *edidEditor) timingForm(form *tview.Form, slot int, refresh func()) {
+	dtd, ok := display.DescriptorTiming(ed.edid, slot)
+	if !ok {
+		ed.message.SetText("[yellow]此描述符目前不是詳細時序，輸入有效時序後會取代原內容[-]")
+	}
+
+	methods := make([]string, len(timing.Methods))
+	for i, m := range timing.Methods {
+		methods[i] = string(m)
+	}
+	method := string(timing.MethodCVTRB)
+	resolution, rate := "", "60"
+	if ok {
+		current := timing.FromDetailedTiming(dtd)
+		resolution = fmt.Sprintf("%dx%d", current.HActive, current.VActive)
+		rate = strconv.FormatFloat(current.RefreshRate(), 'f', 3, 64)
+	}
+	form.AddDropDown("產生方式", methods, 1, func(option string, _ int) { method = option })
+	form.AddInputField("解析度", resolution, 11, nil, func(text string) { resolution = text })
+	form.AddInputField("更新率 (Hz)", rate, 8, nil, func(text string) { rate = text })
+	form.AddButton("計算並套用", func() {
+		generated, err := generateTiming(method, resolution, rate)
+		if err != nil {
+			ed.message.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		// 保留原描述符的影像尺寸。
+		next, err := generated.ToDetailedTiming(dtd.HImageSize, dtd.VImageSize)
+		if err != nil {
+			ed.message.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		ed.apply(func(edid []byte) error { return display.SetDescriptorTiming(edid, slot, next) })
+		refresh()
+		ed.message.SetText(fmt.Sprintf("[green]已套用 %s[-]", tview.Escape(generated.String())))
+	})
+
+	fields := []struct {
+		label string
+		value *int
+	}{
+		{"像素時脈 (kHz)", &dtd.PixelClock},
+		{"水平有效", &dtd.HActive},
+		{"水平遮沒", &dtd.HBlank},
+		{"水平前廊", &dtd.HFrontPorch},
+		{"水平同步寬度", &dtd.HSyncWidth},
+		{"垂直有效", &dtd.VActive},
+		{"垂直遮沒", &dtd.VBlank},
+		{"垂直前廊", &dtd.VFrontPorch},
+		{"垂直同步寬度", &dtd.VSyncWidth},
+		{"水平尺寸 (mm)", &dtd.HImageSize},
+		{"垂直尺寸 (mm)", &dtd.VImageSize},
+		{"水平邊框", &dtd.HBorder},
+		{"垂直邊框", &dtd.VBorder},
+	}
+	for _, f := range fields {
+		value := f.value
+		form.AddInputField(f.label, strconv.Itoa(*value), 8, nil, func(text string) {
+			ed.apply(func(edid []byte) error {
+				n, err := strconv.Atoi(strings.TrimSpace(text))
+				if err != nil {
+					return fmt.Errorf("%q is not a number", text)
+				}
+				*value = n
+				return display.SetDescriptorTiming(edid, slot, dtd)
+			})
+		})
+	}
+	form.AddInputField("旗標", fmt.Sprintf("0x%02X", dtd.Flags), 6, nil, func(text string) {
+		ed.apply(func(edid []byte) error {
+			flags, err := strconv.ParseUint(strings.TrimSpace(text), 0, 8)
+			if err != nil {
+				return fmt.Errorf("flags %q: %w", text, err)
+			}
+			dtd.Flags = byte(flags)
+			return display.SetDescriptorTiming(edid, slot, dtd)
+		})
+	})
+}
+
+// generateTiming 解析「寬x高」與更新率文字後依指定方式產生時序。
+func generateTiming(method, resolution, rate string) (timing.Timing, error) {
+	m, err := timing.ParseMethod(method)
+	if err != nil {
+		return timing.Timing{}, err
+	}
+	var h, v int
+	if _, err := fmt.Sscanf(strings.ToLower(strings.TrimSpace(resolution)), "%dx%d", &h, &v); err != nil {
+		return timing.Timing{}, fmt.Errorf("resolution %q must look like 1920x1080", resolution)
+	}
+	refresh, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+	if err != nil {
+		return timing.Timing{}, fmt.Errorf("refresh rate %q is not a number", rate)
+	}
+	return timing.Generate(m, h, v, refresh)
+}
+
+// ctaForm 以每行「標籤: 十六進位資料」編輯第一個 CTA-861 擴充區塊的資料區塊；
+// 沒有 CTA 擴充區塊時提供新增按鈕，新增後以 refresh 重新建立表單。
+func (ed *edidEditor) ctaForm(form *tview.Form, refresh func()) {
+	blocks := display.CTABlocks(ed.edid)
+	if len(blocks) == 0 {
+		ed.message.SetText("[yellow]此 EDID 沒有 CTA-861 擴充區塊[-]")
+		form.AddButton("新增 CTA 擴充區塊", func() {
+			out, err := display.AppendCTAExtension(ed.edid)
+			if err != nil {
+				ed.message.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				return
+			}
+			ed.edid = out
+			ed.renderPreview()
+			refresh()
+		})
+		return
+	}
+
+	block := blocks[0]
+	dataBlocks, err := display.CTADataBlocks(ed.edid, block)
+	if err != nil {
+		ed.message.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+	}
+	var lines []string
+	for _, b := range dataBlocks {
+		lines = append(lines, fmt.Sprintf("%d: % X", b.Tag, b.Data))
+	}
+	form.AddTextArea(fmt.Sprintf("區塊 %d", block), strings.Join(lines, "\n"), 0, 12, 0, func(text string) {
+		ed.apply(func(edid []byte) error {
+			parsed, err := parseCTADataBlocks(text)
+			if err != nil {
+				return err
+			}
+			return display.SetCTADataBlocks(edid, block, parsed)
+		})
+	})
+}
+
+// parseCTADataBlocks 解析「標籤: 十六進位資料」格式的資料區塊，每行一個，空行略過。
+func parseCTADataBlocks(text string) ([]display.CTADataBlock, error) {
+	var blocks []display.CTADataBlock
+	for i, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		tagText, dataText, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"tag: data\"", i+1)
+		}
+		tag, err := strconv.ParseUint(strings.TrimSpace(tagText), 0, 3)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid tag %q", i+1, strings.TrimSpace(tagText))
+		}
+		data, err := hex.DecodeString(strings.Join(strings.Fields(dataText), ""))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		blocks = append(blocks, display.CTADataBlock{Tag: byte(tag), Data: data})
+	}
+	return blocks, nil
+}
+
+// renderPreview 以解析結果、範圍限制、CTA 資料區塊與十六進位內容呈現編輯中的 EDID。
+func (ed *edidEditor) renderPreview() {
+	var b strings.Builder
+	info, err := display.ParseEDID(ed.edid, ed.source.AdapterName, ed.source.AdapterString, ed.source.DeviceID)
+	if err != nil {
+		fmt.Fprintf(&b, "[red]%s[-]\n", tview.Escape(err.Error()))
+	} else {
+		for _, row := range displayToRows(info) {
+			color := "white"
+			if row[0] == "EDID 警告" {
+				color = "red"
+			}
+			fmt.Fprintf(&b, "[gray]%s[-] [%s]%s[-]\n", row[0], color, tview.Escape(row[1]))
+		}
+	}
+	if limits, ok := display.ParseRangeLimits(ed.edid); ok {
+		fmt.Fprintf(&b, "[gray]範圍限制[-] %d-%d Hz, %d-%d kHz, %d MHz\n",
+			limits.MinVRate, limits.MaxVRate, limits.MinHRate, limits.MaxHRate, limits.MaxPixelClock)
+	}
+	for _, block := range display.CTABlocks(ed.edid) {
+		dataBlocks, _ := display.CTADataBlocks(ed.edid, block)
+		for _, d := range dataBlocks {
+			fmt.Fprintf(&b, "[gray]CTA %d[-] %s (%d bytes)\n", block, d.Name(), len(d.Data))
+		}
+	}
+	fmt.Fprintf(&b, "\n%s", edidhelper.FormatEDIDHex(ed.edid))
+	ed.preview.SetText(b.String())
+}
+
+// saveEditedEDID 依副檔名儲存編輯後的 EDID。
+func (app *App) saveEditedEDID(ed *edidEditor) {
+	if err := edidhelper.SaveEDIDFile(ed.path, ed.edid); err != nil {
+		app.showModal(fmt.Sprintf("EDID 儲存失敗：%v", err))
+		return
+	}
+	app.setStatus(fmt.Sprintf("[green]已將編輯後的 EDID 存至 %s[-]", tview.Escape(ed.path)))
+}
+
+// confirmProgramEDID 確認後將編輯後的 EDID 燒錄至面板 EEPROM。
+func (app *App) confirmProgramEDID(ed *edidEditor) {
+	edid := append([]byte(nil), ed.edid...)
+	info, err := display.ParseEDID(edid, "", "", "")
+	if err != nil {
+		app.showModal(fmt.Sprintf("EDID 無法解析，無法燒錄：%v", err))
+		return
+	}
+	if len(info.Warnings) > 0 {
+		app.showModal(fmt.Sprintf("EDID 仍有問題，無法燒錄：\n%s", strings.Join(info.Warnings, "\n")))
+		return
+	}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("確定將 %d 位元組的 EDID 燒錄至面板 EEPROM (0x50)？\n燒錄期間請勿拔除面板。", len(edid))).
+		AddButtons([]string{"燒錄", "取消"}).
+		SetDoneFunc(func(index int, _ string) {
+			app.restoreRoot()
+			if index == 0 {
+				app.programEDID(edid)
+			}
+		})
+	app.app.SetRoot(modal, true).SetFocus(modal)
+}
+
+// programEDID 於背景寫入 EDID 並讀回比對。
+func (app *App) programEDID(edid []byte) {
+	app.setStatus("[yellow]燒錄 EDID 中...[-]")
+	go func() {
+		driver, err := app.auxDriver()
+		if err != nil || driver == nil {
+			app.queueSetStatus("[red]GPU 驅動不可用[-]")
+			app.queueShowModal(fmt.Sprintf("無法燒錄 EDID：%s", app.describeGPUError(err)))
+			return
+		}
+		if err := edidhelper.WriteEDIDToSink(driver, edid); err != nil {
+			app.queueSetStatus("[red]EDID 燒錄失敗[-]")
+			app.queueShowModal(fmt.Sprintf("EDID 燒錄失敗：%v", err))
+			return
+		}
+		app.queueSetStatus(fmt.Sprintf("[green]EDID 已燒錄並驗證（%s）[-]", tview.Escape(driver.Name())))
+	}()
+}
+
+// luaTimingModule 提供 Lua 的 timing 模組：generate、dmt、to_dtd 與 from_dtd。
+func luaTimingModule() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		"generate": func(L *lua.LState) int {
+			method, err := timing.ParseMethod(L.CheckString(1))
+			if err != nil {
+				L.ArgError(1, err.Error())
+				return 0
+			}
+			h, v, refresh := L.CheckInt(2), L.CheckInt(3), float64(L.CheckNumber(4))
+			var t timing.Timing
+			if method == timing.MethodRBv3 {
+				t = timing.CVTRB3(h, v, refresh, L.OptInt(5, 0))
+			} else if t, err = timing.Generate(method, h, v, refresh); err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			L.Push(timingToTable(L, t))
+			return 1
+		},
+		"dmt": func(L *lua.LState) int {
+			t, ok := timing.DMT(L.CheckInt(1), L.CheckInt(2), float64(L.OptNumber(3, 60)))
+			if !ok {
+				L.Push(lua.LNil)
+				return 1
+			}
+			L.Push(timingToTable(L, t))
+			return 1
+		},
+		"to_dtd": func(L *lua.LState) int {
+			t := tableToTiming(L.CheckTable(1))
+			desc, err := t.EncodeDTD(L.OptInt(2, 0), L.OptInt(3, 0))
+			if err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			L.Push(luascripts.ToLua(L, desc))
+			return 1
+		},
+		"from_dtd": func(L *lua.LState) int {
+			desc, err := tableToByteSlice(L.CheckTable(1))
+			if err != nil {
+				L.ArgError(1, err.Error())
+				return 0
+			}
+			t, ok := timing.DecodeDTD(desc)
+			if !ok {
+				L.Push(lua.LNil)
+				L.Push(lua.LString("not a detailed timing descriptor"))
+				return 2
+			}
+			L.Push(timingToTable(L, t))
+			return 1
+		},
+	}
+}
+
+// timingToTable 將時序轉成 Lua table，並附上總數與更新率等衍生值。
+func timingToTable(L *lua.LState, t timing.Timing) *lua.LTable {
+	tbl := L.NewTable()
+	if t.Method != "" {
+		tbl.RawSetString("method", lua.LString(t.Method))
+	}
+	for key, value := range map[string]int{
+		"pixel_clock":   t.PixelClock,
+		"h_active":      t.HActive,
+		"h_front_porch": t.HFrontPorch,
+		"h_sync":        t.HSync,
+		"h_back_porch":  t.HBackPorch,
+		"v_active":      t.VActive,
+		"v_front_porch": t.VFrontPorch,
+		"v_sync":        t.VSync,
+		"v_back_porch":  t.VBackPorch,
+		"h_total":       t.HTotal(),
+		"v_total":       t.VTotal(),
+	} {
+		tbl.RawSetString(key, lua.LNumber(value))
+	}
+	tbl.RawSetString("h_sync_positive", lua.LBool(t.HSyncPositive))
+	tbl.RawSetString("v_sync_positive", lua.LBool(t.VSyncPositive))
+	tbl.RawSetString("interlaced", lua.LBool(t.Interlaced))
+	tbl.RawSetString("refresh", lua.LNumber(t.RefreshRate()))
+	return tbl
+}
+
+// tableToTiming 由 Lua table 讀回時序，缺少的欄位視為 0 或 false。
+func tableToTiming(tbl *lua.LTable) timing.Timing {
+	number := func(key string) int {
+		n, _ := tbl.RawGetString(key).(lua.LNumber)
+		return int(n)
+	}
+	flag := func(key string) bool {
+		return lua.LVAsBool(tbl.RawGetString(key))
+	}
+	return timing.Timing{
+		PixelClock:    number("pixel_clock"),
+		HActive:       number("h_active"),
+		HFrontPorch:   number("h_front_porch"),
+		HSync:         number("h_sync"),
+		HBackPorch:    number("h_back_porch"),
+		VActive:       number("v_active"),
+		VFrontPorch:   number("v_front_porch"),
+		VSync:         number("v_sync"),
+		VBackPorch:    number("v_back_porch"),
+		HSyncPositive: flag("h_sync_positive"),
+		VSyncPositive: flag("v_sync_positive"),
+		Interlaced:    flag("interlaced"),
+	}
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"GMTAUXOneKeyBuild/edidhelper"
+	display "GMTAUXOneKeyBuild/struct"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showEDIDFileView 開啟 EDID 檔案頁面：由 .bin/.hex/.dat/.inf 檔案或貼上的十六進位文字
+// 載入 EDID 成為虛擬顯示器，或將目前選取顯示器的 EDID 另存至檔案，方便離線檢查供應商檔案。
+func (app *App) showEDIDFileView() {
+	current := app.currentDisplay()
+	path := "edid.bin"
+	if current != nil {
+		path = fmt.Sprintf("%s%04X.bin", current.ManufacturerID, current.ProductCode)
+	}
+
+	info := tview.NewTextView().SetDynamicColors(true)
+	if current != nil {
+		fmt.Fprintf(info, " 目前顯示器：[yellow]%s[-]（%d 位元組）", tview.Escape(current.AdapterName), len(current.EDID))
+	} else {
+		info.SetText(" 目前沒有選取的顯示器，僅能載入 EDID")
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("檔案", path, 48, nil, func(text string) { path = strings.TrimSpace(text) }).
+		AddTextArea("貼上十六進位", "", 48, 8, 0, nil).
+		AddButton("載入", func() {
+			pasted := strings.TrimSpace(form.GetFormItemByLabel("貼上十六進位").(*tview.TextArea).GetText())
+			if pasted != "" {
+				app.loadPastedEDID(pasted)
+				return
+			}
+			app.loadEDIDFile(path)
+		}).
+		AddButton("另存", func() {
+			if current == nil || len(current.EDID) == 0 {
+				app.showModal("目前顯示器沒有可儲存的 EDID")
+				return
+			}
+			if err := edidhelper.SaveEDIDFile(path, current.EDID); err != nil {
+				app.showModal(fmt.Sprintf("EDID 儲存失敗：%v", err))
+				return
+			}
+			app.closeView()
+			app.setStatus(fmt.Sprintf("[green]已將 %s 的 EDID 存至 %s[-]", current.AdapterName, path))
+		}).
+		AddButton("取消", app.closeView)
+	form.SetCancelFunc(app.closeView)
+
+	panel := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(info, 1, 0, false).
+		AddItem(form, 0, 1, true)
+	panel.SetBorder(true).
+		SetTitle(" EDID File ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(panel, 18, 0, true).
+		AddItem(tview.NewBox(), 0, 1, false).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(root, form)
+}
+
+// loadEDIDFile 讀取 EDID 檔案並加入顯示器清單。
+func (app *App) loadEDIDFile(path string) {
+	if path == "" {
+		app.showModal("請輸入檔案路徑或貼上十六進位 EDID")
+		return
+	}
+	edid, err := edidhelper.LoadEDIDFile(path)
+	if err != nil {
+		app.showModal(fmt.Sprintf("EDID 載入失敗：%v", err))
+		return
+	}
+	app.addFileDisplay(edid, filepath.Base(path), path)
+}
+
+// loadPastedEDID 解析貼上的十六進位文字（或 INF 的 EDID_OVERRIDE 行）並加入顯示器清單。
+func (app *App) loadPastedEDID(text string) {
+	var (
+		edid []byte
+		err  error
+	)
+	if strings.Contains(strings.ToUpper(text), "EDID_OVERRIDE") {
+		edid, err = edidhelper.ParseINF(text)
+	} else {
+		edid, err = edidhelper.ParseEDIDHex(text)
+	}
+	if err != nil {
+		app.showModal(fmt.Sprintf("EDID 解析失敗：%v", err))
+		return
+	}
+	app.addFileDisplay(edid, "貼上的 EDID", "")
+}
+
+// addFileDisplay 以 display.ParseEDID 解析 EDID，並以虛擬顯示器加入清單後選取。
+func (app *App) addFileDisplay(edid []byte, name, path string) {
+	info, err := display.ParseEDID(edid, name, "EDID 檔案", path)
+	if err != nil {
+		app.showModal(fmt.Sprintf("EDID 解析失敗：%v", err))
+		return
+	}
+	info.Source = display.SourceFile
+
+	app.displays = append(app.displays, info)
+	app.populateDisplayList()
+	app.displayList.SetCurrentItem(len(app.displays) - 1)
+	app.updateTable(info)
+	app.closeView()
+
+	message := fmt.Sprintf("已載入 %s（%d 個區塊）", tview.Escape(name), len(edid)/128)
+	if len(info.Warnings) > 0 {
+		message += "\n\n[yellow]" + strings.Join(info.Warnings, "\n") + "[-]"
+	}
+	app.setStatus(fmt.Sprintf("[green]已載入 EDID：%s[-]", tview.Escape(name)))
+	app.showModal(message)
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"GMTAUXOneKeyBuild/edidhelper"
+	"GMTAUXOneKeyBuild/gpu"
+	display "GMTAUXOneKeyBuild/struct"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// readSinkEDID 透過 I2C 直接讀取面板 EDID，取代目前顯示器的登錄檔資料；
+// 沒有任何顯示器時則新增一筆，方便在未列舉顯示器的環境檢查面板。
+func (app *App) readSinkEDID() {
+	index := app.displayList.GetCurrentItem()
+	var current *display.Display
+	if index >= 0 && index < len(app.displays) {
+		current = app.displays[index]
+	}
+	app.setStatus("[yellow]由面板讀取 EDID...[-]")
+
+	go func() {
+		driver, err := app.auxDriver()
+		if err != nil || driver == nil {
+			app.queueSetStatus("[red]GPU 驅動不可用[-]")
+			app.queueShowModal(fmt.Sprintf("無法讀取面板 EDID：%s", app.describeGPUError(err)))
+			return
+		}
+
+		edid, readErr := edidhelper.ReadEDIDFromSink(driver)
+		if len(edid) == 0 {
+			app.queueSetStatus("[red]面板 EDID 讀取失敗[-]")
+			app.queueShowModal(fmt.Sprintf("面板 EDID 讀取失敗：%v", readErr))
+			return
+		}
+
+		adapterName, adapterString, deviceID := driver.Name(), "", ""
+		if current != nil {
+			adapterName, adapterString, deviceID = current.AdapterName, current.AdapterString, current.DeviceID
+		}
+		info, err := display.ParseEDID(edid, adapterName, adapterString, deviceID)
+		if err != nil {
+			app.queueSetStatus("[red]面板 EDID 解析失敗[-]")
+			app.queueShowModal(fmt.Sprintf("面板 EDID 解析失敗：%v", err))
+			return
+		}
+		info.Source = display.SourceSink
+
+		message := fmt.Sprintf("已由面板讀取 %d 個 EDID 區塊（%s）", len(edid)/128, driver.Name())
+		switch {
+		case errors.Is(readErr, edidhelper.ErrSegmentNotRetained):
+			message += "\n\n[yellow]驅動未保留 E-DDC 區段指標，僅取得前 256 位元組。[-]"
+		case readErr != nil:
+			message += fmt.Sprintf("\n\n[yellow]警告：%v[-]", readErr)
+		}
+
+		app.app.QueueUpdateDraw(func() {
+			if current != nil && index < len(app.displays) && app.displays[index] == current {
+				app.displays[index] = info
+			} else {
+				app.displays = append(app.displays, info)
+				index = len(app.displays) - 1
+			}
+			app.populateDisplayList()
+			app.displayList.SetCurrentItem(index)
+			app.updateTable(info)
+			app.setStatus("[green]面板 EDID 讀取完成[-]")
+			app.showModal(message)
+		})
+	}()
+}
+
+// luaReadEDID 提供 Lua 的 read_edid()，回傳自面板讀取的 EDID 位元組陣列；
+// 檢查碼錯誤時仍回傳資料並附上錯誤訊息。
+func luaReadEDID(driver gpu.Driver, describeError func() string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		if driver == nil {
+			L.Push(lua.LNil)
+			L.Push(lua.LString(describeError()))
+			return 2
+		}
+		edid, err := edidhelper.ReadEDIDFromSink(driver)
+		if len(edid) == 0 {
+			L.Push(lua.LNil)
+			L.Push(lua.LString(err.Error()))
+			return 2
+		}
+		tbl := L.NewTable()
+		for i, b := range edid {
+			tbl.RawSetInt(i+1, lua.LNumber(b))
+		}
+		L.Push(tbl)
+		if err != nil {
+			L.Push(lua.LString(err.Error()))
+			return 2
+		}
+		return 1
+	}
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"GMTAUXOneKeyBuild/luascripts"
+	display "GMTAUXOneKeyBuild/struct"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// showExportView 詢問檔名後將顯示器清單（含原始 EDID 與解析警告）匯出為 JSON。
+func (app *App) showExportView() {
+	if len(app.displays) == 0 {
+		app.showModal("沒有可匯出的顯示器")
+		return
+	}
+
+	path := fmt.Sprintf("displays_%s.json", time.Now().Format("20060102_150405"))
+	form := tview.NewForm()
+	form.AddInputField("檔案", path, 48, nil, func(text string) { path = strings.TrimSpace(text) }).
+		AddButton("匯出", func() {
+			data, err := display.MarshalDisplays(app.displays, nil)
+			if err == nil {
+				err = os.WriteFile(path, data, 0o644)
+			}
+			if err != nil {
+				app.showModal(fmt.Sprintf("匯出失敗：%v", err))
+				return
+			}
+			app.closeView()
+			app.setStatus(fmt.Sprintf("[green]已匯出 %d 個顯示器至 %s[-]", len(app.displays), path))
+		}).
+		AddButton("取消", app.closeView)
+	form.SetBorder(true).
+		SetTitle(" Export JSON ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	form.SetCancelFunc(app.closeView)
+
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 7, 0, true).
+		AddItem(tview.NewBox(), 0, 1, false).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(root, form)
+}
+
+// luaJSONModule 提供 Lua 的 json.encode(value, [indent]) 與 json.decode(text)。
+func luaJSONModule() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		"encode": func(L *lua.LState) int {
+			value := luascripts.ToGo(L.CheckAny(1))
+			var (
+				data []byte
+				err  error
+			)
+			if L.OptBool(2, false) {
+				data, err = json.MarshalIndent(value, "", "  ")
+			} else {
+				data, err = json.Marshal(value)
+			}
+			if err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			L.Push(lua.LString(data))
+			return 1
+		},
+		"decode": func(L *lua.LState) int {
+			var value interface{}
+			if err := json.Unmarshal([]byte(L.CheckString(1)), &value); err != nil {
+				L.Push(lua.LNil)
+				L.Push(lua.LString(err.Error()))
+				return 2
+			}
+			L.Push(luascripts.ToLua(L, value))
+			return 1
+		},
+	}
+}
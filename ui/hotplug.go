@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"GMTAUXOneKeyBuild/edidhelper"
+	"GMTAUXOneKeyBuild/hotplug"
+	display "GMTAUXOneKeyBuild/struct"
+)
+
+// SetHotplug 設定是否監看顯示器插拔；script 為偵測到新面板時自動執行的 Lua 腳本名稱，空字串表示不執行。
+func (app *App) SetHotplug(enabled bool, script string) {
+	app.hotplugEnabled = enabled
+	app.hotplugScript = strings.TrimSuffix(strings.TrimSpace(script), ".lua")
+}
+
+// SetHotplugSource 以指定的事件來源取代作業系統通知，例如 hotplug.NewFakeSource()。
+func (app *App) SetHotplugSource(source hotplug.Source) {
+	app.hotplugSource = source
+	app.hotplugEnabled = true
+}
+
+// startHotplug 開始監看顯示器插拔，需在第一次 refreshDisplays 之後呼叫以取得比較基準。
+func (app *App) startHotplug() error {
+	if !app.hotplugEnabled {
+		return nil
+	}
+	source := app.hotplugSource
+	if source == nil {
+		var err error
+		if source, err = hotplug.NewSource(); err != nil {
+			return err
+		}
+	}
+	app.hotplugWatcher = hotplug.NewWatcher(source, edidhelper.GetScreens, app.displays, func(change hotplug.Change) {
+		app.app.QueueUpdateDraw(func() { app.onHotplug(change) })
+	})
+	go app.hotplugWatcher.Run()
+	return nil
+}
+
+// stopHotplug 停止監看。
+func (app *App) stopHotplug() {
+	if app.hotplugWatcher != nil {
+		app.hotplugWatcher.Close()
+		app.hotplugWatcher = nil
+	}
+}
+
+// onHotplug 在顯示器組合改變時重新整理清單，選取新接上的面板並視設定執行腳本。
+func (app *App) onHotplug(change hotplug.Change) {
+	// 連接埠或面板已改變，舊的驅動與「找不到驅動」的記錄都不再適用。
+	app.resetLocalGPUDrivers()
+	err := app.refreshDisplays()
+	if len(change.Added) > 0 {
+		app.selectDisplay(change.Added[0])
+	}
+
+	status := fmt.Sprintf("[green]偵測到顯示器變更：新增 %d、移除 %d，目前 %d 個[-]",
+		len(change.Added), len(change.Removed), len(app.displays))
+	if err != nil {
+		status = fmt.Sprintf("[yellow]偵測到顯示器變更，但部分顯示器載入失敗: %v[-]", err)
+	}
+	app.setStatus(status)
+
+	if app.hotplugScript == "" || len(change.Added) == 0 {
+		return
+	}
+	for _, script := range app.scripts {
+		if script.Name == app.hotplugScript {
+			app.setStatus(fmt.Sprintf("[yellow]偵測到新面板，執行 Lua 腳本: %s[-]", script.Name))
+			go app.executeLuaScript(script)
+			return
+		}
+	}
+	app.setStatus(fmt.Sprintf("[red]找不到熱插拔腳本 %s.lua[-]", app.hotplugScript))
+}
+
+// selectDisplay 依連接埠與 EDID 內容在清單中選取對應的顯示器。
+func (app *App) selectDisplay(target *display.Display) {
+	for i, d := range app.displays {
+		if d.DeviceID == target.DeviceID && bytes.Equal(d.EDID, target.EDID) {
+			app.displayList.SetCurrentItem(i)
+			app.updateTable(d)
+			return
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/i2cscan"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// i2cScanView 保存 I2C 掃描頁面的元件與選項。
+type i2cScanView struct {
+	root    *tview.Flex
+	form    *tview.Form
+	output  *tview.TextView
+	start   string
+	end     string
+	dump    bool
+	running bool
+}
+
+// showI2CScanView 開啟 I2C-over-AUX 匯流排掃描頁面。
+func (app *App) showI2CScanView() {
+	view := &i2cScanView{
+		start: fmt.Sprintf("0x%02X", i2cscan.DefaultStart),
+		end:   fmt.Sprintf("0x%02X", i2cscan.DefaultEnd),
+	}
+
+	view.form = tview.NewForm().
+		AddInputField("起始位址", view.start, 6, nil, func(text string) { view.start = strings.TrimSpace(text) }).
+		AddInputField("結束位址", view.end, 6, nil, func(text string) { view.end = strings.TrimSpace(text) }).
+		AddCheckbox("傾印前 256 位元組", false, func(checked bool) { view.dump = checked }).
+		AddButton("掃描", func() { app.runI2CScan(view) }).
+		AddButton("關閉", app.closeView)
+	view.form.SetBorder(true).
+		SetTitle(" I2C Scan ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	view.form.SetCancelFunc(app.closeView)
+
+	view.output = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.output.SetBorder(true).
+		SetTitle(" Result ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	fmt.Fprintln(view.output, "依序以 1 位元組讀取探測每個 7-bit 從屬位址，讀取成功即視為有回應。")
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(view.form, 34, 0, true).
+		AddItem(view.output, 0, 1, false)
+
+	view.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(view.root, view.form)
+}
+
+// parseSlaveAddress 解析 7-bit 從屬位址。
+func parseSlaveAddress(text string) (byte, error) {
+	v, err := strconv.ParseUint(text, 0, 8)
+	if err != nil || v > 0x7F {
+		return 0, fmt.Errorf("從屬位址 %q 無效，需為 0x00~0x7F", text)
+	}
+	return byte(v), nil
+}
+
+// runI2CScan 於背景掃描並將結果輸出至頁面。
+func (app *App) runI2CScan(view *i2cScanView) {
+	if view.running {
+		return
+	}
+	start, err := parseSlaveAddress(view.start)
+	if err != nil {
+		app.showModal(err.Error())
+		return
+	}
+	end, err := parseSlaveAddress(view.end)
+	if err != nil {
+		app.showModal(err.Error())
+		return
+	}
+	if start > end {
+		app.showModal("起始位址不可大於結束位址")
+		return
+	}
+	dump := view.dump
+
+	view.running = true
+	view.output.Clear()
+
+	go func() {
+		defer app.app.QueueUpdate(func() { view.running = false })
+
+		driver, err := app.auxDriver()
+		if err != nil || driver == nil {
+			message := app.describeGPUError(err)
+			app.app.QueueUpdateDraw(func() {
+				fmt.Fprintf(view.output, "[red]GPU 驅動不可用: %s[-]\n", tview.Escape(message))
+			})
+			return
+		}
+
+		devices, err := i2cscan.Scan(driver, i2cscan.Options{
+			Start: start,
+			End:   end,
+			Dump:  dump,
+			Progress: func(addr byte) {
+				app.queueSetStatus(fmt.Sprintf("[yellow]探測 I2C 0x%02X...[-]", addr))
+			},
+		})
+		if err != nil {
+			app.queueSetStatus(fmt.Sprintf("[red]I2C 掃描失敗: %v[-]", err))
+			return
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "[yellow]0x%02X-0x%02X via %s：%d 個位址有回應[-]\n\n", start, end, tview.Escape(driver.Name()), len(devices))
+		for _, d := range devices {
+			fmt.Fprintf(&b, "[aqua]%s[-]\n", tview.Escape(d.String()))
+			if !dump {
+				continue
+			}
+			if len(d.Dump) > 0 {
+				fmt.Fprintln(&b, tview.Escape(i2cscan.FormatDump(d.Dump)))
+			}
+			if d.DumpErr != nil {
+				fmt.Fprintf(&b, "[red]%s[-]\n\n", tview.Escape(d.DumpErr.Error()))
+			}
+		}
+		app.app.QueueUpdateDraw(func() {
+			view.output.SetText(b.String())
+			view.output.ScrollToBeginning()
+			app.setStatus(fmt.Sprintf("[green]I2C 掃描完成，%d 個位址有回應[-]", len(devices)))
+		})
+	}()
+}
+
+// luaScanI2C 提供 Lua 的 scan_i2c([dump], [start], [end])，回傳有回應裝置的陣列表。
+func luaScanI2C(driver gpu.Driver, describeError func() string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		if driver == nil {
+			L.Push(lua.LNil)
+			L.Push(lua.LString(describeError()))
+			return 2
+		}
+		opts := i2cscan.Options{
+			Dump:  L.OptBool(1, false),
+			Start: byte(L.OptInt(2, i2cscan.DefaultStart)),
+			End:   byte(L.OptInt(3, i2cscan.DefaultEnd)),
+		}
+		devices, err := i2cscan.Scan(driver, opts)
+		if err != nil {
+			L.Push(lua.LNil)
+			L.Push(lua.LString(err.Error()))
+			return 2
+		}
+		result := L.NewTable()
+		for i, d := range devices {
+			entry := L.NewTable()
+			entry.RawSetString("address", lua.LNumber(d.Address))
+			entry.RawSetString("name", lua.LString(d.Name))
+			if d.Dump != nil {
+				dumpTbl := L.NewTable()
+				for j, b := range d.Dump {
+					dumpTbl.RawSetInt(j+1, lua.LNumber(b))
+				}
+				entry.RawSetString("dump", dumpTbl)
+			}
+			if d.DumpErr != nil {
+				entry.RawSetString("dump_error", lua.LString(d.DumpErr.Error()))
+			}
+			result.RawSetInt(i+1, entry)
+		}
+		L.Push(result)
+		return 1
+	}
+}
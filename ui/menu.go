@@ -0,0 +1,61 @@
+package ui
+
+import "GMTAUXOneKeyBuild/config"
+
+// mainMenuItem 為主選單項目；id 供 handleMainMenu 分派與設定檔的 key_bindings 使用。
+type mainMenuItem struct {
+	id      string
+	key     rune // 預設快捷鍵
+	label   string
+	help    string
+	labelEN string
+	helpEN  string
+}
+
+// mainMenuItems 依顯示順序列出主選單項目。
+var mainMenuItems = []mainMenuItem{
+	{"refresh", 'r', "重新偵測螢幕", "刷新顯示器列表", "Rescan displays", "Refresh the display list"},
+	{"reload_scripts", 'l', "重新載入 Lua 腳本", "重新掃描 scripts 目錄", "Reload Lua scripts", "Rescan the scripts directory"},
+	{"recipe", 'b', "執行 One Key Build 配方", "依 recipes 目錄的配方逐步燒錄", "Run One Key Build recipe", "Program step by step from a recipe"},
+	{"acc", 'g', "ACC / Gamma 校正", "匯入、編輯與燒錄 ACC 對照表", "ACC / Gamma calibration", "Import, edit and program ACC tables"},
+	{"dpcd_decode", 'p', "DPCD 暫存器解碼", "依 DP/eDP 規格解讀 DPCD 欄位", "DPCD register decoder", "Decode DPCD fields per the DP/eDP spec"},
+	{"dpcd_hex", 'x', "DPCD 十六進位編輯", "瀏覽、比對並修改 DPCD 位址空間", "DPCD hex editor", "Browse, compare and modify the DPCD space"},
+	{"sink_edid", 'e', "由面板讀取 EDID", "以 I2C 0x50/0x30 直接讀取面板 EEPROM", "Read EDID from panel", "Read the panel EEPROM over I2C 0x50/0x30"},
+	{"edid_file", 'f', "EDID 檔案載入/另存", "由 .bin/.hex/.dat/.inf 檔案或貼上文字檢查 EDID", "Load/save EDID file", "Inspect EDID from .bin/.hex/.dat/.inf or pasted text"},
+	{"edid_editor", 'i', "EDID 編輯器", "以表單修改 EDID 欄位並自動重算檢查碼", "EDID editor", "Edit EDID fields with automatic checksums"},
+	{"edid_diff", 'm', "EDID 比較", "逐欄位比較顯示器、面板直讀與檔案的 EDID", "EDID compare", "Compare EDID from displays, panels and files"},
+	{"i2c_scan", 's', "I2C 匯流排掃描", "探測 I2C-over-AUX 上有回應的從屬位址", "I2C bus scan", "Probe responding I2C-over-AUX addresses"},
+	{"ddc", 'c', "DDC/CI 螢幕調整", "以 MCCS 讀寫外接螢幕的 VCP 設定", "DDC/CI monitor control", "Read and write MCCS VCP settings"},
+	{"export", 'j', "匯出顯示器 JSON", "將顯示器、原始 EDID 與解析警告存成 JSON", "Export displays as JSON", "Save displays, raw EDID and warnings as JSON"},
+	{"driver", 'v', "選擇 GPU 驅動", "為目前顯示器指定驅動（auto、igfx、igcl、nvidia、sim、遠端）", "Select GPU driver", "Choose the driver backend for the selected display"},
+	{"dry_run", 'n', "切換 Dry-run 模式", "只記錄腳本與配方的寫入，不觸及硬體", "Toggle dry-run mode", "Record script and recipe writes only"},
+	{"settings", 'o', "設定", "編輯目錄、驅動、延遲、逾時、重試、記錄與快捷鍵", "Settings", "Edit directories, drivers, delays, timeouts, retries, logs and keys"},
+	{"display_list", 'd', "切換至螢幕列表", "將焦點移到螢幕選單", "Go to display list", "Move focus to the display list"},
+	{"quit", 'q', "離開", "結束應用程式", "Quit", "Exit the application"},
+}
+
+// populateMainMenu 依設定的語言與快捷鍵重建主選單，並保留目前選取的項目。
+func (app *App) populateMainMenu() {
+	current := app.mainMenu.GetCurrentItem()
+	app.mainMenu.Clear()
+	for _, item := range mainMenuItems {
+		label, help := item.label, item.help
+		if app.config.Language == config.LanguageEnglish {
+			label, help = item.labelEN, item.helpEN
+		}
+		app.mainMenu.AddItem(label, help, app.menuKey(item), nil)
+	}
+	app.mainMenu.SetCurrentItem(current)
+}
+
+// menuKey 回傳項目的快捷鍵；設定檔指定空字串時停用該項目的快捷鍵。
+func (app *App) menuKey(item mainMenuItem) rune {
+	key, ok := app.config.KeyBindings[item.id]
+	if !ok {
+		return item.key
+	}
+	for _, r := range key {
+		return r
+	}
+	return 0
+}
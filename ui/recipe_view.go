@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/recipe"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// recipeView 保存配方執行頁面的元件與目前選取的配方。
+type recipeView struct {
+	root    *tview.Flex
+	list    *tview.List
+	form    *tview.Form
+	log     *tview.TextView
+	recipes []*recipe.Recipe
+	current *recipe.Recipe
+	vars    map[string]string
+	running bool
+}
+
+// showRecipeView 開啟 One Key Build 配方頁面。
+func (app *App) showRecipeView() {
+	paths, err := recipe.List(app.recipesDir)
+	if err != nil {
+		app.showModal(fmt.Sprintf("配方載入失敗: %v", err))
+		return
+	}
+
+	view := &recipeView{vars: map[string]string{}}
+	var loadErrs []string
+	for _, path := range paths {
+		r, err := recipe.Load(path)
+		if err != nil {
+			loadErrs = append(loadErrs, err.Error())
+			continue
+		}
+		view.recipes = append(view.recipes, r)
+	}
+	if len(view.recipes) == 0 {
+		message := fmt.Sprintf("未找到任何配方\n請將 .json 配方放入 %s 目錄", app.recipesDir)
+		if len(loadErrs) > 0 {
+			message = strings.Join(loadErrs, "\n")
+		}
+		app.showModal(message)
+		return
+	}
+
+	view.list = tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true)
+	view.list.SetBorder(true).
+		SetTitle(" Recipes ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	for _, r := range view.recipes {
+		view.list.AddItem(r.Name, "", 0, nil)
+	}
+
+	view.form = tview.NewForm()
+	view.form.SetBorder(true).
+		SetTitle(" 參數 ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	view.form.SetCancelFunc(app.closeView)
+
+	view.log = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.log.SetBorder(true).
+		SetTitle(" Log ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+
+	view.list.SetChangedFunc(func(index int, _, _ string, _ rune) {
+		app.selectRecipe(view, index)
+	})
+	view.list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		app.selectRecipe(view, index)
+		app.app.SetFocus(view.form)
+	})
+
+	// Tab 在配方清單與參數表單之間切換焦點。
+	view.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyTAB || event.Key() == tcell.KeyBacktab {
+			app.app.SetFocus(view.form)
+			return nil
+		}
+		return event
+	})
+
+	left := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(view.list, 0, 1, true).
+		AddItem(view.form, 0, 1, false)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(left, 0, 1, true).
+		AddItem(view.log, 0, 2, false)
+
+	view.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.selectRecipe(view, 0)
+	app.showView(view.root, view.list)
+	if len(loadErrs) > 0 {
+		app.setStatus(fmt.Sprintf("[yellow]部分配方載入失敗: %s[-]", strings.Join(loadErrs, "; ")))
+	}
+}
+
+// selectRecipe 切換配方並依其變數重建參數表單。
+func (app *App) selectRecipe(view *recipeView, index int) {
+	if index < 0 || index >= len(view.recipes) || view.running {
+		return
+	}
+	r := view.recipes[index]
+	view.current = r
+
+	view.form.Clear(true)
+	for _, name := range r.Variables() {
+		name := name
+		view.form.AddInputField(name, view.vars[name], 24, nil, func(text string) {
+			view.vars[name] = strings.TrimSpace(text)
+		})
+	}
+	view.form.
+		AddButton("執行", func() { app.runRecipe(view) }).
+		AddButton("關閉", app.closeView)
+	view.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyBacktab {
+			item, button := view.form.GetFocusedItemIndex()
+			if item == 0 || (view.form.GetFormItemCount() == 0 && button == 0) {
+				app.app.SetFocus(view.list)
+				return nil
+			}
+		}
+		return event
+	})
+
+	view.log.Clear()
+	fmt.Fprintf(view.log, "[yellow]%s[-]\n", tview.Escape(r.Name))
+	if r.Description != "" {
+		fmt.Fprintf(view.log, "%s\n", tview.Escape(r.Description))
+	}
+	fmt.Fprintf(view.log, "檔案: %s\n", tview.Escape(filepath.Base(r.Path)))
+	for i, step := range r.Steps {
+		name := step.Name
+		if name == "" {
+			name = string(step.Action)
+		}
+		fmt.Fprintf(view.log, "  %2d. %-14s %s\n", i+1, step.Action, tview.Escape(name))
+	}
+}
+
+// runRecipe 於背景執行目前選取的配方，並將日誌與報告輸出至頁面。
+func (app *App) runRecipe(view *recipeView) {
+	if view.running || view.current == nil {
+		return
+	}
+	r := view.current
+	for _, name := range r.Variables() {
+		if view.vars[name] == "" {
+			app.showModal(fmt.Sprintf("請先輸入參數 %s", name))
+			return
+		}
+	}
+
+	vars := make(map[string]string, len(view.vars))
+	for k, v := range view.vars {
+		vars[k] = v
+	}
+
+	view.running = true
+	view.log.Clear()
+	if app.dryRun {
+		app.setStatus(fmt.Sprintf("[yellow]Dry-run 配方: %s[-]", r.Name))
+	} else {
+		app.setStatus(fmt.Sprintf("[yellow]執行配方: %s[-]", r.Name))
+	}
+
+	go func() {
+		defer app.app.QueueUpdate(func() { view.running = false })
+
+		logf := func(format string, args ...interface{}) {
+			line := fmt.Sprintf(format, args...)
+			app.app.QueueUpdateDraw(func() {
+				fmt.Fprintln(view.log, tview.Escape(line))
+				view.log.ScrollToEnd()
+			})
+		}
+
+		var driver gpu.Driver
+		if app.dryRun {
+			// dry-run 的寫入會列在報告末尾，供燒錄前審閱。
+			dryRun, err := app.newDryRunDriver()
+			if err != nil {
+				logf("Dry-run 快照載入失敗: %v", err)
+				app.queueSetStatus("[red]配方執行失敗：Dry-run 快照載入失敗[-]")
+				return
+			}
+			driver = dryRun
+		} else {
+			var err error
+			driver, err = app.ensureGPUDriver()
+			if err != nil || driver == nil {
+				logf("GPU 驅動不可用: %s", app.describeGPUError(err))
+				app.queueSetStatus("[red]配方執行失敗：GPU 驅動不可用[-]")
+				return
+			}
+		}
+
+		executor := recipe.NewExecutor(driver)
+		executor.Vars = vars
+		executor.Logf = logf
+		retries := driverRetries(driver)
+		report, runErr := executor.Run(r)
+		if n := driverRetries(driver) - retries; n > 0 {
+			logf("AUX 暫時性錯誤重試 %d 次", n)
+		}
+		if report != nil {
+			logf("%s", strings.TrimRight(report.String(), "\n"))
+			reportPath := filepath.Join(app.reportsDir, report.FileName(r.Path))
+			if err := os.MkdirAll(app.reportsDir, 0o755); err != nil {
+				logf("報告儲存失敗: %v", err)
+			} else if err := report.Save(reportPath); err != nil {
+				logf("報告儲存失敗: %v", err)
+			} else {
+				logf("報告已儲存: %s", reportPath)
+			}
+		}
+		if runErr != nil {
+			app.queueSetStatus(fmt.Sprintf("[red]配方「%s」失敗: %v[-]", r.Name, runErr))
+			return
+		}
+		app.queueSetStatus(fmt.Sprintf("[green]配方「%s」完成 (%s)[-]", r.Name, time.Since(report.Started).Round(time.Millisecond)) + retryNote(driver, retries))
+	}()
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"GMTAUXOneKeyBuild/config"
+	"GMTAUXOneKeyBuild/gpu"
+	"GMTAUXOneKeyBuild/remote"
+)
+
+// SetRemote 設定遠端測試機的位址與權杖，設定後 GPU 驅動改由遠端伺服器提供。
+func (app *App) SetRemote(addr, token string) {
+	app.remoteAddr = addr
+	app.remoteToken = token
+}
+
+// remoteDriver 連線至遠端測試機並快取連線；未設定遠端位址時回傳 gpu.ErrNoDriver。
+func (app *App) remoteDriver() (gpu.Driver, error) {
+	if app.remoteAddr == "" {
+		return nil, gpu.ErrNoDriver
+	}
+	return app.dialRemote(app.remoteAddr)
+}
+
+// dialRemote 連線至指定的遠端測試機並以位址快取連線。
+func (app *App) dialRemote(addr string) (gpu.Driver, error) {
+	return app.cachedGPUDriver("remote:"+addr, func() (gpu.Driver, error) {
+		return remote.DialTimeout(addr, app.remoteToken, config.Millis(app.config.Timeouts.RemoteMS))
+	})
+}
@@ -0,0 +1,373 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"GMTAUXOneKeyBuild/config"
+	"GMTAUXOneKeyBuild/edidhelper"
+	"GMTAUXOneKeyBuild/gpu"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// driverChoices 為設定畫面中各廠牌可選的驅動。
+var driverChoices = []struct {
+	vendor  string
+	label   string
+	options []string
+}{
+	{"intel", "Intel 驅動", []string{config.DriverAuto, "intel-igfx", "intel-igcl"}},
+	{"nvidia", "NVIDIA 驅動", []string{config.DriverAuto, "nvidia"}},
+	{"default", "其他顯示卡驅動", []string{config.DriverAuto, "drm-aux"}},
+}
+
+// Config 回傳目前套用的設定。
+func (app *App) Config() config.Config {
+	return app.config
+}
+
+// LoadConfig 由指定路徑載入並套用設定，之後設定畫面也會儲存至該檔案。
+func (app *App) LoadConfig(path string) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	app.configPath = path
+	app.configErr = nil
+	return app.applyConfig(cfg)
+}
+
+// applyConfig 套用設定至目錄、延遲、驅動偏好、記錄檔與主選單。
+func (app *App) applyConfig(cfg config.Config) error {
+	// 驅動偏好或建立驅動時使用的參數改變時，捨棄快取讓下次操作重新偵測。
+	resetDrivers := !maps.Equal(app.config.Drivers, cfg.Drivers) ||
+		app.config.AUX.IntelCUIDelayMS != cfg.AUX.IntelCUIDelayMS ||
+		app.config.Timeouts.RemoteMS != cfg.Timeouts.RemoteMS ||
+		app.config.Retry != cfg.Retry
+
+	app.config = cfg
+	app.scriptsDir = cfg.ScriptsDir
+	app.recipesDir = cfg.RecipesDir
+	app.reportsDir = cfg.Logs.ReportsDir
+	app.panelsPath = cfg.PanelsFile
+	app.snapshotPath = cfg.SnapshotFile
+	app.SetHotplug(cfg.Hotplug.Enabled, cfg.Hotplug.Script)
+	gpu.IntelCUIDelay = config.Millis(cfg.AUX.IntelCUIDelayMS)
+	edidhelper.EDIDWriteDelay = config.Millis(cfg.AUX.EDIDWriteDelayMS)
+	if resetDrivers {
+		app.resetGPUDrivers()
+	}
+	app.populateMainMenu()
+
+	if err := app.openLogFile(cfg.Logs.LogFile); err != nil {
+		return err
+	}
+	return menuKeyConflicts(cfg)
+}
+
+// resetGPUDrivers 清除驅動快取並關閉可關閉的驅動（例如遠端連線）。
+func (app *App) resetGPUDrivers() {
+	app.gpuDetectMu.Lock()
+	defer app.gpuDetectMu.Unlock()
+	for _, driver := range app.gpuDrivers {
+		if closer, ok := driver.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	clear(app.gpuDrivers)
+	clear(app.gpuDetectErrs)
+}
+
+// resetLocalGPUDrivers 清除本機硬體驅動的快取（含偵測失敗的記錄），保留模擬驅動與遠端連線，
+// 讓熱插拔後的操作重新偵測連接埠。
+func (app *App) resetLocalGPUDrivers() {
+	app.gpuDetectMu.Lock()
+	defer app.gpuDetectMu.Unlock()
+	for key := range app.gpuDetectErrs {
+		if key == driverSim || strings.HasPrefix(key, driverRemote+":") {
+			continue
+		}
+		if closer, ok := app.gpuDrivers[key].(io.Closer); ok {
+			closer.Close()
+		}
+		delete(app.gpuDrivers, key)
+		delete(app.gpuDetectErrs, key)
+	}
+}
+
+// menuKeyConflicts 檢查 key_bindings 的項目名稱，以及套用後的快捷鍵是否重複。
+func menuKeyConflicts(cfg config.Config) error {
+	known := make(map[string]bool, len(mainMenuItems))
+	for _, item := range mainMenuItems {
+		known[item.id] = true
+	}
+	for id := range cfg.KeyBindings {
+		if !known[id] {
+			return fmt.Errorf("unknown menu item %q in key_bindings", id)
+		}
+	}
+
+	used := map[rune]string{}
+	for _, item := range mainMenuItems {
+		key := item.key
+		if bound, ok := cfg.KeyBindings[item.id]; ok {
+			key = 0
+			for _, r := range bound {
+				key = r
+			}
+		}
+		if key == 0 {
+			continue
+		}
+		if other, ok := used[key]; ok {
+			return fmt.Errorf("key %q is bound to both %s and %s", key, other, item.id)
+		}
+		used[key] = item.id
+	}
+	return nil
+}
+
+// openLogFile 開啟狀態列訊息記錄檔（附加寫入）；path 為空字串時停止記錄。
+func (app *App) openLogFile(path string) error {
+	if app.logFile != nil && app.logFile.Name() == path {
+		return nil
+	}
+	app.closeLogFile()
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	app.logFile = file
+	app.logger = log.New(file, "", log.LstdFlags)
+	return nil
+}
+
+// closeLogFile 關閉記錄檔。
+func (app *App) closeLogFile() {
+	if app.logFile != nil {
+		app.logFile.Close()
+	}
+	app.logFile = nil
+	app.logger = nil
+}
+
+// colorTagPattern 比對狀態列訊息中的 tview 顏色標籤，例如 [red]、[#ff0000] 與 [-]。
+var colorTagPattern = regexp.MustCompile(`\[(?:[a-zA-Z]+|#[0-9a-fA-F]{6}|-)(?::[^\[\]]*)?\]`)
+
+// stripColorTags 移除顏色標籤，供寫入記錄檔使用。
+func stripColorTags(message string) string {
+	return colorTagPattern.ReplaceAllString(message, "")
+}
+
+// showSettingsView 開啟設定頁面，儲存後寫入設定檔並立即套用。
+func (app *App) showSettingsView() {
+	app.showSettingsForm(app.config)
+}
+
+// showSettingsForm 以 cfg 為初始值建立設定表單。
+func (app *App) showSettingsForm(cfg config.Config) {
+	cfg.Drivers = maps.Clone(cfg.Drivers)
+	if cfg.Drivers == nil {
+		cfg.Drivers = map[string]string{}
+	}
+
+	form := tview.NewForm()
+	for _, field := range []struct {
+		label string
+		value *string
+	}{
+		{"腳本目錄", &cfg.ScriptsDir},
+		{"配方目錄", &cfg.RecipesDir},
+		{"報告目錄", &cfg.Logs.ReportsDir},
+		{"面板型號檔", &cfg.PanelsFile},
+		{"Dry-run 快照檔", &cfg.SnapshotFile},
+		{"記錄檔（空白不記錄）", &cfg.Logs.LogFile},
+	} {
+		form.AddInputField(field.label, *field.value, 40, nil, func(text string) {
+			*field.value = strings.TrimSpace(text)
+		})
+	}
+
+	for _, choice := range driverChoices {
+		options := choice.options
+		current := cfg.Drivers[choice.vendor]
+		if current == "" {
+			current = config.DriverAuto
+		}
+		index := slices.Index(options, current)
+		if index < 0 {
+			// 保留設定檔中手動填寫的驅動名稱。
+			options = append(append([]string(nil), options...), current)
+			index = len(options) - 1
+		}
+		form.AddDropDown(choice.label, options, index, func(option string, _ int) {
+			if option == config.DriverAuto {
+				delete(cfg.Drivers, choice.vendor)
+				return
+			}
+			cfg.Drivers[choice.vendor] = option
+		})
+	}
+
+	for _, field := range []struct {
+		label string
+		value *int
+	}{
+		{"Intel CUI 延遲 (ms)", &cfg.AUX.IntelCUIDelayMS},
+		{"EDID 寫入延遲 (ms)", &cfg.AUX.EDIDWriteDelayMS},
+		{"DDC 回覆延遲 (ms)", &cfg.AUX.DDCReplyDelayMS},
+		{"DDC 指令間隔 (ms)", &cfg.AUX.DDCCommandDelayMS},
+		{"遠端逾時 (ms)", &cfg.Timeouts.RemoteMS},
+		{"ACC 輪詢逾時 (ms)", &cfg.Timeouts.ACCPollMS},
+		{"重試次數", &cfg.Retry.MaxAttempts},
+		{"重試等待 (ms)", &cfg.Retry.BackoffMS},
+		{"重試等待上限 (ms)", &cfg.Retry.MaxBackoffMS},
+	} {
+		form.AddInputField(field.label, strconv.Itoa(*field.value), 8, tview.InputFieldInteger, func(text string) {
+			value, err := strconv.Atoi(text)
+			if err != nil {
+				// 空白或無效數值交由 Validate 回報超出範圍。
+				value = -1
+			}
+			*field.value = value
+		})
+	}
+
+	languages := []string{config.LanguageChinese, config.LanguageEnglish}
+	form.AddDropDown("選單語言", languages, max(slices.Index(languages, cfg.Language), 0), func(option string, _ int) {
+		cfg.Language = option
+	})
+	form.AddCheckbox("寫入逾時也重試", cfg.Retry.WriteTimeout, func(checked bool) {
+		cfg.Retry.WriteTimeout = checked
+	})
+	form.AddCheckbox("熱插拔偵測", cfg.Hotplug.Enabled, func(checked bool) {
+		cfg.Hotplug.Enabled = checked
+	})
+	form.AddInputField("熱插拔腳本", cfg.Hotplug.Script, 24, nil, func(text string) {
+		cfg.Hotplug.Script = strings.TrimSpace(text)
+	})
+
+	bindings := formatKeyBindings(cfg)
+	form.AddTextArea("快捷鍵（項目=按鍵）", bindings, 40, 6, 0, func(text string) {
+		bindings = text
+	})
+
+	form.AddButton("儲存", func() {
+		keys, err := parseKeyBindings(bindings)
+		if err != nil {
+			app.showModal(fmt.Sprintf("快捷鍵格式錯誤：%v", err))
+			return
+		}
+		cfg.KeyBindings = keys
+		cfg.FillDefaults()
+		if err := menuKeyConflicts(cfg); err != nil {
+			app.showModal(fmt.Sprintf("快捷鍵設定錯誤：%v", err))
+			return
+		}
+		if err := config.Save(app.configPath, cfg); err != nil {
+			app.showModal(fmt.Sprintf("設定儲存失敗：%v", err))
+			return
+		}
+		app.saveSettings(cfg)
+	}).
+		AddButton("還原預設", func() {
+			app.showSettingsForm(config.Default())
+			app.setStatus("[yellow]已填入預設值，按「儲存」後生效[-]")
+		}).
+		AddButton("取消", app.closeView)
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Settings (%s) ", app.configPath)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitleColor(tcell.ColorYellow)
+	form.SetCancelFunc(app.closeView)
+
+	root := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(app.statusBar, 1, 0, false)
+
+	app.showView(root, form)
+}
+
+// saveSettings 套用已儲存的設定，並依變更重新載入腳本與重新啟動熱插拔監看。
+func (app *App) saveSettings(cfg config.Config) {
+	previous := app.config
+	app.closeView()
+	if err := app.applyConfig(cfg); err != nil {
+		app.showModal(fmt.Sprintf("設定已儲存，但套用時發生錯誤：%v", err))
+	}
+	if previous.ScriptsDir != cfg.ScriptsDir {
+		if err := app.refreshScripts(); err != nil {
+			app.setStatus(fmt.Sprintf("[red]Lua 腳本載入失敗: %v[-]", err))
+			return
+		}
+	}
+	if previous.Hotplug.Enabled != cfg.Hotplug.Enabled {
+		app.stopHotplug()
+		if err := app.startHotplug(); err != nil {
+			app.setStatus(fmt.Sprintf("[yellow]無法監看顯示器插拔，請手動重新偵測: %v[-]", err))
+			return
+		}
+	}
+	app.setStatus(fmt.Sprintf("[green]設定已儲存至 %s[-]", app.configPath))
+}
+
+// formatKeyBindings 以「項目=按鍵」逐行列出所有主選單項目目前的快捷鍵。
+func formatKeyBindings(cfg config.Config) string {
+	var b strings.Builder
+	for _, item := range mainMenuItems {
+		key := string(item.key)
+		if bound, ok := cfg.KeyBindings[item.id]; ok {
+			key = bound
+		}
+		fmt.Fprintf(&b, "%s=%s\n", item.id, key)
+	}
+	return b.String()
+}
+
+// parseKeyBindings 解析「項目=按鍵」文字，只保留與預設值不同的項目；按鍵留白表示停用。
+func parseKeyBindings(text string) (map[string]string, error) {
+	defaults := make(map[string]string, len(mainMenuItems))
+	for _, item := range mainMenuItems {
+		defaults[item.id] = string(item.key)
+	}
+	keys := map[string]string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		id, key, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("missing '=' in %q", line)
+		}
+		id, key = strings.TrimSpace(id), strings.TrimSpace(key)
+		if len([]rune(key)) > 1 {
+			return nil, fmt.Errorf("key for %s must be a single character, got %q", id, key)
+		}
+		if defaults[id] != key {
+			keys[id] = key
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return keys, nil
+}